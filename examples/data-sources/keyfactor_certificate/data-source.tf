@@ -34,3 +34,8 @@ data "keyfactor_certificate" "cert_wo_pkey_id" {
   identifier = "1" # Using Keyfactor Command certificate ID
 }
 
+
+output "cert_wo_pkey_id_locations" {
+  # Every certificate store the certificate is deployed to
+  value = [for location in data.keyfactor_certificate.cert_wo_pkey_id.locations : "${location.client_machine}:${location.store_path}/${location.alias}"]
+}
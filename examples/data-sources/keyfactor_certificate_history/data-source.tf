@@ -0,0 +1,14 @@
+provider "keyfactor" {
+  username = "COMMAND\\your_username"
+  password = "your_api_password"
+  hostname = "mykfinstance.kfdelivery.com"
+}
+
+data "keyfactor_certificate_history" "web_cert" {
+  certificate_id = keyfactor_certificate.web_cert.certificate_id
+}
+
+output "web_cert_issued_by" {
+  # Users behind every event recorded for the certificate
+  value = distinct([for event in data.keyfactor_certificate_history.web_cert.history : event.user])
+}
@@ -4,8 +4,14 @@ provider "keyfactor" {
   hostname = "mykfinstance.kfdelivery.com"
 }
 
-# Both client_machine and store_path are required to do a lookup
+# Lookup by client_machine and store_path, add store_type when several store types share the path
 data "keyfactor_certificate_store" "k8s_cluster_store_lookup" {
   client_machine = "192.168.0.4"
   store_path     = "/home/azureuser/certs"
+  store_type     = "PEM"
+}
+
+# Lookup by the store GUID
+data "keyfactor_certificate_store" "store_by_id" {
+  id = "9f8855f1-80ff-4475-89ec-d82accb32cea"
 }
\ No newline at end of file
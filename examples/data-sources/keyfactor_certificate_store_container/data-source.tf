@@ -0,0 +1,21 @@
+provider "keyfactor" {
+  username = "COMMAND\\your_username"
+  password = "your_api_password"
+  hostname = "mykfinstance.kfdelivery.com"
+}
+
+# Lookup a container by its name, or use id to lookup by its integer ID
+data "keyfactor_certificate_store_container" "k8s_clusters" {
+  name = "K8S Clusters"
+}
+
+# Deploy a certificate to every store in the container
+resource "keyfactor_certificate_deployment" "k8s_clusters" {
+  certificate_id = 42
+  dynamic "store" {
+    for_each = data.keyfactor_certificate_store_container.k8s_clusters.stores
+    content {
+      certificate_store_id = store.value.id
+    }
+  }
+}
@@ -0,0 +1,31 @@
+provider "keyfactor" {
+  username = "COMMAND\\your_username"
+  password = "your_api_password"
+  hostname = "mykfinstance.kfdelivery.com"
+}
+
+data "keyfactor_certificate_store_inventory" "iis_server01" {
+  certificate_store_id = "a1b2c3d4-e5f6-7a8b-9c0d-e1f2a3b4c5d6"
+  refresh              = true # Run an inventory job first, on every plan and apply
+}
+
+locals {
+  expired_aliases = [
+    for alias in data.keyfactor_certificate_store_inventory.iis_server01.aliases : alias.alias
+    if length([for certificate in alias.certificates : certificate if certificate.expired]) > 0
+  ]
+}
+
+# Fail the plan when the store holds expired certificates
+resource "terraform_data" "no_expired_certificates" {
+  lifecycle {
+    precondition {
+      condition     = length(local.expired_aliases) == 0
+      error_message = "Certificate store holds expired certificates under aliases ${join(", ", local.expired_aliases)}."
+    }
+  }
+}
+
+output "inventoried_at" {
+  value = data.keyfactor_certificate_store_inventory.iis_server01.inventoried_at
+}
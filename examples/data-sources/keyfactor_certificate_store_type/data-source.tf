@@ -0,0 +1,23 @@
+provider "keyfactor" {
+  username = "COMMAND\\your_username"
+  password = "your_api_password"
+  hostname = "mykfinstance.kfdelivery.com"
+}
+
+# Lookup a store type by its short name, or use id to lookup by its integer ID
+data "keyfactor_certificate_store_type" "k8s_cluster" {
+  short_name = "K8SCluster"
+}
+
+# Use the defaults of the store type's properties, except for the ones set explicitly
+locals {
+  k8s_cluster_properties = merge(
+    {
+      for p in data.keyfactor_certificate_store_type.k8s_cluster.properties : p.name => p.default_value
+      if !p.secret && p.default_value != ""
+    },
+    {
+      IsRootStore = "false"
+    }
+  )
+}
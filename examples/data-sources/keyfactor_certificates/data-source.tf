@@ -0,0 +1,23 @@
+provider "keyfactor" {
+  username = "COMMAND\\your_username"
+  password = "your_api_password"
+  hostname = "mykfinstance.kfdelivery.com"
+}
+
+data "keyfactor_certificates" "expiring_web_certs" {
+  issued_cn_contains = "example.com"
+  template           = "2yrWebServer"
+  expires_before     = "2024-01-01T00:00:00Z"
+  metadata = {
+    "Email-Contact" = "kfadmin@keyfactor.com"
+  }
+}
+
+data "keyfactor_certificates" "query" {
+  query           = "IssuerDN -contains \"My Issuing CA\" AND KeySizeInBits -lt 2048"
+  include_revoked = true
+}
+
+output "expiring_web_cert_thumbprints" {
+  value = [for cert in data.keyfactor_certificates.expiring_web_certs.certificates : cert.thumbprint]
+}
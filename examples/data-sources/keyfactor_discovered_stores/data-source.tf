@@ -0,0 +1,29 @@
+provider "keyfactor" {
+  username = "COMMAND\\your_username"
+  password = "your_api_password"
+  hostname = "mykfinstance.kfdelivery.com"
+}
+
+# Lookup the Java keystores that discovery jobs have found and that aren't approved yet
+data "keyfactor_discovered_stores" "java_keystores" {
+  agent_identifier = "my-orch-10-2"
+  store_type       = "JKS"
+}
+
+output "discovered_java_keystores" {
+  value = data.keyfactor_discovered_stores.java_keystores.stores
+}
+
+# With approve_discovered the discovered store is approved instead of creating a new store. Approved stores no longer
+# appear in the data source, so the store is configured with literal values rather than with for_each over the data source.
+resource "keyfactor_certificate_store" "app01_keystore" {
+  client_machine     = "app01.example.com"
+  store_path         = "/opt/app01/conf/keystore.jks"
+  store_type         = "JKS"
+  agent_identifier   = "my-orch-10-2"
+  store_password     = "my store password"
+  approve_discovered = true
+  inventory_schedule {
+    interval_minutes = 720
+  }
+}
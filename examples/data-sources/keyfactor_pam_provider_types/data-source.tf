@@ -0,0 +1,16 @@
+provider "keyfactor" {
+  username = "COMMAND\\your_username"
+  password = "your_api_password"
+  hostname = "mykfinstance.kfdelivery.com"
+}
+
+data "keyfactor_pam_provider_types" "installed" {}
+
+# Parameters each PAM provider type needs on keyfactor_pam_provider, by provider type name
+output "pam_provider_parameters" {
+  value = {
+    for t in data.keyfactor_pam_provider_types.installed.types : t.name => [
+      for p in t.parameters : p.name if !p.instance_level
+    ]
+  }
+}
@@ -13,6 +13,7 @@ resource "keyfactor_certificate" "pkcs12_enrollment" {
   locality              = "Cleveland"
   organization          = "Keyfactor"
   organizational_unit   = "Engineering"
+  email                 = "pki-admins@keyfactor.com"
   ip_sans               = sort(["192.168.123.2", "172.51.2.4"])
   dns_sans              = sort(["my.pkcs12.io", "My PKCS12 Certificate", "my.pkcs12.co.uk", "Certificate PKCS12 My"])
   uri_sans              = sort(["my.pkcs12.io"])
@@ -23,6 +24,9 @@ resource "keyfactor_certificate" "pkcs12_enrollment" {
     "Email-Contact" = "kfadmin@keyfactor.com"
     "Owner"         = "integrations@keyfactor.com"
   }
+  enrollment_fields = {
+    "Business Justification" = "Public facing web server" # Must be declared on the template
+  }
 }
 
 ## CSR Enrollment
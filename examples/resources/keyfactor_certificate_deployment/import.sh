@@ -0,0 +1,4 @@
+terraform import keyfactor_certificate_deployment.ca_cert_deployment "9f8855f1-80ff-4475-89ec-d82accb32cea/mycert" # Where this is the GUID of the certificate store and the alias of the certificate in it
+terraform import keyfactor_certificate_deployment.ca_cert_deployment "9f8855f1-80ff-4475-89ec-d82accb32cea/65"     # Where this is the GUID of the certificate store and the Keyfactor Command certificate ID, certificate_alias is left unset. Import by alias when the configuration sets certificate_alias, or the deployment is replaced.
+# key_password is not imported. Set it in the configuration after the import, which updates the state without redeploying the certificate.
+# include_private_key, overwrite, rotate_in_place, job_parameters and wait_for_completion aren't stored by Keyfactor Command and are unset after the import, and job_ids is empty.
@@ -32,3 +32,53 @@ resource "keyfactor_certificate_deployment" "ca_cert_deployment" {
   }
 }
 
+
+# Deploy a wildcard certificate to many stores in a single job
+resource "keyfactor_certificate_deployment" "wildcard_deployment" {
+  certificate_id  = data.keyfactor_certificate.ca_cert.certificate_id
+  rotate_in_place = true # Overwrite the alias with the renewed certificate before removing the old one
+
+  store {
+    certificate_store_id = "e0a2e1d6-2e1b-4bb4-8c4b-a1b2c3d4e5f6" # F5 store
+    certificate_alias    = "wildcard"
+    job_parameters = {
+      "Partition" = "Common"
+    }
+  }
+
+  store {
+    certificate_store_id = "7c5c1f0a-9d43-4a57-9e5b-f6e5d4c3b2a1" # IIS store
+    certificate_alias    = "wildcard"
+  }
+
+  store {
+    certificate_store_id = "3b9d6c2e-1f7a-4c8e-b5d4-0a9f8e7d6c5b" # Java trust store
+    certificate_alias    = "wildcard"
+    include_private_key  = false # Only deploy the public certificate
+    overwrite            = false # Fail instead of replacing an existing alias
+  }
+}
+
+# Deploy during a maintenance window without waiting for the job to run
+resource "keyfactor_certificate_deployment" "appliance_deployment" {
+  certificate_id       = data.keyfactor_certificate.ca_cert.certificate_id
+  certificate_store_id = data.keyfactor_certificate_store.my_cert_store.id
+  certificate_alias    = "appliance"
+  wait_for_completion  = false # The job status is checked on later refreshes
+
+  schedule {
+    exactly_once = "2024-01-01T02:00:00Z"
+  }
+}
+
+# Deploy to every store on the web servers in a container, including stores added to it later
+resource "keyfactor_certificate_deployment" "web_servers" {
+  certificate_id    = data.keyfactor_certificate.ca_cert.certificate_id
+  certificate_alias = "webserver"
+  container_name    = "Web Servers"
+  store_query       = "ClientMachine -startswith \"web-\""
+}
+
+output "web_server_store_ids" {
+  value = keyfactor_certificate_deployment.web_servers.selected_store_ids
+}
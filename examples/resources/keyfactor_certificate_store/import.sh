@@ -1 +1,4 @@
-terraform import keyfactor_certificate_store.mystore "9f8855f1-80ff-4475-89ec-d82accb32cea" # Where this is the GUID of the certificate store
\ No newline at end of file
+terraform import keyfactor_certificate_store.mystore "9f8855f1-80ff-4475-89ec-d82accb32cea" # Where this is the GUID of the certificate store
+terraform import keyfactor_certificate_store.mystore "192.168.0.4::/home/azureuser/certs" # Or client_machine::store_path
+terraform import keyfactor_certificate_store.mystore "192.168.0.4::/home/azureuser/certs::PEM" # Or client_machine::store_path::store_type, when several store types share the path
+# agent_identifier is imported as the orchestrator GUID. A configuration that names the orchestrator by its client machine shows an in-place update after the import, which only records the name.
\ No newline at end of file
@@ -14,12 +14,47 @@ resource "keyfactor_certificate_store" "k8scluster_w_container" {
     # This block will vary based on certificate store type
     IsRootStore = false
   }
-  inventory_schedule = "1d"                    # How often to update the inventory
-  container_name     = "K8S Clusters"          # Must exist in KeyFactor Command
-  server_username    = "kubeconfig"            # Optional, only required if store type requires it.
-  server_password    = file("kubeconfig.json") # Optional, only required if store type requires it.
-  server_use_ssl     = true                    # Optional, only required if store type requires it.
-  store_password     = "password"              # Optional, only required if store type requires it.
+  container_name  = "K8S Clusters"          # Must exist in KeyFactor Command
+  server_username = "kubeconfig"            # Optional, only required if store type requires it.
+  server_password = file("kubeconfig.json") # Optional, only required if store type requires it.
+  server_use_ssl  = true                    # Optional, only required if store type requires it.
+  store_password  = "password"              # Optional, only required if store type requires it.
+  inventory_schedule {
+    daily_time = "02:30" # How often to update the inventory, times are UTC
+  }
+}
+
+resource "keyfactor_certificate_store" "pem_weekly_inventory" {
+  client_machine   = "my-web-host"
+  store_path       = "/etc/ssl/certs/web.pem"
+  agent_identifier = "my-orch-10-2"
+  store_type       = "PEM"
+  inventory_schedule {
+    weekly_days = ["Saturday", "Sunday"]
+    weekly_time = "23:00"
+  }
+}
+
+resource "keyfactor_certificate_store" "iis_pam_credentials" {
+  client_machine   = "my-iis-host"
+  store_path       = "My"
+  agent_identifier = "my-orch-10-2"
+  store_type       = "IISU"
+  server_use_ssl   = true
+  server_username_pam { # Credentials are read from the PAM provider by Keyfactor Command and not stored in Terraform state
+    provider_name = "Delinea"
+    parameters = {
+      SecretId        = "42"
+      SecretFieldName = "Username"
+    }
+  }
+  server_password_pam {
+    provider_name = "Delinea"
+    parameters = {
+      SecretId        = "42"
+      SecretFieldName = "Password"
+    }
+  }
 }
 
 
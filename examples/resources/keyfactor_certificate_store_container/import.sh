@@ -0,0 +1 @@
+terraform import keyfactor_certificate_store_container.k8s_clusters "K8S Clusters" # Where this is the name or the integer ID of the certificate store container
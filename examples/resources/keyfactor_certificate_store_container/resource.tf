@@ -0,0 +1,24 @@
+provider "keyfactor" {
+  username = "COMMAND\\your_username"
+  password = "your_api_password"
+  hostname = "mykfinstance.kfdelivery.com"
+}
+
+resource "keyfactor_certificate_store_container" "k8s_clusters" {
+  name                = "K8S Clusters"
+  store_type          = "K8SCluster"
+  overwrite_schedules = true # The schedule below replaces the inventory schedules of the stores in the container
+  inventory_schedule {
+    daily_time = "02:30"
+  }
+}
+
+resource "keyfactor_certificate_store" "k8scluster_w_container" {
+  client_machine   = "my-k8s-host"
+  store_path       = "test-cluster01"
+  agent_identifier = "my-orch-10-2"
+  store_type       = keyfactor_certificate_store_container.k8s_clusters.store_type
+  container_name   = keyfactor_certificate_store_container.k8s_clusters.name
+  server_username  = "kubeconfig"
+  server_password  = file("kubeconfig.json")
+}
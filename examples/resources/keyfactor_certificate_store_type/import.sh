@@ -0,0 +1 @@
+terraform import keyfactor_certificate_store_type.aws_acm "AWS-ACM" # Where this is the short name or the integer ID of the certificate store type
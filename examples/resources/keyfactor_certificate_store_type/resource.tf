@@ -0,0 +1,44 @@
+provider "keyfactor" {
+  username = "COMMAND\\your_username"
+  password = "your_api_password"
+  hostname = "mykfinstance.kfdelivery.com"
+  domain   = "mydomain.com"
+}
+
+resource "keyfactor_certificate_store_type" "aws_acm" {
+  name                 = "AWS Certificate Manager" # Display name in Keyfactor Command
+  short_name           = "AWS-ACM"                 # Used as store_type on keyfactor_certificate_store
+  capability           = "AWS-ACM"                 # Optional, must match the capability of the orchestrator extension. Defaults to short_name
+  server_required      = true                      # Stores need server_username and server_password
+  supports_add         = true
+  supports_remove      = true
+  supports_discovery   = false
+  private_key_allowed  = "Required"
+  custom_alias_allowed = "Optional"
+  job_properties       = ["Description"]
+
+  properties = [
+    {
+      name          = "Region"
+      display_name  = "AWS Region"
+      type          = "MultipleChoice"
+      default_value = "us-east-1,us-east-2,us-west-1,us-west-2" # Allowed values of a MultipleChoice property
+      required      = true
+    },
+    {
+      name          = "UseIAMRole"
+      display_name  = "Use IAM Role"
+      type          = "Bool"
+      default_value = "false"
+    },
+  ]
+
+  entry_parameters = [
+    {
+      name                 = "Tags"
+      display_name         = "Certificate Tags"
+      type                 = "String"
+      required_when_on_add = false
+    },
+  ]
+}
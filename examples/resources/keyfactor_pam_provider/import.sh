@@ -0,0 +1 @@
+terraform import keyfactor_pam_provider.secret_server "Delinea Secret Server" # Where this is the name or the integer ID of the PAM provider
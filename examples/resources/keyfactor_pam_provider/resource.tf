@@ -0,0 +1,44 @@
+provider "keyfactor" {
+  username = "COMMAND\\your_username"
+  password = "your_api_password"
+  hostname = "mykfinstance.kfdelivery.com"
+}
+
+variable "secret_server_password" {
+  type      = string
+  sensitive = true
+}
+
+resource "keyfactor_pam_provider" "secret_server" {
+  name          = "Delinea Secret Server"
+  provider_type = "Delinea-SecretServer"
+  parameters = {
+    Host     = "https://secretserver.example.com/SecretServer"
+    Username = "keyfactor-svc"
+  }
+  secret_parameters = {
+    Password = var.secret_server_password
+  }
+}
+
+# Certificate stores read their credentials from the PAM provider
+resource "keyfactor_certificate_store" "iis" {
+  client_machine   = "my-iis-host"
+  store_path       = "My"
+  agent_identifier = "my-orch-10-2"
+  store_type       = "IISU"
+  server_username_pam {
+    provider_name = keyfactor_pam_provider.secret_server.name
+    parameters = {
+      SecretId        = "42"
+      SecretFieldName = "Username"
+    }
+  }
+  server_password_pam {
+    provider_name = keyfactor_pam_provider.secret_server.name
+    parameters = {
+      SecretId        = "42"
+      SecretFieldName = "Password"
+    }
+  }
+}
@@ -0,0 +1,32 @@
+provider "keyfactor" {
+  username = "COMMAND\\your_username"
+  password = "your_api_password"
+  hostname = "mykfinstance.kfdelivery.com"
+}
+
+resource "keyfactor_store_discovery_job" "java_keystores" {
+  agent_identifier    = "my-orch-10-2"
+  store_type          = "JKS"
+  directories         = ["/opt", "/home"]
+  ignored_directories = ["/opt/backup"]
+  extensions          = ["jks", "keystore"]
+  follow_symlinks     = true
+  schedule {
+    weekly_days = ["Sunday"]
+    weekly_time = "03:00"
+  }
+}
+
+resource "keyfactor_store_discovery_job" "remote_windows" {
+  agent_identifier = "my-orch-10-2"
+  client_machine   = "iis-server01.example.com" # Search a remote machine instead of the orchestrator host
+  store_type       = "WinCert"
+  directories      = ["fullscan"]
+  server_username  = "EXAMPLE\\discovery"
+  server_password_pam {
+    provider_name = "Delinea"
+    parameters = {
+      SecretId = "42"
+    }
+  }
+}
@@ -0,0 +1,120 @@
+package keyfactor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// commandErrorResponse is the error body returned by the Keyfactor Command API.
+type commandErrorResponse struct {
+	ErrorCode string `json:"ErrorCode"`
+	Message   string `json:"Message"`
+}
+
+// commandRequest sends a request to the Keyfactor Command API using the provider credentials. It is used for
+// endpoints that are not exposed by the Keyfactor Go client. When result is not nil the JSON response body is decoded
+// into it.
+func (p provider) commandRequest(
+	ctx context.Context,
+	method string,
+	endpoint string,
+	query url.Values,
+	body interface{},
+	result interface{},
+) error {
+	return p.commandRequestWithHeaders(ctx, method, endpoint, query, nil, body, result)
+}
+
+// commandRequestWithHeaders is commandRequest with additional request headers, for endpoints that take parameters as
+// headers.
+func (p provider) commandRequestWithHeaders(
+	ctx context.Context,
+	method string,
+	endpoint string,
+	query url.Values,
+	headers map[string]string,
+	body interface{},
+	result interface{},
+) error {
+	hostname := strings.TrimSuffix(p.clientAuth.Hostname, "/")
+	if !strings.HasPrefix(hostname, "http://") && !strings.HasPrefix(hostname, "https://") {
+		hostname = "https://" + hostname
+	}
+	apiPath := strings.Trim(p.clientAuth.APIPath, "/")
+	if apiPath == "" {
+		apiPath = DEFAULT_API_PATH
+	}
+	requestURL := fmt.Sprintf("%s/%s/%s", hostname, apiPath, strings.TrimPrefix(endpoint, "/"))
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	var requestBody io.Reader
+	if body != nil {
+		jsonBody, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		requestBody = bytes.NewReader(jsonBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, requestBody)
+	if err != nil {
+		return err
+	}
+
+	username := p.clientAuth.Username
+	if p.clientAuth.Domain != "" && !strings.Contains(username, "\\") && !strings.Contains(username, "@") {
+		username = p.clientAuth.Domain + "\\" + username
+	}
+	req.SetBasicAuth(username, p.clientAuth.Password)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-keyfactor-requested-with", "APIClient")
+	req.Header.Set("x-keyfactor-api-version", "1")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	timeout := p.clientAuth.Timeout
+	if timeout <= 0 {
+		timeout = MAX_WAIT_SECONDS
+	}
+	httpClient := &http.Client{Timeout: time.Duration(timeout) * time.Second}
+
+	tflog.Debug(ctx, fmt.Sprintf("Calling Keyfactor Command API %s %s", method, requestURL))
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var commandErr commandErrorResponse
+		if jErr := json.Unmarshal(respBody, &commandErr); jErr == nil && commandErr.Message != "" {
+			return fmt.Errorf("%d - %s", resp.StatusCode, commandErr.Message)
+		}
+		return fmt.Errorf("%d - %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	if result != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, result); err != nil {
+			return fmt.Errorf("unable to decode response from %s: %s", endpoint, err.Error())
+		}
+	}
+	return nil
+}
@@ -10,13 +10,41 @@ const (
 	DEFAULT_PFX_PASSWORD_SPECIAL_CHAR_COUNT  = 4
 	DEFAULT_PFX_PASSWORD_NUMBER_COUNT        = 4
 	DEFAULT_PFX_PASSWORD_UPPER_COUNT         = 4
+	DEFAULT_API_PATH                         = "KeyfactorAPI"
+	DEFAULT_QUERY_RETURN_SIZE                = 100
+	MAX_DEPLOYMENT_CONFIRMATION_LOOPS        = 5
+	MAX_INVENTORY_WAIT_LOOPS                 = 20
+	MAX_ORCHESTRATOR_JOB_WAIT_SECONDS        = 1800
 	ERR_SUMMARY_INVALID_CERTIFICATE_RESOURCE = "Invalid certificate resource definition."
 	ERR_SUMMARY_CERTIFICATE_RESOURCE_CREATE  = "Unable to create Keyfactor Command certificate."
 	ERR_SUMMARY_CERTIFICATE_RESOURCE_READ    = "Unable to read Keyfactor Command certificate."
 	ERR_SUMMARY_CERT_STORE_READ              = "Unable to read Keyfactor Command certificate store."
 	ERR_SUMMARY_AGENT_READ                   = "Unable to read Keyfactor Command agent."
 	ERR_SUMMARY_TEMPLATE_READ                = "Unable to read Keyfactor Command template."
+	ERR_SUMMARY_TEMPLATE_POLICY              = "Certificate does not meet Keyfactor Command template policy."
+	ERR_SUMMARY_DEPLOYMENT_CONFIG            = "Invalid certificate deployment resource definition."
 	ERR_SUMMARY_IDENTITY_DELETE              = "Unable to delete security identity."
+	ERR_SUMMARY_STORE_TYPE_CONFIG            = "Invalid certificate store type resource definition."
+	ERR_SUMMARY_STORE_TYPE_CREATE            = "Unable to create Keyfactor Command certificate store type."
+	ERR_SUMMARY_STORE_TYPE_READ              = "Unable to read Keyfactor Command certificate store type."
+	ERR_SUMMARY_STORE_TYPE_UPDATE            = "Unable to update Keyfactor Command certificate store type."
+	ERR_SUMMARY_STORE_TYPE_DELETE            = "Unable to delete Keyfactor Command certificate store type."
+	ERR_SUMMARY_INVENTORY_SCHEDULE           = "Invalid certificate store inventory schedule."
+	ERR_SUMMARY_PAM_REFERENCE                = "Invalid PAM provider reference."
+	ERR_SUMMARY_PAM_PROVIDER_CONFIG          = "Invalid PAM provider resource definition."
+	ERR_SUMMARY_PAM_PROVIDER_CREATE          = "Unable to create Keyfactor Command PAM provider."
+	ERR_SUMMARY_PAM_PROVIDER_READ            = "Unable to read Keyfactor Command PAM provider."
+	ERR_SUMMARY_PAM_PROVIDER_UPDATE          = "Unable to update Keyfactor Command PAM provider."
+	ERR_SUMMARY_PAM_PROVIDER_DELETE          = "Unable to delete Keyfactor Command PAM provider."
+	ERR_SUMMARY_STORE_CONTAINER_CONFIG       = "Invalid certificate store container resource definition."
+	ERR_SUMMARY_STORE_CONTAINER_CREATE       = "Unable to create Keyfactor Command certificate store container."
+	ERR_SUMMARY_STORE_CONTAINER_READ         = "Unable to read Keyfactor Command certificate store container."
+	ERR_SUMMARY_STORE_CONTAINER_UPDATE       = "Unable to update Keyfactor Command certificate store container."
+	ERR_SUMMARY_STORE_CONTAINER_DELETE       = "Unable to delete Keyfactor Command certificate store container."
+	ERR_SUMMARY_DISCOVERY_JOB_CONFIG         = "Invalid certificate store discovery job resource definition."
+	ERR_SUMMARY_DISCOVERY_JOB_SUBMIT         = "Unable to schedule Keyfactor Command certificate store discovery job."
+	ERR_SUMMARY_DISCOVERED_STORES_READ       = "Unable to read Keyfactor Command discovered certificate stores."
+	ERR_SUMMARY_STORE_INVENTORY_READ         = "Unable to read Keyfactor Command certificate store inventory."
 
 	//EnvCommandHostname = "KEYFACTOR_HOSTNAME"
 	EnvCommandUsername = "KEYFACTOR_USERNAME"
@@ -26,3 +54,56 @@ const (
 	//EnvCommandTimeout  = "KEYFACTOR_TIMEOUT"
 	//DefaultAPIPath     = "KeyfactorAPI"
 )
+
+// Template enrollment field data types
+const (
+	TEMPLATE_ENROLLMENT_FIELD_TYPE_STRING          = 1
+	TEMPLATE_ENROLLMENT_FIELD_TYPE_MULTIPLE_CHOICE = 2
+)
+
+// Subject alternative name types
+const (
+	SAN_TYPE_DNS = "DNS"
+	SAN_TYPE_IP  = "IP"
+	SAN_TYPE_URI = "URI"
+)
+
+// Orchestrator agent statuses
+const (
+	AGENT_STATUS_APPROVED = 2
+)
+
+// Orchestrator job history results
+const (
+	ORCHESTRATOR_JOB_RESULT_UNKNOWN = 0
+	ORCHESTRATOR_JOB_RESULT_SUCCESS = 1
+	ORCHESTRATOR_JOB_RESULT_WARNING = 2
+	ORCHESTRATOR_JOB_RESULT_FAILURE = 3
+)
+
+// Certificate deployment job statuses
+const (
+	DEPLOYMENT_JOB_STATUS_PENDING   = "Pending"
+	DEPLOYMENT_JOB_STATUS_COMPLETED = "Completed"
+)
+
+// Certificate store type private key and custom alias policies
+const (
+	STORE_TYPE_POLICY_FORBIDDEN = "Forbidden"
+	STORE_TYPE_POLICY_OPTIONAL  = "Optional"
+	STORE_TYPE_POLICY_REQUIRED  = "Required"
+)
+
+// Certificate store type property types
+const (
+	STORE_TYPE_PROPERTY_STRING          = "String"
+	STORE_TYPE_PROPERTY_BOOL            = "Bool"
+	STORE_TYPE_PROPERTY_MULTIPLE_CHOICE = "MultipleChoice"
+	STORE_TYPE_PROPERTY_SECRET          = "Secret"
+)
+
+// PAM provider type parameter data types
+const (
+	PAM_PARAMETER_STRING = 1
+	PAM_PARAMETER_SECRET = 2
+)
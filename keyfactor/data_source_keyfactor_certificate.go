@@ -71,6 +71,31 @@ func (r dataSourceCertificateType) GetSchema(_ context.Context) (tfsdk.Schema, d
 				PlanModifiers: []tfsdk.AttributePlanModifier{tfsdk.RequiresReplace()},
 				Description:   "Subject organizational unit (OU) of the certificate",
 			},
+			"email": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "Subject email address (E) of the certificate",
+			},
+			"street_address": {
+				Type:        types.ListType{ElemType: types.StringType},
+				Computed:    true,
+				Description: "Subject street address (STREET) values of the certificate",
+			},
+			"postal_code": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "Subject postal code (POSTALCODE) of the certificate",
+			},
+			"subject_serial_number": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "Subject serial number (SERIALNUMBER) of the certificate. This is not the certificate serial number.",
+			},
+			"domain_components": {
+				Type:        types.ListType{ElemType: types.StringType},
+				Computed:    true,
+				Description: "Subject domain components (DC) of the certificate in the order they appear in the subject",
+			},
 			"certificate_authority": {
 				Type:          types.StringType,
 				Computed:      true,
@@ -126,6 +151,11 @@ func (r dataSourceCertificateType) GetSchema(_ context.Context) (tfsdk.Schema, d
 				Optional:    true,
 				Description: "Metadata key-value pairs to be attached to certificate",
 			},
+			"locations": {
+				Computed:    true,
+				Description: "Certificate stores the certificate is deployed to, including stores that are not managed by Terraform.",
+				Attributes:  tfsdk.ListNestedAttributes(certificateLocationAttributes()),
+			},
 			"serial_number": {
 				Type:        types.StringType,
 				Computed:    true,
@@ -208,7 +238,7 @@ func (r dataSourceCertificate) Read(
 	request tfsdk.ReadDataSourceRequest,
 	response *tfsdk.ReadDataSourceResponse,
 ) {
-	var state KeyfactorCertificate
+	var state KeyfactorCertificateDataSource
 
 	tflog.Info(ctx, "Reading terraform data resource 'certificate'.")
 	diags := request.Config.Get(ctx, &state)
@@ -459,23 +489,34 @@ func (r dataSourceCertificate) Read(
 		)
 	}
 
-	cn, ou, o, l, st, c := expandSubject(cResp.IssuedDN)
-	dnsSans, ipSans, uriSans := flattenSANs(cResp.SubjectAltNameElements, state.DNSSANs, state.IPSANs, state.URISANs)
+	subject, sErr := expandSubject(cResp.IssuedDN)
+	if sErr != nil {
+		response.Diagnostics.AddWarning(
+			ERR_SUMMARY_CERTIFICATE_RESOURCE_READ,
+			fmt.Sprintf("Could not parse subject of certificate '%s', the subject attributes are left empty: %s", state.ID.Value, sErr.Error()),
+		)
+	}
+	dnsSans, ipSans, uriSans := flattenSANs(cResp.SubjectAltNameElements, state.DNSSANs, state.IPSANs, state.URISANs, false)
 	metadata := flattenMetadata(cResp.Metadata)
 
-	var result = KeyfactorCertificate{
-		ID:                 types.String{Value: state.ID.Value},
-		CSR:                types.String{Value: csr},
-		CommonName:         cn,
-		Country:            c,
-		Locality:           l,
-		Organization:       o,
-		OrganizationalUnit: ou,
-		State:              st,
-		DNSSANs:            dnsSans,
-		IPSANs:             ipSans,
-		URISANs:            uriSans,
-		SerialNumber:       types.String{Value: cResp.SerialNumber},
+	var result = KeyfactorCertificateDataSource{
+		ID:                  types.String{Value: state.ID.Value},
+		CSR:                 types.String{Value: csr},
+		CommonName:          types.String{Value: subject.CommonName},
+		Country:             types.String{Value: subject.Country},
+		Locality:            types.String{Value: subject.Locality},
+		Organization:        types.String{Value: subject.Organization},
+		OrganizationalUnit:  types.String{Value: subject.OrganizationalUnit},
+		State:               types.String{Value: subject.State},
+		Email:               types.String{Value: subject.Email, Null: isNullString(subject.Email)},
+		StreetAddress:       flattenStringList(subject.StreetAddress),
+		PostalCode:          types.String{Value: subject.PostalCode, Null: isNullString(subject.PostalCode)},
+		SubjectSerialNumber: types.String{Value: subject.SerialNumber, Null: isNullString(subject.SerialNumber)},
+		DomainComponents:    flattenStringList(subject.DomainComponents),
+		DNSSANs:             dnsSans,
+		IPSANs:              ipSans,
+		URISANs:             uriSans,
+		SerialNumber:        types.String{Value: cResp.SerialNumber},
 		IssuerDN: types.String{
 			Value: cResp.IssuerDN,
 		},
@@ -492,6 +533,7 @@ func (r dataSourceCertificate) Read(
 		RequestId:           types.Int64{Value: int64(cResp.CertRequestId)},
 		CertificateId:       types.Int64{Value: int64(cResp.Id)},
 		Metadata:            metadata,
+		Locations:           flattenCertificateLocations(cResp.Locations),
 	}
 
 	// Set state
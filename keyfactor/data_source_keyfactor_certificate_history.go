@@ -0,0 +1,160 @@
+package keyfactor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// certificateHistoryResponse is a single entry returned by the Keyfactor Command certificate history endpoint.
+type certificateHistoryResponse struct {
+	Id            int    `json:"Id"`
+	CertificateId int    `json:"CertificateId"`
+	Timestamp     string `json:"Timestamp"`
+	Operation     string `json:"Operation"`
+	User          string `json:"User"`
+	Comment       string `json:"Comment"`
+}
+
+type dataSourceCertificateHistoryType struct{}
+
+func (r dataSourceCertificateHistoryType) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Attributes: map[string]tfsdk.Attribute{
+			"certificate_id": {
+				Type:        types.Int64Type,
+				Required:    true,
+				Description: "Keyfactor Command certificate ID. Ex: `keyfactor_certificate.example.certificate_id`",
+			},
+			"collection_id": {
+				Type:        types.Int64Type,
+				Optional:    true,
+				Description: "Optional certificate collection ID. This is required if permissions have been granted at the collection level.",
+			},
+			"history": {
+				Computed:    true,
+				Description: "Lifecycle events of the certificate, oldest first.",
+				Attributes: tfsdk.ListNestedAttributes(
+					map[string]tfsdk.Attribute{
+						"id": {
+							Type:        types.Int64Type,
+							Computed:    true,
+							Description: "Keyfactor Command history event ID.",
+						},
+						"timestamp": {
+							Type:        types.StringType,
+							Computed:    true,
+							Description: "Time, in UTC, at which the event occurred.",
+						},
+						"operation": {
+							Type:        types.StringType,
+							Computed:    true,
+							Description: "Operation that was performed on the certificate. Ex: enrollment, approval, metadata update, added to or removed from a certificate store, revocation or renewal.",
+						},
+						"user": {
+							Type:        types.StringType,
+							Computed:    true,
+							Description: "User or service account that performed the operation.",
+						},
+						"comment": {
+							Type:        types.StringType,
+							Computed:    true,
+							Description: "Details recorded with the event.",
+						},
+					},
+				),
+			},
+		},
+	}, nil
+}
+
+func (r dataSourceCertificateHistoryType) NewDataSource(ctx context.Context, p tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	return dataSourceCertificateHistory{
+		p: *(p.(*provider)),
+	}, nil
+}
+
+type dataSourceCertificateHistory struct {
+	p provider
+}
+
+func (r dataSourceCertificateHistory) Read(ctx context.Context, request tfsdk.ReadDataSourceRequest, response *tfsdk.ReadDataSourceResponse) {
+	var state KeyfactorCertificateHistory
+	diags := request.Config.Get(ctx, &state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Read called on certificate history data source")
+	certificateId := state.CertificateId.Value
+	ctx = tflog.SetField(ctx, "certificate_id", certificateId)
+
+	params := url.Values{}
+	params.Set("pq.returnLimit", strconv.Itoa(DEFAULT_QUERY_RETURN_SIZE))
+	if !state.CollectionId.Null && state.CollectionId.Value > 0 {
+		params.Set("collectionId", strconv.FormatInt(state.CollectionId.Value, 10))
+	}
+
+	var events []certificateHistoryResponse
+	for page := 1; ; page++ {
+		params.Set("pq.pageReturned", strconv.Itoa(page))
+		var pageResults []certificateHistoryResponse
+		err := r.p.commandRequest(
+			ctx,
+			http.MethodGet,
+			fmt.Sprintf("Certificates/%d/History", certificateId),
+			params,
+			nil,
+			&pageResults,
+		)
+		if err != nil {
+			response.Diagnostics.AddError(
+				ERR_SUMMARY_CERTIFICATE_RESOURCE_READ,
+				fmt.Sprintf("Could not retrieve history of certificate '%d' from Keyfactor Command: "+err.Error(), certificateId),
+			)
+			return
+		}
+		events = append(events, pageResults...)
+		if len(pageResults) < DEFAULT_QUERY_RETURN_SIZE {
+			break
+		}
+	}
+	tflog.Info(ctx, fmt.Sprintf("Found %d history events for certificate.", len(events)))
+
+	sort.SliceStable(
+		events, func(i, j int) bool {
+			if events[i].Timestamp == events[j].Timestamp {
+				return events[i].Id < events[j].Id
+			}
+			return events[i].Timestamp < events[j].Timestamp
+		},
+	)
+
+	state.History = []KeyfactorCertificateHistoryEvent{}
+	for _, event := range events {
+		state.History = append(
+			state.History, KeyfactorCertificateHistoryEvent{
+				Id:        types.Int64{Value: int64(event.Id)},
+				Timestamp: types.String{Value: event.Timestamp},
+				Operation: types.String{Value: event.Operation},
+				User:      types.String{Value: event.User},
+				Comment:   types.String{Value: event.Comment},
+			},
+		)
+	}
+
+	diags = response.State.Set(ctx, &state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,46 @@
+package keyfactor
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"os"
+	"testing"
+)
+
+func TestAccKeyfactorCertificateHistoryDataSource(t *testing.T) {
+	var resourceType = "keyfactor_certificate_history"
+	var resourceName = fmt.Sprintf("data.%s.test", resourceType)
+	var cID = os.Getenv("KEYFACTOR_CERTIFICATE_ID")
+	if cID == "" {
+		cID = os.Getenv("TEST_CERTIFICATE_ID")
+		if cID == "" {
+			cID = "1"
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccDataSourceKeyfactorCertificateHistoryBasic(resourceType, cID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "certificate_id", cID),
+					resource.TestCheckResourceAttrSet(resourceName, "history.#"),
+					resource.TestCheckResourceAttrSet(resourceName, "history.0.operation"),
+					resource.TestCheckResourceAttrSet(resourceName, "history.0.timestamp"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceKeyfactorCertificateHistoryBasic(resourceName string, id string) string {
+	output := fmt.Sprintf(`
+	data "%s" "test" {
+		certificate_id = %s
+	}
+	`, resourceName, id)
+	return output
+}
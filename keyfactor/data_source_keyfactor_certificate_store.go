@@ -25,21 +25,22 @@ func (r dataSourceCertificateStoreType) GetSchema(_ context.Context) (tfsdk.Sche
 				Description: "Display name of the certificate store.",
 			},
 			"client_machine": {
-				Type: types.StringType,
-				//Computed:    true,
-				Required:    true,
-				Description: "Client machine name; value depends on certificate store type. See API reference guide",
+				Type:        types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Description: "Client machine name; value depends on certificate store type. See API reference guide. Used with `store_path` and `store_type` to look the store up when `id` isn't set.",
 			},
 			"store_path": {
-				Type: types.StringType,
-				//Computed:    true,
-				Required:    true,
-				Description: "Path to the new certificate store on a target. Format varies depending on type.",
+				Type:        types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Description: "Path to the new certificate store on a target. Format varies depending on type. Used with `client_machine` and `store_type` to look the store up when `id` isn't set.",
 			},
 			"store_type": {
 				Type:        types.StringType,
+				Optional:    true,
 				Computed:    true,
-				Description: "Short name of certificate store type. See API reference guide",
+				Description: "Short name of certificate store type. See API reference guide. Used with `client_machine` and `store_path` to look the store up when `id` isn't set.",
 			},
 			"approved": {
 				Type:     types.BoolType,
@@ -87,9 +88,9 @@ func (r dataSourceCertificateStoreType) GetSchema(_ context.Context) (tfsdk.Sche
 				Description: "Name of certificate store's associated container, if applicable.",
 			},
 			"inventory_schedule": {
-				Type:        types.StringType,
-				Optional:    true,
-				Description: "Inventory schedule for new certificate store.",
+				Computed:    true,
+				Description: "Schedule of the certificate store's inventory jobs.",
+				Attributes:  tfsdk.ListNestedAttributes(inventoryScheduleAttributes(true)),
 			},
 			"set_new_password_allowed": {
 				Type:        types.BoolType,
@@ -97,10 +98,10 @@ func (r dataSourceCertificateStoreType) GetSchema(_ context.Context) (tfsdk.Sche
 				Description: "Indicates whether the store password can be changed.",
 			},
 			"id": {
-				Type: types.StringType,
-				//Required:    true,
+				Type:        types.StringType,
+				Optional:    true,
 				Computed:    true,
-				Description: "Keyfactor certificate store GUID.",
+				Description: "Keyfactor certificate store GUID. Either `id` or at least one of `client_machine`, `store_path` and `store_type` must be provided, and the lookup fails when more than one store matches.",
 			},
 			"store_password": {
 				Type:        types.StringType,
@@ -140,59 +141,66 @@ type dataSourceCertificateStore struct {
 }
 
 func (r dataSourceCertificateStore) Read(ctx context.Context, request tfsdk.ReadDataSourceRequest, response *tfsdk.ReadDataSourceResponse) {
-	var state CertificateStore
+	var state CertificateStoreDataSource
 
-	tflog.Info(ctx, "Read called on certificate resource")
 	diags := request.Config.Get(ctx, &state)
 	response.Diagnostics.Append(diags...)
 	if response.Diagnostics.HasError() {
 		return
 	}
 
-	tflog.Info(ctx, "Read called on certificate store resource")
-	//certificateStoreID := state.ID.Value
+	tflog.Info(ctx, "Read called on certificate store data source")
+	certificateStoreID := state.ID.Value
 	clientMachine := state.ClientMachine.Value
 	storePath := state.StorePath.Value
-	containerID := state.ContainerID.Value
+	storeType := state.StoreType.Value
 
-	//tflog.SetField(ctx, "certificate_id", certificateStoreID)
 	tflog.SetField(ctx, "client_machine", clientMachine)
 	tflog.SetField(ctx, "store_path", storePath)
-
-	//sResp, err := r.p.client.GetCertificateStoreByID(certificateStoreID)
-	sRespList, err := r.p.client.GetCertificateStoreByClientAndStorePath(clientMachine, storePath, containerID)
-	if err != nil {
-		response.Diagnostics.AddError(
-			ERR_SUMMARY_CERT_STORE_READ,
-			"Error reading certificate store: %s"+err.Error(),
-		)
-		return
+	tflog.SetField(ctx, "store_type", storeType)
+	if state.ID.Null {
+		if state.ClientMachine.Null && state.StorePath.Null && state.StoreType.Null {
+			response.Diagnostics.AddError(
+				ERR_SUMMARY_CERT_STORE_READ,
+				"Either 'id' or at least one of 'client_machine', 'store_path' and 'store_type' must be provided to look up a certificate store.",
+			)
+			return
+		}
+		var err error
+		certificateStoreID, err = lookupCertificateStore(ctx, r.p, clientMachine, storePath, storeType)
+		if err != nil {
+			response.Diagnostics.AddError(
+				ERR_SUMMARY_CERT_STORE_READ,
+				fmt.Sprintf("Unable to locate certificate store: %s", err.Error()),
+			)
+			return
+		}
 	}
+	tflog.SetField(ctx, "certificate_store_id", certificateStoreID)
 
-	if sRespList != nil && len(*sRespList) == 0 {
+	sResp, err := r.p.client.GetCertificateStoreByID(certificateStoreID)
+	if err == nil && sResp == nil {
+		err = fmt.Errorf("certificate store not found")
+	}
+	if err != nil {
 		response.Diagnostics.AddError(
 			ERR_SUMMARY_CERT_STORE_READ,
-			fmt.Sprintf("Error reading certificate store '%s/%s'", clientMachine, storePath),
+			fmt.Sprintf("Error reading certificate store '%s': %s", certificateStoreID, err.Error()),
 		)
 		return
 	}
-	sRespRef := *sRespList
-	//Because we're looking up by client machine and store path, there should only be one result as that's what Command uses for uniqueness as of KF 9.x
-	sResp := sRespRef[0]
-
-	password := state.StorePassword.Value
-	tflog.Trace(ctx, fmt.Sprintf("Password for store %s: %s", sResp.Id, password))
 
+	csType, err := r.p.client.GetCertificateStoreType(sResp.CertStoreType)
 	if err != nil {
 		response.Diagnostics.AddError(
-			"Certificate store not found",
-			fmt.Sprintf("Unable to locate certificate store using client machine '%s' and storepath '%s' %s", clientMachine, storePath, err.Error()),
+			ERR_SUMMARY_CERT_STORE_READ,
+			fmt.Sprintf("Could not retrieve certificate store type '%v' of certificate store '%s': %s", sResp.CertStoreType, certificateStoreID, err.Error()),
 		)
 		return
 	}
 
 	// parse inventory schedule
-	invSchedule := parseInventorySchedule(&sResp.InventorySchedule)
+	invSchedule := flattenInventorySchedule(ctx, readStoreInventorySchedule(ctx, r.p, sResp.Id, sResp.InventorySchedule), types.List{Null: true})
 	// parse store password
 	storePassword := parseStorePassword(&sResp.Password)
 	// parse properties
@@ -202,21 +210,21 @@ func (r dataSourceCertificateStore) Read(ctx context.Context, request tfsdk.Read
 		return
 	}
 
-	var result = CertificateStore{
+	var result = CertificateStoreDataSource{
 		ID:                    types.String{Value: sResp.Id},
 		ContainerID:           types.Int64{Value: int64(sResp.ContainerId)},
 		ContainerName:         types.String{Value: sResp.ContainerName},
 		AgentId:               types.String{Value: sResp.AgentId},
 		AgentIdentifier:       types.String{Value: sResp.AgentId},
 		AgentAssigned:         types.Bool{Value: sResp.AgentAssigned},
-		ClientMachine:         state.ClientMachine,
-		StorePath:             state.StorePath,
-		StoreType:             types.String{Value: fmt.Sprintf("%v", sResp.CertStoreType)},
+		ClientMachine:         types.String{Value: sResp.ClientMachine},
+		StorePath:             types.String{Value: sResp.StorePath},
+		StoreType:             types.String{Value: csType.ShortName},
 		Approved:              types.Bool{Value: sResp.Approved},
 		CreateIfMissing:       types.Bool{Value: sResp.CreateIfMissing},
 		Properties:            properties,
 		SetNewPasswordAllowed: types.Bool{Value: sResp.SetNewPasswordAllowed},
-		InventorySchedule:     types.String{Value: invSchedule},
+		InventorySchedule:     invSchedule,
 		ServerUsername:        serverUsername,
 		ServerPassword:        serverPassword,
 		ServerUseSsl:          serverUseSsl,
@@ -224,6 +232,14 @@ func (r dataSourceCertificateStore) Read(ctx context.Context, request tfsdk.Read
 		DisplayName:           types.String{Value: sResp.DisplayName},
 	}
 
+	// Keep the configured lookup values, Command may return them in a different case
+	if !state.ClientMachine.Null {
+		result.ClientMachine = state.ClientMachine
+	}
+	if !state.StoreType.Null {
+		result.StoreType = state.StoreType
+	}
+
 	// Set state
 	diags = response.State.Set(ctx, &result)
 	response.Diagnostics.Append(diags...)
@@ -0,0 +1,177 @@
+package keyfactor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type dataSourceCertificateStoreContainerType struct{}
+
+func (r dataSourceCertificateStoreContainerType) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:        types.Int64Type,
+				Optional:    true,
+				Computed:    true,
+				Description: "Keyfactor Command integer ID of the certificate store container. Either `id` or `name` must be provided.",
+			},
+			"name": {
+				Type:        types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of the certificate store container. Either `id` or `name` must be provided.",
+			},
+			"store_type": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "Short name of the certificate store type of the stores in the container.",
+			},
+			"overwrite_schedules": {
+				Type:        types.BoolType,
+				Computed:    true,
+				Description: "Whether the inventory schedule of the container replaces the inventory schedules of the stores in it.",
+			},
+			"inventory_schedule": {
+				Computed:    true,
+				Description: "Inventory schedule of the stores in the container.",
+				Attributes:  tfsdk.ListNestedAttributes(inventoryScheduleAttributes(true)),
+			},
+			"stores": {
+				Computed:    true,
+				Description: "Certificate stores in the container, sorted by ID.",
+				Attributes: tfsdk.ListNestedAttributes(
+					map[string]tfsdk.Attribute{
+						"id": {
+							Type:        types.StringType,
+							Computed:    true,
+							Description: "Keyfactor Command certificate store GUID.",
+						},
+						"client_machine": {
+							Type:        types.StringType,
+							Computed:    true,
+							Description: "Client machine name of the certificate store.",
+						},
+						"store_path": {
+							Type:        types.StringType,
+							Computed:    true,
+							Description: "Path of the certificate store on the client machine.",
+						},
+					},
+				),
+			},
+		},
+	}, nil
+}
+
+func (r dataSourceCertificateStoreContainerType) NewDataSource(ctx context.Context, p tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	return dataSourceCertificateStoreContainer{
+		p: *(p.(*provider)),
+	}, nil
+}
+
+type dataSourceCertificateStoreContainer struct {
+	p provider
+}
+
+func (r dataSourceCertificateStoreContainer) Read(ctx context.Context, request tfsdk.ReadDataSourceRequest, response *tfsdk.ReadDataSourceResponse) {
+	var state CertificateStoreContainerDataSource
+	diags := request.Config.Get(ctx, &state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	identifier := state.Name.Value
+	if !state.ID.Null {
+		identifier = strconv.FormatInt(state.ID.Value, 10)
+	} else if state.Name.Null {
+		response.Diagnostics.AddError(
+			ERR_SUMMARY_STORE_CONTAINER_READ,
+			"Either 'id' or 'name' must be provided to look up a certificate store container.",
+		)
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "container", identifier)
+	tflog.Info(ctx, "Read called on certificate store container data source")
+
+	container, err := lookupStoreContainer(r.p.client, identifier)
+	if err != nil {
+		response.Diagnostics.AddError(
+			ERR_SUMMARY_STORE_CONTAINER_READ,
+			fmt.Sprintf("Could not retrieve certificate store container '%s' from Keyfactor Command: %s", identifier, err.Error()),
+		)
+		return
+	}
+
+	definition, diags := flattenCertificateStoreContainer(ctx, r.p, container, CertificateStoreContainer{
+		InventorySchedule: types.List{ElemType: types.ObjectType{AttrTypes: inventoryScheduleAttrTypes}, Null: true},
+	})
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	stores, err := listContainerStores(ctx, r.p, *container.Id)
+	if err != nil {
+		response.Diagnostics.AddError(
+			ERR_SUMMARY_STORE_CONTAINER_READ,
+			fmt.Sprintf("Could not retrieve the certificate stores of container '%s' from Keyfactor Command: %s", container.Name, err.Error()),
+		)
+		return
+	}
+
+	result := CertificateStoreContainerDataSource{
+		ID:                 definition.ID,
+		Name:               definition.Name,
+		StoreType:          definition.StoreType,
+		OverwriteSchedules: definition.OverwriteSchedules,
+		InventorySchedule:  definition.InventorySchedule,
+		Stores:             []CertificateStoreContainerMember{},
+	}
+	for _, store := range stores {
+		result.Stores = append(result.Stores, CertificateStoreContainerMember{
+			ID:            types.String{Value: store.Id},
+			ClientMachine: types.String{Value: store.ClientMachine},
+			StorePath:     types.String{Value: store.StorePath},
+		})
+	}
+
+	diags = response.State.Set(ctx, &result)
+	response.Diagnostics.Append(diags...)
+}
+
+// listContainerStores returns the certificate stores in a certificate store container, sorted by ID.
+func listContainerStores(ctx context.Context, p provider, containerId int) ([]certificateStoreSummary, error) {
+	params := url.Values{}
+	params.Set("pq.queryString", fmt.Sprintf("ContainerId -eq %d", containerId))
+	params.Set("pq.returnLimit", strconv.Itoa(DEFAULT_QUERY_RETURN_SIZE))
+
+	var stores []certificateStoreSummary
+	for page := 1; ; page++ {
+		params.Set("pq.pageReturned", strconv.Itoa(page))
+		var pageResults []certificateStoreSummary
+		err := p.commandRequest(ctx, http.MethodGet, "CertificateStores", params, nil, &pageResults)
+		if err != nil {
+			return nil, err
+		}
+		stores = append(stores, pageResults...)
+		if len(pageResults) < DEFAULT_QUERY_RETURN_SIZE {
+			break
+		}
+	}
+	sort.Slice(stores, func(i, j int) bool {
+		return stores[i].Id < stores[j].Id
+	})
+	return stores, nil
+}
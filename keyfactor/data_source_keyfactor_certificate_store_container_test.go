@@ -0,0 +1,39 @@
+package keyfactor
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"os"
+	"testing"
+)
+
+func TestAccKeyfactorCertificateStoreContainerDataSource(t *testing.T) {
+	var resourceName = fmt.Sprintf("data.%s.test", "keyfactor_certificate_store_container")
+	var containerName = os.Getenv("KEYFACTOR_CERTIFICATE_STORE_CONTAINER_NAME1")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccDataSourceKeyfactorCertificateStoreContainerBasic(containerName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "name", containerName),
+					resource.TestCheckResourceAttrSet(resourceName, "store_type"),
+					resource.TestCheckResourceAttrSet(resourceName, "overwrite_schedules"),
+					resource.TestCheckResourceAttrSet(resourceName, "stores.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceKeyfactorCertificateStoreContainerBasic(containerName string) string {
+	return fmt.Sprintf(`
+	data "keyfactor_certificate_store_container" "test" {
+		name = "%s"
+	}
+	`, containerName)
+}
@@ -0,0 +1,310 @@
+package keyfactor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// storeInventoryItem is an alias in the inventory of a Keyfactor Command certificate store. The client's
+// api.CertStoreInventory drops the private key flag, so inventories are read with it.
+type storeInventoryItem struct {
+	Name            string                      `json:"Name"`
+	PrivateKeyEntry *bool                       `json:"PrivateKeyEntry"`
+	Certificates    []storeInventoryCertificate `json:"Certificates"`
+}
+
+type storeInventoryCertificate struct {
+	Id           int    `json:"Id"`
+	IssuedDN     string `json:"IssuedDN"`
+	SerialNumber string `json:"SerialNumber"`
+	NotBefore    string `json:"NotBefore"`
+	NotAfter     string `json:"NotAfter"`
+	Thumbprint   string `json:"Thumbprint"`
+}
+
+type dataSourceCertificateStoreInventoryType struct{}
+
+func (r dataSourceCertificateStoreInventoryType) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "Keyfactor Command certificate store GUID.",
+			},
+			"certificate_store_id": {
+				Type:        types.StringType,
+				Required:    true,
+				Description: "Keyfactor Command GUID of the certificate store to read the inventory of.",
+			},
+			"refresh": {
+				Type:        types.BoolType,
+				Optional:    true,
+				Description: "Run an inventory job and wait for it to complete before reading the inventory. This happens on every read, including plans. Waiting gives up after 30 minutes. The inventory schedule of the store is replaced by an immediate schedule while the job runs and restored afterwards, even when Terraform is interrupted. Defaults to `false`.",
+			},
+			"inventoried_at": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "RFC 3339 timestamp of the end of the last completed inventory job of the store. Null when no inventory job has completed.",
+			},
+			"aliases": {
+				Computed:    true,
+				Description: "Aliases in the inventory of the certificate store, sorted as returned by Keyfactor Command.",
+				Attributes: tfsdk.ListNestedAttributes(
+					map[string]tfsdk.Attribute{
+						"alias": {
+							Type:        types.StringType,
+							Computed:    true,
+							Description: "Alias of the entry in the certificate store.",
+						},
+						"private_key_present": {
+							Type:        types.BoolType,
+							Computed:    true,
+							Description: "Whether the entry has a private key. Null when the orchestrator doesn't report it.",
+						},
+						"certificates": {
+							Computed:    true,
+							Description: "Certificates of the entry, the leaf certificate first when the entry holds a chain.",
+							Attributes: tfsdk.ListNestedAttributes(
+								map[string]tfsdk.Attribute{
+									"id": {
+										Type:        types.Int64Type,
+										Computed:    true,
+										Description: "Keyfactor Command certificate ID.",
+									},
+									"thumbprint": {
+										Type:        types.StringType,
+										Computed:    true,
+										Description: "Thumbprint of the certificate.",
+									},
+									"serial_number": {
+										Type:        types.StringType,
+										Computed:    true,
+										Description: "Serial number of the certificate.",
+									},
+									"issued_dn": {
+										Type:        types.StringType,
+										Computed:    true,
+										Description: "Distinguished name of the certificate subject.",
+									},
+									"not_before": {
+										Type:        types.StringType,
+										Computed:    true,
+										Description: "RFC 3339 timestamp the certificate is valid from.",
+									},
+									"not_after": {
+										Type:        types.StringType,
+										Computed:    true,
+										Description: "RFC 3339 timestamp the certificate expires at.",
+									},
+									"expired": {
+										Type:        types.BoolType,
+										Computed:    true,
+										Description: "Whether the certificate had expired when the data source was read.",
+									},
+								},
+							),
+						},
+					},
+				),
+			},
+		},
+	}, nil
+}
+
+func (r dataSourceCertificateStoreInventoryType) NewDataSource(ctx context.Context, p tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	return dataSourceCertificateStoreInventory{
+		p: *(p.(*provider)),
+	}, nil
+}
+
+type dataSourceCertificateStoreInventory struct {
+	p provider
+}
+
+func (r dataSourceCertificateStoreInventory) Read(ctx context.Context, request tfsdk.ReadDataSourceRequest, response *tfsdk.ReadDataSourceResponse) {
+	var state CertificateStoreInventoryDataSource
+	diags := request.Config.Get(ctx, &state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	storeId := state.CertificateStoreID.Value
+	ctx = tflog.SetField(ctx, "certificate_store_id", storeId)
+	tflog.Info(ctx, "Read called on certificate store inventory data source")
+
+	store, err := r.p.client.GetCertificateStoreByID(storeId)
+	if err == nil && store == nil {
+		err = fmt.Errorf("certificate store not found")
+	}
+	if err != nil {
+		response.Diagnostics.AddError(
+			ERR_SUMMARY_STORE_INVENTORY_READ,
+			fmt.Sprintf("Could not retrieve certificate store '%s' from Keyfactor Command: %s", storeId, err.Error()),
+		)
+		return
+	}
+	jobId := store.CertStoreInventoryJobId
+
+	if state.Refresh.Value {
+		jobId, err = refreshStoreInventory(ctx, r.p, storeId, jobId)
+		if err != nil {
+			response.Diagnostics.AddError(
+				ERR_SUMMARY_STORE_INVENTORY_READ,
+				fmt.Sprintf("Could not run an inventory job on certificate store '%s': %s", storeId, err.Error()),
+			)
+			return
+		}
+	}
+
+	var inventory []storeInventoryItem
+	err = r.p.commandRequest(ctx, http.MethodGet, fmt.Sprintf("CertificateStores/%s/Inventory", storeId), nil, nil, &inventory)
+	if err != nil {
+		response.Diagnostics.AddError(
+			ERR_SUMMARY_STORE_INVENTORY_READ,
+			fmt.Sprintf("Could not read the inventory of certificate store '%s' from Keyfactor Command: %s", storeId, err.Error()),
+		)
+		return
+	}
+
+	result := CertificateStoreInventoryDataSource{
+		ID:                 types.String{Value: storeId},
+		CertificateStoreID: state.CertificateStoreID,
+		Refresh:            state.Refresh,
+		InventoriedAt:      types.String{Null: true},
+		Aliases:            []CertificateStoreInventoryAlias{},
+	}
+	if jobId != "" {
+		jobs, err := r.p.completedOrchestratorJobs(ctx, []string{jobId})
+		if err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("Unable to read the inventory job history of certificate store '%s': %s", storeId, err.Error()))
+		} else if job, ok := jobs[strings.ToLower(jobId)]; ok {
+			result.InventoriedAt = types.String{Value: inventoryTimestamp(job.OperationEnd)}
+		}
+	}
+
+	now := time.Now()
+	for _, item := range inventory {
+		alias := CertificateStoreInventoryAlias{
+			Alias:             types.String{Value: item.Name},
+			PrivateKeyPresent: types.Bool{Null: true},
+			Certificates:      []CertificateStoreInventoryCertificate{},
+		}
+		if item.PrivateKeyEntry != nil {
+			alias.PrivateKeyPresent = types.Bool{Value: *item.PrivateKeyEntry}
+		}
+		for _, certificate := range item.Certificates {
+			notAfter := inventoryTimestamp(certificate.NotAfter)
+			expired := false
+			if t, err := time.Parse(time.RFC3339, notAfter); err == nil {
+				expired = t.Before(now)
+			}
+			alias.Certificates = append(alias.Certificates, CertificateStoreInventoryCertificate{
+				ID:           types.Int64{Value: int64(certificate.Id)},
+				Thumbprint:   types.String{Value: certificate.Thumbprint},
+				SerialNumber: types.String{Value: certificate.SerialNumber},
+				IssuedDN:     types.String{Value: certificate.IssuedDN},
+				NotBefore:    types.String{Value: inventoryTimestamp(certificate.NotBefore)},
+				NotAfter:     types.String{Value: notAfter},
+				Expired:      types.Bool{Value: expired},
+			})
+		}
+		result.Aliases = append(result.Aliases, alias)
+	}
+
+	diags = response.State.Set(ctx, &result)
+	response.Diagnostics.Append(diags...)
+}
+
+// inventoryTimestamp returns a timestamp returned by Keyfactor Command in RFC 3339 format. Timestamps without a time
+// zone are in UTC.
+func inventoryTimestamp(timestamp string) string {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05"} {
+		if t, err := time.Parse(layout, timestamp); err == nil {
+			return t.UTC().Format(time.RFC3339)
+		}
+	}
+	return timestamp
+}
+
+// refreshStoreInventory runs an immediate inventory job on a certificate store and waits for it to complete. The
+// inventory schedule of the store is restored afterwards. It returns the ID of the inventory job of the store, and gives
+// up after MAX_ORCHESTRATOR_JOB_WAIT_SECONDS or when ctx is cancelled.
+func refreshStoreInventory(ctx context.Context, p provider, storeId string, jobId string) (_ string, err error) {
+	previousHistoryId := 0
+	if jobId != "" {
+		jobs, err := p.completedOrchestratorJobs(ctx, []string{jobId})
+		if err != nil {
+			return jobId, err
+		}
+		previousHistoryId = jobs[strings.ToLower(jobId)].JobHistoryId
+	}
+
+	schedule, err := getStoreInventorySchedule(ctx, p, storeId)
+	if err != nil {
+		return jobId, err
+	}
+	err = setStoreInventorySchedule(ctx, p, storeId, &storeInventorySchedule{Immediate: boolToPointer(true)})
+	if err != nil {
+		return jobId, err
+	}
+	if schedule != nil && (schedule.Interval != nil || schedule.Daily != nil || schedule.Weekly != nil || schedule.ExactlyOnce != nil) {
+		defer func() {
+			// Restore the schedule even when Terraform is interrupted
+			if restoreErr := setStoreInventorySchedule(context.Background(), p, storeId, schedule); restoreErr != nil && err == nil {
+				err = fmt.Errorf("the inventory schedule of the store could not be restored: %s", restoreErr.Error())
+			}
+		}()
+	}
+
+	// The inventory job is created when a store is first scheduled
+	store, err := p.client.GetCertificateStoreByID(storeId)
+	if err != nil {
+		return jobId, err
+	}
+	jobId = store.CertStoreInventoryJobId
+	if jobId == "" {
+		return jobId, fmt.Errorf("certificate store has no inventory job")
+	}
+
+	timeout := time.Duration(MAX_ORCHESTRATOR_JOB_WAIT_SECONDS) * time.Second
+	deadline := time.Now().Add(timeout)
+	retryDelay := 2 * time.Second
+	for {
+		jobs, err := p.completedOrchestratorJobs(ctx, []string{jobId})
+		if err != nil {
+			return jobId, err
+		}
+		if job, ok := jobs[strings.ToLower(jobId)]; ok && job.JobHistoryId > previousHistoryId {
+			if job.Result == ORCHESTRATOR_JOB_RESULT_FAILURE {
+				return jobId, fmt.Errorf("inventory job failed: %s", job.Message)
+			}
+			return jobId, nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return jobId, fmt.Errorf("timed out after %v waiting for inventory job '%s' to complete", timeout, jobId)
+		}
+		if retryDelay > remaining {
+			retryDelay = remaining
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Inventory job of certificate store '%s' hasn't completed. Retrying in %v", storeId, retryDelay))
+		if err := sleepContext(ctx, retryDelay); err != nil {
+			return jobId, err
+		}
+		retryDelay = retryDelay * SLEEP_DURATION_MULTIPLIER
+		if retryDelay > time.Minute {
+			retryDelay = time.Minute
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package keyfactor
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"os"
+	"testing"
+)
+
+func TestAccKeyfactorCertificateStoreInventoryDataSource(t *testing.T) {
+	var resourceName = fmt.Sprintf("data.%s.test", "keyfactor_certificate_store_inventory")
+	var storeId = os.Getenv("KEYFACTOR_CERTIFICATE_STORE_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccDataSourceKeyfactorCertificateStoreInventoryBasic(storeId, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", storeId),
+					resource.TestCheckResourceAttr(resourceName, "certificate_store_id", storeId),
+					resource.TestCheckResourceAttrSet(resourceName, "aliases.#"),
+				),
+			},
+			// Read after a fresh inventory job
+			{
+				Config: testAccDataSourceKeyfactorCertificateStoreInventoryBasic(storeId, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", storeId),
+					resource.TestCheckResourceAttrSet(resourceName, "inventoried_at"),
+					resource.TestCheckResourceAttrSet(resourceName, "aliases.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceKeyfactorCertificateStoreInventoryBasic(storeId string, refresh bool) string {
+	return fmt.Sprintf(`
+	data "keyfactor_certificate_store_inventory" "test" {
+		certificate_store_id = "%s"
+		refresh              = %t
+	}
+	`, storeId, refresh)
+}
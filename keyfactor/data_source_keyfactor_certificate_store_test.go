@@ -16,13 +16,6 @@ func TestAccKeyfactorCertificateStoreDataSource(t *testing.T) {
 			sID = "1"
 		}
 	}
-	var sPass = os.Getenv("KEYFACTOR_CERTIFICATE_STORE_PASS")
-	if sPass == "" {
-		sPass = os.Getenv("TEST_CERTIFICATE_STORE_PASS")
-		if sPass == "" {
-			sPass = "password1234!"
-		}
-	}
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
@@ -30,10 +23,9 @@ func TestAccKeyfactorCertificateStoreDataSource(t *testing.T) {
 		Steps: []resource.TestStep{
 			// Read testing
 			{
-				Config: testAccDataSourceKeyfactorCertificateStoreBasic(sID, sPass),
+				Config: testAccDataSourceKeyfactorCertificateStoreBasic(sID),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "id", sID),
-					resource.TestCheckResourceAttr(resourceName, "password", sPass),
 					resource.TestCheckResourceAttrSet(resourceName, "store_path"),
 					resource.TestCheckResourceAttrSet(resourceName, "store_type"),
 					resource.TestCheckResourceAttrSet(resourceName, "approved"),
@@ -51,11 +43,44 @@ func TestAccKeyfactorCertificateStoreDataSource(t *testing.T) {
 	})
 }
 
-func testAccDataSourceKeyfactorCertificateStoreBasic(resourceName string, password string) string {
+func testAccDataSourceKeyfactorCertificateStoreBasic(resourceName string) string {
 	return fmt.Sprintf(`
 	data "keyfactor_certificate_store" "test" {
 		id = "%s"
-		password = "%s"
 	}
-	`, resourceName, password)
+	`, resourceName)
+}
+
+func TestAccKeyfactorCertificateStoreDataSource_Lookup(t *testing.T) {
+	var resourceName = fmt.Sprintf("data.%s.test", "keyfactor_certificate_store")
+	var clientMachine = os.Getenv("KEYFACTOR_CERTIFICATE_STORE_CLIENT_MACHINE")
+	var storePath = os.Getenv("KEYFACTOR_CERTIFICATE_STORE_PATH")
+	var storeType = os.Getenv("KEYFACTOR_CERTIFICATE_STORE_TYPE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccDataSourceKeyfactorCertificateStoreLookup(clientMachine, storePath, storeType),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "client_machine", clientMachine),
+					resource.TestCheckResourceAttr(resourceName, "store_path", storePath),
+					resource.TestCheckResourceAttr(resourceName, "store_type", storeType),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceKeyfactorCertificateStoreLookup(clientMachine string, storePath string, storeType string) string {
+	return fmt.Sprintf(`
+	data "keyfactor_certificate_store" "test" {
+		client_machine = "%s"
+		store_path     = "%s"
+		store_type     = "%s"
+	}
+	`, clientMachine, storePath, storeType)
 }
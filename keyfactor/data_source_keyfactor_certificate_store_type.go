@@ -0,0 +1,305 @@
+package keyfactor
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type dataSourceCertificateStoreTypeType struct{}
+
+func (r dataSourceCertificateStoreTypeType) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:        types.Int64Type,
+				Optional:    true,
+				Computed:    true,
+				Description: "Keyfactor Command integer ID of the certificate store type. Either `id` or `short_name` must be provided.",
+			},
+			"short_name": {
+				Type:        types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Description: "Short name of the certificate store type, as used by `keyfactor_certificate_store.store_type`. Either `id` or `short_name` must be provided.",
+			},
+			"name": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "Display name of the certificate store type.",
+			},
+			"capability": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "Capability the orchestrator extension registers for the certificate store type.",
+			},
+			"local_store": {
+				Type:        types.BoolType,
+				Computed:    true,
+				Description: "Whether certificate stores of this type are local to the orchestrator.",
+			},
+			"server_required": {
+				Type:        types.BoolType,
+				Computed:    true,
+				Description: "Whether certificate stores of this type need `server_username`, `server_password` and `server_use_ssl`.",
+			},
+			"blueprint_allowed": {
+				Type:        types.BoolType,
+				Computed:    true,
+				Description: "Whether certificate stores of this type can be included in orchestrator blueprints.",
+			},
+			"supports_add": {
+				Type:        types.BoolType,
+				Computed:    true,
+				Description: "Whether certificates can be added to certificate stores of this type.",
+			},
+			"supports_remove": {
+				Type:        types.BoolType,
+				Computed:    true,
+				Description: "Whether certificates can be removed from certificate stores of this type.",
+			},
+			"supports_create": {
+				Type:        types.BoolType,
+				Computed:    true,
+				Description: "Whether the orchestrator can create certificate stores of this type.",
+			},
+			"supports_discovery": {
+				Type:        types.BoolType,
+				Computed:    true,
+				Description: "Whether the orchestrator can discover certificate stores of this type.",
+			},
+			"supports_reenrollment": {
+				Type:        types.BoolType,
+				Computed:    true,
+				Description: "Whether certificate stores of this type support reenrollment jobs.",
+			},
+			"private_key_allowed": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "Whether certificates in stores of this type are deployed with their private key. One of `Forbidden`, `Optional` or `Required`.",
+			},
+			"custom_alias_allowed": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "Whether certificates deployed to stores of this type need a custom alias. One of `Forbidden`, `Optional` or `Required`.",
+			},
+			"supports_entry_password": {
+				Type:        types.BoolType,
+				Computed:    true,
+				Description: "Whether certificates in stores of this type can be protected by their own password.",
+			},
+			"store_password_required": {
+				Type:        types.BoolType,
+				Computed:    true,
+				Description: "Whether certificate stores of this type must have a store password.",
+			},
+			"password_style": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "How store and entry passwords are generated. One of `Default` or `Custom`.",
+			},
+			"job_properties": {
+				Type:        types.ListType{ElemType: types.StringType},
+				Computed:    true,
+				Description: "Names of the custom fields passed to the orchestrator with management jobs.",
+			},
+			"properties": {
+				Computed:    true,
+				Description: "Custom properties that certificate stores of this type are configured with.",
+				Attributes: tfsdk.ListNestedAttributes(
+					map[string]tfsdk.Attribute{
+						"name": {
+							Type:        types.StringType,
+							Computed:    true,
+							Description: "Name of the property as it appears in `keyfactor_certificate_store.properties`.",
+						},
+						"display_name": {
+							Type:        types.StringType,
+							Computed:    true,
+							Description: "Display name of the property in the Keyfactor Command portal.",
+						},
+						"type": {
+							Type:        types.StringType,
+							Computed:    true,
+							Description: "Type of the property. One of `String`, `Bool`, `MultipleChoice` or `Secret`.",
+						},
+						"depends_on": {
+							Type:        types.StringType,
+							Computed:    true,
+							Description: "Name of a `Bool` property that has to be true for this property to be shown.",
+						},
+						"default_value": {
+							Type:        types.StringType,
+							Computed:    true,
+							Description: "Value a certificate store gets when the property is not configured. For `MultipleChoice` properties this is the first allowed value.",
+						},
+						"allowed_values": {
+							Type:        types.ListType{ElemType: types.StringType},
+							Computed:    true,
+							Description: "Allowed values of a `MultipleChoice` property.",
+						},
+						"required": {
+							Type:        types.BoolType,
+							Computed:    true,
+							Description: "Whether the property must be set on every certificate store of this type.",
+						},
+						"secret": {
+							Type:        types.BoolType,
+							Computed:    true,
+							Description: "Whether the property holds a secret, such as `ServerUsername` and `ServerPassword`.",
+						},
+					},
+				),
+			},
+			"entry_parameters": {
+				Computed:    true,
+				Description: "Parameters passed to the orchestrator when certificates are added to, removed from or reenrolled in stores of this type.",
+				Attributes: tfsdk.ListNestedAttributes(
+					map[string]tfsdk.Attribute{
+						"name": {
+							Type:        types.StringType,
+							Computed:    true,
+							Description: "Name of the entry parameter, as used by `keyfactor_certificate_deployment.job_parameters`.",
+						},
+						"display_name": {
+							Type:        types.StringType,
+							Computed:    true,
+							Description: "Display name of the entry parameter in the Keyfactor Command portal.",
+						},
+						"type": {
+							Type:        types.StringType,
+							Computed:    true,
+							Description: "Type of the entry parameter. One of `String`, `Bool` or `MultipleChoice`.",
+						},
+						"depends_on": {
+							Type:        types.StringType,
+							Computed:    true,
+							Description: "Name of a `Bool` entry parameter that has to be true for this parameter to be shown.",
+						},
+						"default_value": {
+							Type:        types.StringType,
+							Computed:    true,
+							Description: "Default value of the entry parameter.",
+						},
+						"options": {
+							Type:        types.StringType,
+							Computed:    true,
+							Description: "Comma separated list of allowed values of a `MultipleChoice` entry parameter.",
+						},
+						"required_when_has_private_key": {
+							Type:        types.BoolType,
+							Computed:    true,
+							Description: "Whether the parameter is required when the certificate has a private key.",
+						},
+						"required_when_on_add": {
+							Type:        types.BoolType,
+							Computed:    true,
+							Description: "Whether the parameter is required when adding a certificate.",
+						},
+						"required_when_on_remove": {
+							Type:        types.BoolType,
+							Computed:    true,
+							Description: "Whether the parameter is required when removing a certificate.",
+						},
+						"required_when_on_reenrollment": {
+							Type:        types.BoolType,
+							Computed:    true,
+							Description: "Whether the parameter is required when reenrolling a certificate.",
+						},
+					},
+				),
+			},
+		},
+	}, nil
+}
+
+func (r dataSourceCertificateStoreTypeType) NewDataSource(ctx context.Context, p tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	return dataSourceCertificateStoreTypeDefinition{
+		p: *(p.(*provider)),
+	}, nil
+}
+
+type dataSourceCertificateStoreTypeDefinition struct {
+	p provider
+}
+
+func (r dataSourceCertificateStoreTypeDefinition) Read(ctx context.Context, request tfsdk.ReadDataSourceRequest, response *tfsdk.ReadDataSourceResponse) {
+	var state CertificateStoreTypeDataSource
+	diags := request.Config.Get(ctx, &state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	identifier := state.ShortName.Value
+	if !state.ID.Null {
+		identifier = strconv.FormatInt(state.ID.Value, 10)
+	} else if state.ShortName.Null {
+		response.Diagnostics.AddError(
+			ERR_SUMMARY_STORE_TYPE_READ,
+			"Either 'id' or 'short_name' must be provided to look up a certificate store type.",
+		)
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "store_type", identifier)
+	tflog.Info(ctx, "Read called on certificate store type data source")
+
+	storeType, err := lookupCertificateStoreType(r.p.client, identifier)
+	if err != nil {
+		response.Diagnostics.AddError(
+			ERR_SUMMARY_STORE_TYPE_READ,
+			fmt.Sprintf("Could not retrieve certificate store type '%s' from Keyfactor Command: %s", identifier, err.Error()),
+		)
+		return
+	}
+
+	definition := flattenCertificateStoreType(storeType, CertificateStoreType{})
+	result := CertificateStoreTypeDataSource{
+		ID:                    definition.ID,
+		Name:                  definition.Name,
+		ShortName:             definition.ShortName,
+		Capability:            definition.Capability,
+		LocalStore:            definition.LocalStore,
+		ServerRequired:        definition.ServerRequired,
+		BlueprintAllowed:      definition.BlueprintAllowed,
+		SupportsAdd:           definition.SupportsAdd,
+		SupportsRemove:        definition.SupportsRemove,
+		SupportsCreate:        definition.SupportsCreate,
+		SupportsDiscovery:     definition.SupportsDiscovery,
+		SupportsReenrollment:  definition.SupportsReenrollment,
+		PrivateKeyAllowed:     definition.PrivateKeyAllowed,
+		CustomAliasAllowed:    definition.CustomAliasAllowed,
+		SupportsEntryPassword: definition.SupportsEntryPassword,
+		StorePasswordRequired: definition.StorePasswordRequired,
+		PasswordStyle:         definition.PasswordStyle,
+		JobProperties:         definition.JobProperties,
+		Properties:            []CertificateStoreTypePropertyDataSource{},
+		EntryParameters:       definition.EntryParameters,
+	}
+	if result.EntryParameters == nil {
+		result.EntryParameters = []CertificateStoreTypeEntryParameter{}
+	}
+	if storeType.Properties != nil {
+		for _, property := range *storeType.Properties {
+			result.Properties = append(result.Properties, CertificateStoreTypePropertyDataSource{
+				Name:          types.String{Value: property.Name},
+				DisplayName:   types.String{Value: property.DisplayName},
+				Type:          types.String{Value: property.Type},
+				DependsOn:     types.String{Value: property.DependsOn},
+				DefaultValue:  types.String{Value: storeTypePropertyDefaultValue(property)},
+				AllowedValues: flattenStringList(storeTypePropertyChoices(property)),
+				Required:      types.Bool{Value: property.Required},
+				Secret:        types.Bool{Value: property.Type == STORE_TYPE_PROPERTY_SECRET},
+			})
+		}
+	}
+
+	diags = response.State.Set(ctx, &result)
+	response.Diagnostics.Append(diags...)
+}
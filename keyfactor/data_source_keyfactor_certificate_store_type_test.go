@@ -0,0 +1,46 @@
+package keyfactor
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"os"
+	"testing"
+)
+
+func TestAccKeyfactorCertificateStoreTypeDataSource(t *testing.T) {
+	var resourceName = fmt.Sprintf("data.%s.test", "keyfactor_certificate_store_type")
+	var shortName = os.Getenv("KEYFACTOR_CERTIFICATE_STORE_TYPE")
+	if shortName == "" {
+		shortName = "PEM"
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccDataSourceKeyfactorCertificateStoreTypeBasic(shortName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "short_name", shortName),
+					resource.TestCheckResourceAttrSet(resourceName, "name"),
+					resource.TestCheckResourceAttrSet(resourceName, "capability"),
+					resource.TestCheckResourceAttrSet(resourceName, "supports_add"),
+					resource.TestCheckResourceAttrSet(resourceName, "private_key_allowed"),
+					resource.TestCheckResourceAttrSet(resourceName, "custom_alias_allowed"),
+					resource.TestCheckResourceAttrSet(resourceName, "properties.#"),
+					resource.TestCheckResourceAttrSet(resourceName, "entry_parameters.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceKeyfactorCertificateStoreTypeBasic(shortName string) string {
+	return fmt.Sprintf(`
+	data "keyfactor_certificate_store_type" "test" {
+		short_name = "%s"
+	}
+	`, shortName)
+}
@@ -50,6 +50,7 @@ func TestAccKeyfactorCertificateDataSource(t *testing.T) {
 					resource.TestCheckResourceAttrSet(resourceName, "keyfactor_request_id"),
 					resource.TestCheckResourceAttrSet(resourceName, "certificate_pem"),
 					resource.TestCheckResourceAttrSet(resourceName, "certificate_chain"),
+					resource.TestCheckResourceAttrSet(resourceName, "locations.#"),
 					//resource.TestCheckResourceAttrSet(resourceName, "private_key"),
 				),
 			},
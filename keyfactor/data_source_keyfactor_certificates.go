@@ -0,0 +1,312 @@
+package keyfactor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Keyfactor/keyfactor-go-client/v2/api"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// metadataFieldSummary is the subset of a Keyfactor Command metadata field definition needed to filter on it.
+type metadataFieldSummary struct {
+	Name string `json:"Name"`
+}
+
+type dataSourceCertificatesType struct{}
+
+func (r dataSourceCertificatesType) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Attributes: map[string]tfsdk.Attribute{
+			"query": {
+				Type:     types.StringType,
+				Optional: true,
+				Description: "Raw Keyfactor Command certificate query. Ex: `IssuedCN -contains \"example.com\" AND CertState -eq 1`. " +
+					"This is combined with any of the other filters using AND.",
+			},
+			"issued_cn_contains": {
+				Type:        types.StringType,
+				Optional:    true,
+				Description: "Only return certificates whose issued common name contains this value.",
+			},
+			"template": {
+				Type:        types.StringType,
+				Optional:    true,
+				Description: "Only return certificates issued from the template with this short name.",
+			},
+			"issuer_dn": {
+				Type:        types.StringType,
+				Optional:    true,
+				Description: "Only return certificates issued by this issuer distinguished name.",
+			},
+			"expires_before": {
+				Type:        types.StringType,
+				Optional:    true,
+				Description: "Only return certificates that expire before this RFC 3339 timestamp. Ex: 2024-01-01T00:00:00Z",
+			},
+			"metadata": {
+				Type:        types.MapType{ElemType: types.StringType},
+				Optional:    true,
+				Description: "Only return certificates with these metadata field values. Field names must be defined in Keyfactor Command.",
+			},
+			"collection_id": {
+				Type:        types.Int64Type,
+				Optional:    true,
+				Description: "Optional certificate collection ID. This is required if permissions have been granted at the collection level.",
+			},
+			"include_revoked": {
+				Type:        types.BoolType,
+				Optional:    true,
+				Description: "Include revoked certificates in the results. Defaults to false.",
+			},
+			"certificates": {
+				Computed:    true,
+				Description: "Certificates matching the query.",
+				Attributes: tfsdk.ListNestedAttributes(
+					map[string]tfsdk.Attribute{
+						"certificate_id": {
+							Type:        types.Int64Type,
+							Computed:    true,
+							Description: "Keyfactor Command certificate ID.",
+						},
+						"common_name": {
+							Type:        types.StringType,
+							Computed:    true,
+							Description: "Issued common name (CN) of the certificate.",
+						},
+						"thumbprint": {
+							Type:        types.StringType,
+							Computed:    true,
+							Description: "Thumbprint of the certificate.",
+						},
+						"serial_number": {
+							Type:        types.StringType,
+							Computed:    true,
+							Description: "Serial number of the certificate.",
+						},
+						"issuer_dn": {
+							Type:        types.StringType,
+							Computed:    true,
+							Description: "Issuer distinguished name that signed the certificate.",
+						},
+						"not_before": {
+							Type:        types.StringType,
+							Computed:    true,
+							Description: "Date the certificate is valid from.",
+						},
+						"not_after": {
+							Type:        types.StringType,
+							Computed:    true,
+							Description: "Date the certificate expires.",
+						},
+						"certificate_template": {
+							Type:        types.StringType,
+							Computed:    true,
+							Description: "Name of the template the certificate was issued from.",
+						},
+						"locations": {
+							Computed:    true,
+							Description: "Certificate stores the certificate is in.",
+							Attributes:  tfsdk.ListNestedAttributes(certificateLocationAttributes()),
+						},
+						"metadata": {
+							Type:        types.MapType{ElemType: types.StringType},
+							Computed:    true,
+							Description: "Metadata key-value pairs attached to the certificate.",
+						},
+					},
+				),
+			},
+		},
+	}, nil
+}
+
+func (r dataSourceCertificatesType) NewDataSource(ctx context.Context, p tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	return dataSourceCertificates{
+		p: *(p.(*provider)),
+	}, nil
+}
+
+type dataSourceCertificates struct {
+	p provider
+}
+
+func (r dataSourceCertificates) Read(ctx context.Context, request tfsdk.ReadDataSourceRequest, response *tfsdk.ReadDataSourceResponse) {
+	var state KeyfactorCertificates
+	diags := request.Config.Get(ctx, &state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Read called on certificates data source")
+	query, qDiags := buildCertificateQuery(ctx, r.p, state)
+	response.Diagnostics.Append(qDiags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "query", query)
+
+	params := url.Values{}
+	params.Set("pq.queryString", query)
+	params.Set("pq.returnLimit", strconv.Itoa(DEFAULT_QUERY_RETURN_SIZE))
+	params.Set("pq.sortField", "Id")
+	params.Set("pq.sortAscending", "0")
+	params.Set("pq.includeRevoked", strconv.FormatBool(state.IncludeRevoked.Value))
+	params.Set("includeLocations", "true")
+	params.Set("includeMetadata", "true")
+	if !state.CollectionId.Null && state.CollectionId.Value > 0 {
+		params.Set("collectionId", strconv.FormatInt(state.CollectionId.Value, 10))
+	}
+
+	var certificates []api.GetCertificateResponse
+	for page := 1; ; page++ {
+		params.Set("pq.pageReturned", strconv.Itoa(page))
+		var pageResults []api.GetCertificateResponse
+		tflog.Debug(ctx, fmt.Sprintf("Querying certificates page %d", page))
+		err := r.p.commandRequest(ctx, http.MethodGet, "Certificates", params, nil, &pageResults)
+		if err != nil {
+			response.Diagnostics.AddError(
+				ERR_SUMMARY_CERTIFICATE_RESOURCE_READ,
+				fmt.Sprintf("Could not query certificates '%s' from Keyfactor Command: "+err.Error(), query),
+			)
+			return
+		}
+		certificates = append(certificates, pageResults...)
+		if len(pageResults) < DEFAULT_QUERY_RETURN_SIZE {
+			break
+		}
+	}
+	tflog.Info(ctx, fmt.Sprintf("Found %d certificates matching query.", len(certificates)))
+
+	state.Certificates = []KeyfactorCertificateSummary{}
+	for _, cert := range certificates {
+		state.Certificates = append(
+			state.Certificates, KeyfactorCertificateSummary{
+				CertificateId:       types.Int64{Value: int64(cert.Id)},
+				CommonName:          types.String{Value: cert.IssuedCN},
+				Thumbprint:          types.String{Value: cert.Thumbprint},
+				SerialNumber:        types.String{Value: cert.SerialNumber},
+				IssuerDN:            types.String{Value: cert.IssuerDN},
+				NotBefore:           types.String{Value: cert.NotBefore},
+				NotAfter:            types.String{Value: cert.NotAfter},
+				CertificateTemplate: types.String{Value: cert.TemplateName},
+				Locations:           flattenCertificateLocations(cert.Locations),
+				Metadata:            flattenMetadata(cert.Metadata),
+			},
+		)
+	}
+
+	diags = response.State.Set(ctx, &state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+}
+
+// buildCertificateQuery combines the raw query and the convenience filters of the certificates data source into a
+// single Keyfactor Command certificate query. Metadata filters are checked against the metadata fields defined in
+// Keyfactor Command, so that a field name can't change the query.
+func buildCertificateQuery(ctx context.Context, p provider, state KeyfactorCertificates) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var clauses []string
+
+	quote := func(value string) string {
+		return "\"" + strings.ReplaceAll(value, "\"", "\\\"") + "\""
+	}
+
+	if state.Query.Value != "" {
+		clauses = append(clauses, "("+state.Query.Value+")")
+	}
+	if state.IssuedCNContains.Value != "" {
+		clauses = append(clauses, "IssuedCN -contains "+quote(state.IssuedCNContains.Value))
+	}
+	if state.Template.Value != "" {
+		clauses = append(clauses, "TemplateShortName -eq "+quote(state.Template.Value))
+	}
+	if state.IssuerDN.Value != "" {
+		clauses = append(clauses, "IssuerDN -eq "+quote(state.IssuerDN.Value))
+	}
+	if state.ExpiresBefore.Value != "" {
+		expiresBefore, err := time.Parse(time.RFC3339, state.ExpiresBefore.Value)
+		if err != nil {
+			diags.AddError(
+				"Invalid certificates query.",
+				fmt.Sprintf("expires_before '%s' is not a valid RFC 3339 timestamp: "+err.Error(), state.ExpiresBefore.Value),
+			)
+			return "", diags
+		}
+		clauses = append(clauses, "NotAfter -lt "+quote(expiresBefore.UTC().Format("2006-01-02T15:04:05")))
+	}
+	if len(state.Metadata.Elems) > 0 {
+		var metadata map[string]string
+		diags.Append(state.Metadata.ElementsAs(ctx, &metadata, false)...)
+		if diags.HasError() {
+			return "", diags
+		}
+		definedFields, err := listMetadataFieldNames(ctx, p)
+		if err != nil {
+			diags.AddError(
+				"Invalid certificates query.",
+				fmt.Sprintf("Could not retrieve metadata fields from Keyfactor Command: %s", err.Error()),
+			)
+			return "", diags
+		}
+		var fields []string
+		for field := range metadata {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			name, ok := definedFields[strings.ToLower(field)]
+			if !ok {
+				diags.AddAttributeError(
+					path.Root("metadata"),
+					"Invalid certificates query.",
+					fmt.Sprintf("Metadata field '%s' is not defined in Keyfactor Command.", field),
+				)
+				continue
+			}
+			clauses = append(clauses, name+" -eq "+quote(metadata[field]))
+		}
+		if diags.HasError() {
+			return "", diags
+		}
+	}
+
+	return strings.Join(clauses, " AND "), diags
+}
+
+// listMetadataFieldNames returns the names of the metadata fields defined in Keyfactor Command, keyed by their lower
+// case name.
+func listMetadataFieldNames(ctx context.Context, p provider) (map[string]string, error) {
+	params := url.Values{}
+	params.Set("pq.returnLimit", strconv.Itoa(DEFAULT_QUERY_RETURN_SIZE))
+
+	names := map[string]string{}
+	for page := 1; ; page++ {
+		params.Set("pq.pageReturned", strconv.Itoa(page))
+		var pageResults []metadataFieldSummary
+		err := p.commandRequest(ctx, http.MethodGet, "MetadataFields", params, nil, &pageResults)
+		if err != nil {
+			return nil, err
+		}
+		for _, field := range pageResults {
+			names[strings.ToLower(field.Name)] = field.Name
+		}
+		if len(pageResults) < DEFAULT_QUERY_RETURN_SIZE {
+			break
+		}
+	}
+	return names, nil
+}
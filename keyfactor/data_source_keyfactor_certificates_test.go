@@ -0,0 +1,62 @@
+package keyfactor
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"os"
+	"regexp"
+	"testing"
+)
+
+func TestAccKeyfactorCertificatesDataSource(t *testing.T) {
+	var resourceType = "keyfactor_certificates"
+	var resourceName = fmt.Sprintf("data.%s.test", resourceType)
+	var cn = os.Getenv("TEST_CERTIFICATE_CN")
+	if cn == "" {
+		cn = "terraform"
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccDataSourceKeyfactorCertificatesBasic(resourceType, cn),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "certificates.#"),
+					resource.TestCheckResourceAttrSet(resourceName, "certificates.0.certificate_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "certificates.0.thumbprint"),
+					resource.TestCheckResourceAttrSet(resourceName, "certificates.0.serial_number"),
+					resource.TestCheckResourceAttrSet(resourceName, "certificates.0.not_after"),
+				),
+			},
+			// Metadata field names are not passed into the query unchecked
+			{
+				Config:      testAccDataSourceKeyfactorCertificatesMetadata(resourceType, `Owner -eq \"x\" OR Id`),
+				ExpectError: regexp.MustCompile("is not defined in Keyfactor Command"),
+			},
+		},
+	})
+}
+
+func testAccDataSourceKeyfactorCertificatesBasic(resourceName string, cn string) string {
+	output := fmt.Sprintf(`
+	data "%s" "test" {
+		issued_cn_contains = "%s"
+		include_revoked    = true
+	}
+	`, resourceName, cn)
+	return output
+}
+
+func testAccDataSourceKeyfactorCertificatesMetadata(resourceName string, field string) string {
+	output := fmt.Sprintf(`
+	data "%s" "test" {
+		metadata = {
+			"%s" = "terraform"
+		}
+	}
+	`, resourceName, field)
+	return output
+}
@@ -0,0 +1,255 @@
+package keyfactor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// discoveredStoreSummary is the subset of a Keyfactor Command certificate store needed to list discovered stores.
+type discoveredStoreSummary struct {
+	Id            string `json:"Id"`
+	ClientMachine string `json:"ClientMachine"`
+	StorePath     string `json:"StorePath"`
+	CertStoreType int    `json:"CertStoreType"`
+	AgentId       string `json:"AgentId"`
+	Approved      bool   `json:"Approved"`
+}
+
+type dataSourceDiscoveredStoresType struct{}
+
+func (r dataSourceDiscoveredStoresType) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "Identifier of the lookup, the concatenation of the filters.",
+			},
+			"agent_identifier": {
+				Type:        types.StringType,
+				Optional:    true,
+				Description: "Only return stores discovered by this orchestrator, given as agent GUID or client machine name.",
+			},
+			"store_type": {
+				Type:        types.StringType,
+				Optional:    true,
+				Description: "Only return stores of this certificate store type short name.",
+			},
+			"client_machine": {
+				Type:        types.StringType,
+				Optional:    true,
+				Description: "Only return stores on this client machine. The comparison ignores case.",
+			},
+			"stores": {
+				Computed:    true,
+				Description: "Discovered certificate stores that aren't approved yet, sorted by client machine and store path. Manage one with a `keyfactor_certificate_store` resource with the same `client_machine`, `store_path` and `store_type` and `approve_discovered` set to approve it. Approved stores no longer appear here, so don't use `stores` as the `for_each` of the resources that approve them.",
+				Attributes: tfsdk.ListNestedAttributes(
+					map[string]tfsdk.Attribute{
+						"id": {
+							Type:        types.StringType,
+							Computed:    true,
+							Description: "Keyfactor Command certificate store GUID.",
+						},
+						"client_machine": {
+							Type:        types.StringType,
+							Computed:    true,
+							Description: "Client machine name of the certificate store.",
+						},
+						"store_path": {
+							Type:        types.StringType,
+							Computed:    true,
+							Description: "Path of the certificate store on the client machine.",
+						},
+						"store_type": {
+							Type:        types.StringType,
+							Computed:    true,
+							Description: "Short name of the certificate store type.",
+						},
+						"agent_id": {
+							Type:        types.StringType,
+							Computed:    true,
+							Description: "Keyfactor Command GUID of the orchestrator that discovered the certificate store.",
+						},
+					},
+				),
+			},
+		},
+	}, nil
+}
+
+func (r dataSourceDiscoveredStoresType) NewDataSource(ctx context.Context, p tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	return dataSourceDiscoveredStores{
+		p: *(p.(*provider)),
+	}, nil
+}
+
+type dataSourceDiscoveredStores struct {
+	p provider
+}
+
+func (r dataSourceDiscoveredStores) Read(ctx context.Context, request tfsdk.ReadDataSourceRequest, response *tfsdk.ReadDataSourceResponse) {
+	var state DiscoveredStores
+	diags := request.Config.Get(ctx, &state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Read called on discovered stores data source")
+
+	agentIds := map[string]bool{}
+	if !state.AgentIdentifier.Null {
+		agents, err := r.p.client.GetAgent(state.AgentIdentifier.Value)
+		if err != nil || len(agents) == 0 {
+			response.Diagnostics.AddError(
+				ERR_SUMMARY_DISCOVERED_STORES_READ,
+				fmt.Sprintf("Could not retrieve agent '%s' from Keyfactor Command: %v", state.AgentIdentifier.Value, err),
+			)
+			return
+		}
+		for _, agent := range agents {
+			agentIds[strings.ToLower(agent.AgentId)] = true
+		}
+	}
+
+	storeTypeId := 0
+	if !state.StoreType.Null {
+		storeType, err := r.p.client.GetCertificateStoreTypeByName(state.StoreType.Value)
+		if err != nil || storeType == nil {
+			response.Diagnostics.AddError(
+				ERR_SUMMARY_DISCOVERED_STORES_READ,
+				fmt.Sprintf("Could not retrieve certificate store type '%s' from Keyfactor Command: %v", state.StoreType.Value, err),
+			)
+			return
+		}
+		storeTypeId = storeType.StoreType
+	}
+
+	stores, err := listDiscoveredStores(ctx, r.p)
+	if err != nil {
+		response.Diagnostics.AddError(
+			ERR_SUMMARY_DISCOVERED_STORES_READ,
+			fmt.Sprintf("Could not retrieve discovered certificate stores from Keyfactor Command: %s", err.Error()),
+		)
+		return
+	}
+
+	result := DiscoveredStores{
+		ID:              types.String{Value: strings.Join([]string{state.AgentIdentifier.Value, state.StoreType.Value, state.ClientMachine.Value}, ":")},
+		AgentIdentifier: state.AgentIdentifier,
+		StoreType:       state.StoreType,
+		ClientMachine:   state.ClientMachine,
+		Stores:          []DiscoveredStore{},
+	}
+	storeTypeNames := map[int]string{}
+	for _, store := range stores {
+		if len(agentIds) > 0 && !agentIds[strings.ToLower(store.AgentId)] {
+			continue
+		}
+		if storeTypeId != 0 && store.CertStoreType != storeTypeId {
+			continue
+		}
+		if !state.ClientMachine.Null && !strings.EqualFold(store.ClientMachine, state.ClientMachine.Value) {
+			continue
+		}
+
+		storeTypeName, ok := storeTypeNames[store.CertStoreType]
+		if !ok {
+			storeType, err := r.p.client.GetCertificateStoreTypeById(store.CertStoreType)
+			if err != nil {
+				response.Diagnostics.AddError(
+					ERR_SUMMARY_DISCOVERED_STORES_READ,
+					fmt.Sprintf("Could not retrieve certificate store type '%v' of discovered certificate store '%s' from Keyfactor Command: %s", store.CertStoreType, store.Id, err.Error()),
+				)
+				return
+			}
+			storeTypeName = storeType.ShortName
+			storeTypeNames[store.CertStoreType] = storeTypeName
+		}
+
+		result.Stores = append(result.Stores, DiscoveredStore{
+			ID:            types.String{Value: store.Id},
+			ClientMachine: types.String{Value: store.ClientMachine},
+			StorePath:     types.String{Value: store.StorePath},
+			StoreType:     types.String{Value: storeTypeName},
+			AgentId:       types.String{Value: store.AgentId},
+		})
+	}
+
+	diags = response.State.Set(ctx, &result)
+	response.Diagnostics.Append(diags...)
+}
+
+// listDiscoveredStores returns the certificate stores that were discovered and aren't approved yet, sorted by client
+// machine and store path. Query clauses narrow down the stores on the server.
+func listDiscoveredStores(ctx context.Context, p provider, clauses ...string) ([]discoveredStoreSummary, error) {
+	params := url.Values{}
+	params.Set("pq.queryString", strings.Join(append([]string{"Approved -eq false"}, clauses...), " AND "))
+	params.Set("pq.returnLimit", strconv.Itoa(DEFAULT_QUERY_RETURN_SIZE))
+
+	var stores []discoveredStoreSummary
+	for page := 1; ; page++ {
+		params.Set("pq.pageReturned", strconv.Itoa(page))
+		var pageResults []discoveredStoreSummary
+		err := p.commandRequest(ctx, http.MethodGet, "CertificateStores", params, nil, &pageResults)
+		if err != nil {
+			return nil, err
+		}
+		for _, store := range pageResults {
+			if !store.Approved {
+				stores = append(stores, store)
+			}
+		}
+		if len(pageResults) < DEFAULT_QUERY_RETURN_SIZE {
+			break
+		}
+	}
+	sort.Slice(stores, func(i, j int) bool {
+		if stores[i].ClientMachine != stores[j].ClientMachine {
+			return stores[i].ClientMachine < stores[j].ClientMachine
+		}
+		return stores[i].StorePath < stores[j].StorePath
+	})
+	return stores, nil
+}
+
+// findDiscoveredStore returns the discovered certificate store that a certificate store resource would duplicate, or
+// nil when there is none.
+func findDiscoveredStore(ctx context.Context, p provider, clientMachine string, storePath string, storeTypeId int) (*discoveredStoreSummary, error) {
+	stores, err := listDiscoveredStores(
+		ctx,
+		p,
+		fmt.Sprintf("ClientMachine -eq \"%s\"", clientMachine),
+		fmt.Sprintf("StorePath -eq \"%s\"", storePath),
+		fmt.Sprintf("CertStoreType -eq %d", storeTypeId),
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, store := range stores {
+		if strings.EqualFold(store.ClientMachine, clientMachine) && store.StorePath == storePath && store.CertStoreType == storeTypeId {
+			return &store, nil
+		}
+	}
+	return nil, nil
+}
+
+// approveDiscoveredStore approves a discovered certificate store, so that it can be managed like any other store.
+func approveDiscoveredStore(ctx context.Context, p provider, storeId string, containerId int) error {
+	store := map[string]interface{}{"Id": storeId}
+	if containerId != 0 {
+		store["ContainerId"] = containerId
+	}
+	body := []map[string]interface{}{store}
+	return p.commandRequest(ctx, http.MethodPost, "CertificateStores/Approve", nil, body, nil)
+}
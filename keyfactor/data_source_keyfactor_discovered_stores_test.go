@@ -0,0 +1,40 @@
+package keyfactor
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"os"
+	"testing"
+)
+
+func TestAccKeyfactorDiscoveredStoresDataSource(t *testing.T) {
+	var resourceName = fmt.Sprintf("data.%s.test", "keyfactor_discovered_stores")
+	var storeType = os.Getenv("KEYFACTOR_CERTIFICATE_STORE_TYPE")
+	if storeType == "" {
+		storeType = "PEM"
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccDataSourceKeyfactorDiscoveredStoresBasic(storeType),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "store_type", storeType),
+					resource.TestCheckResourceAttrSet(resourceName, "stores.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceKeyfactorDiscoveredStoresBasic(storeType string) string {
+	return fmt.Sprintf(`
+	data "keyfactor_discovered_stores" "test" {
+		store_type = "%s"
+	}
+	`, storeType)
+}
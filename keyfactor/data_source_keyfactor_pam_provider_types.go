@@ -0,0 +1,115 @@
+package keyfactor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type dataSourcePamProviderTypesType struct{}
+
+func (r dataSourcePamProviderTypesType) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Attributes: map[string]tfsdk.Attribute{
+			"types": {
+				Computed:    true,
+				Description: "PAM provider types installed in Keyfactor Command, sorted by name.",
+				Attributes: tfsdk.ListNestedAttributes(
+					map[string]tfsdk.Attribute{
+						"id": {
+							Type:        types.StringType,
+							Computed:    true,
+							Description: "Keyfactor Command GUID of the PAM provider type.",
+						},
+						"name": {
+							Type:        types.StringType,
+							Computed:    true,
+							Description: "Name of the PAM provider type, as used by `keyfactor_pam_provider.provider_type`.",
+						},
+						"parameters": {
+							Computed:    true,
+							Description: "Parameters of the PAM provider type.",
+							Attributes: tfsdk.ListNestedAttributes(
+								map[string]tfsdk.Attribute{
+									"name": {
+										Type:        types.StringType,
+										Computed:    true,
+										Description: "Name of the parameter.",
+									},
+									"display_name": {
+										Type:        types.StringType,
+										Computed:    true,
+										Description: "Display name of the parameter in the Keyfactor Command portal.",
+									},
+									"secret": {
+										Type:        types.BoolType,
+										Computed:    true,
+										Description: "Whether the parameter is set with `keyfactor_pam_provider.secret_parameters` instead of `parameters`.",
+									},
+									"instance_level": {
+										Type:     types.BoolType,
+										Computed: true,
+										Description: "Whether the parameter identifies a secret and is set by the certificate stores that use the " +
+											"PAM provider, in `server_username_pam`, `server_password_pam` and `store_password_pam`, instead of on the PAM provider.",
+									},
+								},
+							),
+						},
+					},
+				),
+			},
+		},
+	}, nil
+}
+
+func (r dataSourcePamProviderTypesType) NewDataSource(ctx context.Context, p tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	return dataSourcePamProviderTypes{
+		p: *(p.(*provider)),
+	}, nil
+}
+
+type dataSourcePamProviderTypes struct {
+	p provider
+}
+
+func (r dataSourcePamProviderTypes) Read(ctx context.Context, request tfsdk.ReadDataSourceRequest, response *tfsdk.ReadDataSourceResponse) {
+	tflog.Info(ctx, "Read called on PAM provider types data source")
+
+	providerTypes, err := getPamProviderTypes(ctx, r.p)
+	if err != nil {
+		response.Diagnostics.AddError(
+			ERR_SUMMARY_PAM_PROVIDER_READ,
+			fmt.Sprintf("Could not retrieve PAM provider types from Keyfactor Command: %s", err.Error()),
+		)
+		return
+	}
+	sort.Slice(providerTypes, func(i, j int) bool {
+		return providerTypes[i].Name < providerTypes[j].Name
+	})
+
+	result := PamProviderTypes{Types: []PamProviderTypeDataSource{}}
+	for _, providerType := range providerTypes {
+		parameters := []PamProviderTypeParamDataSource{}
+		for _, param := range providerType.Parameters {
+			parameters = append(parameters, PamProviderTypeParamDataSource{
+				Name:          types.String{Value: param.Name},
+				DisplayName:   types.String{Value: param.DisplayName},
+				Secret:        types.Bool{Value: param.DataType == PAM_PARAMETER_SECRET},
+				InstanceLevel: types.Bool{Value: param.InstanceLevel},
+			})
+		}
+		result.Types = append(result.Types, PamProviderTypeDataSource{
+			ID:         types.String{Value: providerType.Id},
+			Name:       types.String{Value: providerType.Name},
+			Parameters: parameters,
+		})
+	}
+
+	diags := response.State.Set(ctx, &result)
+	response.Diagnostics.Append(diags...)
+}
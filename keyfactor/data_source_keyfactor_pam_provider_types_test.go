@@ -0,0 +1,34 @@
+package keyfactor
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+func TestAccKeyfactorPamProviderTypesDataSource(t *testing.T) {
+	var resourceName = fmt.Sprintf("data.%s.test", "keyfactor_pam_provider_types")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccDataSourceKeyfactorPamProviderTypesBasic(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "types.#"),
+					resource.TestCheckResourceAttrSet(resourceName, "types.0.id"),
+					resource.TestCheckResourceAttrSet(resourceName, "types.0.name"),
+					resource.TestCheckResourceAttrSet(resourceName, "types.0.parameters.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceKeyfactorPamProviderTypesBasic() string {
+	return `
+	data "keyfactor_pam_provider_types" "test" {}
+	`
+}
@@ -4,11 +4,14 @@ import (
 	"crypto/ecdsa"
 	rsa2 "crypto/rsa"
 	"crypto/x509"
+	"encoding/asn1"
+	"encoding/hex"
 	"encoding/json"
 	"encoding/pem"
 	"fmt"
 	"log"
 	"math/rand"
+	"net"
 	"regexp"
 	"sort"
 	"strconv"
@@ -17,6 +20,8 @@ import (
 	"github.com/Keyfactor/keyfactor-go-client/v2/api"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -63,84 +68,318 @@ func generatePassword(passwordLength, minSpecialChar, minNum, minUpperCase int)
 	return string(inRune)
 }
 
-func expandSubject(subject string) (
-	types.String,
-	types.String,
-	types.String,
-	types.String,
-	types.String,
-	types.String,
-) {
-	var (
-		cn string
-		ou string
-		o  string
-		l  string
-		st string
-		c  string
-	)
-	if subject != "" {
-		subjectFields := strings.Split(subject, ",") // Separate subject fields into slices
-		for _, field := range subjectFields {        // Iterate and assign slices to associated map
-			if strings.Contains(field, "CN=") {
-				//result["subject_common_name"] = types.String{Value: strings.Replace(field, "CN=", "", 1)}
-				cn = strings.Replace(field, "CN=", "", 1)
-			} else if strings.Contains(field, "OU=") {
-				//result["subject_organizational_unit"] = types.String{Value: strings.Replace(field, "OU=", "", 1)}
-				ou = strings.Replace(field, "OU=", "", 1)
-			} else if strings.Contains(field, "C=") {
-				//result["subject_country"] = types.String{Value: strings.Replace(field, "C=", "", 1)}
-				c = strings.Replace(field, "C=", "", 1)
-			} else if strings.Contains(field, "L=") {
-				//result["subject_locality"] = types.String{Value: strings.Replace(field, "L=", "", 1)}
-				l = strings.Replace(field, "L=", "", 1)
-			} else if strings.Contains(field, "ST=") {
-				//result["subject_state"] = types.String{Value: strings.Replace(field, "ST=", "", 1)}
-				st = strings.Replace(field, "ST=", "", 1)
-			} else if strings.Contains(field, "O=") {
-				//result["subject_organization"] = types.String{Value: strings.Replace(field, "O=", "", 1)}
-				o = strings.Replace(field, "O=", "", 1)
+// subjectDN holds the attributes of a certificate subject distinguished name.
+type subjectDN struct {
+	CommonName         string
+	Organization       string
+	OrganizationalUnit string
+	Locality           string
+	State              string
+	Country            string
+	Email              string
+	StreetAddress      []string
+	PostalCode         string
+	SerialNumber       string
+	DomainComponents   []string
+}
+
+// dnAttributeTypes maps RFC 4514 attribute type keywords and OIDs to a canonical short name.
+var dnAttributeTypes = map[string]string{
+	"CN":                         "CN",
+	"COMMONNAME":                 "CN",
+	"2.5.4.3":                    "CN",
+	"O":                          "O",
+	"2.5.4.10":                   "O",
+	"OU":                         "OU",
+	"2.5.4.11":                   "OU",
+	"L":                          "L",
+	"2.5.4.7":                    "L",
+	"ST":                         "ST",
+	"S":                          "ST",
+	"2.5.4.8":                    "ST",
+	"C":                          "C",
+	"2.5.4.6":                    "C",
+	"STREET":                     "STREET",
+	"2.5.4.9":                    "STREET",
+	"POSTALCODE":                 "POSTALCODE",
+	"2.5.4.17":                   "POSTALCODE",
+	"SERIALNUMBER":               "SERIALNUMBER",
+	"2.5.4.5":                    "SERIALNUMBER",
+	"DC":                         "DC",
+	"0.9.2342.19200300.100.1.25": "DC",
+	"E":                          "E",
+	"EMAIL":                      "E",
+	"EMAILADDRESS":               "E",
+	"1.2.840.113549.1.9.1":       "E",
+}
+
+// dnAttribute is a single attribute type and value of a relative distinguished name.
+type dnAttribute struct {
+	Type  string
+	Value string
+}
+
+// parseDistinguishedName parses a distinguished name string as described by RFC 4514 into its relative
+// distinguished names, each of which may contain multiple attributes. Escaped characters, quoted values, hex encoded
+// values and the spaces Keyfactor Command adds after separators are all handled.
+func parseDistinguishedName(dn string) ([][]dnAttribute, error) {
+	var rdns [][]dnAttribute
+	var rdn []dnAttribute
+	input := []rune(dn)
+	i := 0
+
+	skipSpaces := func() {
+		for i < len(input) && input[i] == ' ' {
+			i++
+		}
+	}
+
+	skipSpaces()
+	if i == len(input) {
+		return rdns, nil
+	}
+
+	for i < len(input) {
+		// Attribute type
+		skipSpaces()
+		start := i
+		for i < len(input) && input[i] != '=' {
+			if input[i] == ',' || input[i] == '+' || input[i] == ';' {
+				return nil, fmt.Errorf("invalid distinguished name '%s': missing '=' at position %d", dn, i)
+			}
+			i++
+		}
+		if i == len(input) {
+			return nil, fmt.Errorf("invalid distinguished name '%s': missing '=' after attribute type", dn)
+		}
+		attrType := strings.TrimSpace(string(input[start:i]))
+		if attrType == "" {
+			return nil, fmt.Errorf("invalid distinguished name '%s': empty attribute type at position %d", dn, start)
+		}
+		i++ // skip '='
+		skipSpaces()
+
+		// Attribute value
+		var value strings.Builder
+		switch {
+		case i < len(input) && input[i] == '#':
+			// Hex encoded BER value
+			i++
+			start = i
+			for i < len(input) && input[i] != ',' && input[i] != '+' && input[i] != ';' && input[i] != ' ' {
+				i++
+			}
+			raw, err := hex.DecodeString(string(input[start:i]))
+			if err != nil {
+				return nil, fmt.Errorf("invalid distinguished name '%s': invalid hex value for '%s': %s", dn, attrType, err.Error())
+			}
+			var ber asn1.RawValue
+			if _, err := asn1.Unmarshal(raw, &ber); err != nil {
+				return nil, fmt.Errorf("invalid distinguished name '%s': invalid BER value for '%s': %s", dn, attrType, err.Error())
+			}
+			value.Write(ber.Bytes)
+			skipSpaces()
+		case i < len(input) && input[i] == '"':
+			// Quoted value, supported for compatibility with RFC 2253 producers
+			i++
+			for i < len(input) && input[i] != '"' {
+				if input[i] == '\\' && i+1 < len(input) {
+					i++
+				}
+				value.WriteRune(input[i])
+				i++
+			}
+			if i == len(input) {
+				return nil, fmt.Errorf("invalid distinguished name '%s': unterminated quoted value for '%s'", dn, attrType)
+			}
+			i++ // skip closing quote
+			skipSpaces()
+		default:
+			var pending []byte // bytes from \XX hex pairs which may form a multibyte UTF-8 character
+			trailing := 0      // unescaped trailing spaces, which are not part of the value
+			flush := func() {
+				if len(pending) > 0 {
+					value.Write(pending)
+					pending = nil
+				}
+			}
+			for i < len(input) && input[i] != ',' && input[i] != '+' && input[i] != ';' {
+				c := input[i]
+				if c == '\\' {
+					if i+1 >= len(input) {
+						return nil, fmt.Errorf("invalid distinguished name '%s': trailing escape character", dn)
+					}
+					if i+2 < len(input) && isHexRune(input[i+1]) && isHexRune(input[i+2]) {
+						b, _ := hex.DecodeString(string(input[i+1 : i+3]))
+						pending = append(pending, b...)
+						i += 3
+						trailing = 0
+						continue
+					}
+					flush()
+					value.WriteRune(input[i+1])
+					i += 2
+					trailing = 0
+					continue
+				}
+				flush()
+				if c == ' ' {
+					trailing++
+				} else {
+					trailing = 0
+				}
+				value.WriteRune(c)
+				i++
+			}
+			flush()
+			if trailing > 0 {
+				s := value.String()
+				value.Reset()
+				value.WriteString(s[:len(s)-trailing])
+			}
+		}
+
+		rdn = append(rdn, dnAttribute{Type: attrType, Value: value.String()})
+
+		if i < len(input) && input[i] == '+' {
+			i++
+			continue
+		}
+		rdns = append(rdns, rdn)
+		rdn = nil
+		if i < len(input) {
+			// ',' or ';' separator
+			i++
+			skipSpaces()
+			if i == len(input) {
+				return nil, fmt.Errorf("invalid distinguished name '%s': trailing separator", dn)
 			}
 		}
 	}
-	return types.String{Value: cn}, types.String{Value: ou}, types.String{Value: o}, types.String{Value: l}, types.String{Value: st}, types.String{Value: c}
+	return rdns, nil
 }
 
-func flattenSubject(subject string) types.Object {
-	data := make(map[string]string) // Inner subject interface is a string mapped interface
-	if subject != "" {
-		subjectFields := strings.Split(subject, ",") // Separate subject fields into slices
-		for _, field := range subjectFields {        // Iterate and assign slices to associated map
-			if strings.Contains(field, "CN=") {
-				//result["subject_common_name"] = types.String{Value: strings.Replace(field, "CN=", "", 1)}
-				data["subject_common_name"] = strings.Replace(field, "CN=", "", 1)
-			} else if strings.Contains(field, "OU=") {
-				//result["subject_organizational_unit"] = types.String{Value: strings.Replace(field, "OU=", "", 1)}
-				data["subject_organizational_unit"] = strings.Replace(field, "OU=", "", 1)
-			} else if strings.Contains(field, "C=") {
-				//result["subject_country"] = types.String{Value: strings.Replace(field, "C=", "", 1)}
-				data["subject_country"] = strings.Replace(field, "C=", "", 1)
-			} else if strings.Contains(field, "L=") {
-				//result["subject_locality"] = types.String{Value: strings.Replace(field, "L=", "", 1)}
-				data["subject_locality"] = strings.Replace(field, "L=", "", 1)
-			} else if strings.Contains(field, "ST=") {
-				//result["subject_state"] = types.String{Value: strings.Replace(field, "ST=", "", 1)}
-				data["subject_state"] = strings.Replace(field, "ST=", "", 1)
-			} else if strings.Contains(field, "O=") {
-				//result["subject_organization"] = types.String{Value: strings.Replace(field, "O=", "", 1)}
-				data["subject_organization"] = strings.Replace(field, "O=", "", 1)
+func isHexRune(r rune) bool {
+	return (r >= '0' && r <= '9') || (r >= 'a' && r <= 'f') || (r >= 'A' && r <= 'F')
+}
+
+// expandSubject parses a certificate subject distinguished name into a subjectDN. When an attribute appears more than
+// once the first value is used, except for street address and domain components which keep every value in order.
+func expandSubject(subject string) (subjectDN, error) {
+	var result subjectDN
+	rdns, err := parseDistinguishedName(subject)
+	if err != nil {
+		return result, err
+	}
+	setOnce := func(field *string, value string) {
+		if *field == "" {
+			*field = value
+		}
+	}
+	for _, rdn := range rdns {
+		for _, attribute := range rdn {
+			switch dnAttributeTypes[strings.ToUpper(attribute.Type)] {
+			case "CN":
+				setOnce(&result.CommonName, attribute.Value)
+			case "O":
+				setOnce(&result.Organization, attribute.Value)
+			case "OU":
+				setOnce(&result.OrganizationalUnit, attribute.Value)
+			case "L":
+				setOnce(&result.Locality, attribute.Value)
+			case "ST":
+				setOnce(&result.State, attribute.Value)
+			case "C":
+				setOnce(&result.Country, attribute.Value)
+			case "E":
+				setOnce(&result.Email, attribute.Value)
+			case "STREET":
+				result.StreetAddress = append(result.StreetAddress, attribute.Value)
+			case "POSTALCODE":
+				setOnce(&result.PostalCode, attribute.Value)
+			case "SERIALNUMBER":
+				setOnce(&result.SerialNumber, attribute.Value)
+			case "DC":
+				result.DomainComponents = append(result.DomainComponents, attribute.Value)
+			}
+		}
+	}
+	return result, nil
+}
+
+// hasExtendedAttributes reports whether the subject has attributes that the Keyfactor Go client can't submit on
+// enrollment.
+func (s subjectDN) hasExtendedAttributes() bool {
+	return s.Email != "" || len(s.StreetAddress) > 0 || s.PostalCode != "" || s.SerialNumber != "" || len(s.DomainComponents) > 0
+}
+
+// formatDistinguishedName formats a subject as an RFC 4514 distinguished name. Empty attributes are left out.
+func formatDistinguishedName(s subjectDN) string {
+	var rdns []string
+	add := func(attrType string, values ...string) {
+		for _, value := range values {
+			if value != "" {
+				rdns = append(rdns, attrType+"="+escapeDNValue(value))
 			}
 		}
+	}
+	add("CN", s.CommonName)
+	add("E", s.Email)
+	add("SERIALNUMBER", s.SerialNumber)
+	add("OU", s.OrganizationalUnit)
+	add("O", s.Organization)
+	add("STREET", s.StreetAddress...)
+	add("L", s.Locality)
+	add("ST", s.State)
+	add("POSTALCODE", s.PostalCode)
+	add("C", s.Country)
+	add("DC", s.DomainComponents...)
+	return strings.Join(rdns, ",")
+}
+
+// escapeDNValue escapes an attribute value for use in a distinguished name as described by RFC 4514.
+func escapeDNValue(value string) string {
+	var escaped strings.Builder
+	runes := []rune(value)
+	for i, r := range runes {
+		switch {
+		case r == '"' || r == '+' || r == ',' || r == ';' || r == '<' || r == '>' || r == '\\':
+			escaped.WriteRune('\\')
+			escaped.WriteRune(r)
+		case r == 0:
+			escaped.WriteString("\\00")
+		case (r == '#' && i == 0) || (r == ' ' && (i == 0 || i == len(runes)-1)):
+			escaped.WriteRune('\\')
+			escaped.WriteRune(r)
+		default:
+			escaped.WriteRune(r)
+		}
+	}
+	return escaped.String()
+}
 
+func expandCertificateSubject(certificatePEM string) (subjectDN, error) {
+	block, _ := pem.Decode([]byte(certificatePEM))
+	if block == nil {
+		return subjectDN{}, fmt.Errorf("unable to decode certificate PEM")
 	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return subjectDN{}, err
+	}
+	return expandSubject(cert.Subject.String())
+}
+
+func flattenSubject(subject string) types.Object {
+	data, _ := expandSubject(subject)
 	result := types.Object{
 		Attrs: map[string]attr.Value{
-			"subject_common_name":         types.String{Value: data["subject_common_name"]},
-			"subject_locality":            types.String{Value: data["subject_locality"]},
-			"subject_organization":        types.String{Value: data["subject_organization"]},
-			"subject_state":               types.String{Value: data["subject_state"]},
-			"subject_country":             types.String{Value: data["subject_country"]},
-			"subject_organizational_unit": types.String{Value: data["subject_organizational_unit"]},
+			"subject_common_name":         types.String{Value: data.CommonName},
+			"subject_locality":            types.String{Value: data.Locality},
+			"subject_organization":        types.String{Value: data.Organization},
+			"subject_state":               types.String{Value: data.State},
+			"subject_country":             types.String{Value: data.Country},
+			"subject_organizational_unit": types.String{Value: data.OrganizationalUnit},
 		},
 		AttrTypes: map[string]attr.Type{
 			"subject_common_name":         types.StringType,
@@ -155,6 +394,20 @@ func flattenSubject(subject string) types.Object {
 	return result
 }
 
+func flattenStringList(values []string) types.List {
+	result := types.List{
+		ElemType: types.StringType,
+		Elems:    []attr.Value{},
+	}
+	for _, v := range values {
+		result.Elems = append(result.Elems, types.String{Value: v})
+	}
+	if len(result.Elems) == 0 {
+		result.Null = true
+	}
+	return result
+}
+
 func flattenMetadata(metadata interface{}) types.Map {
 	data := make(map[string]string)
 	if metadata != nil {
@@ -183,6 +436,7 @@ func flattenSANs(
 	tfDNSSANs types.List,
 	tfIPSANs types.List,
 	tfURISANs types.List,
+	compareAsSet bool,
 ) (types.List, types.List, types.List) {
 	sanIP4Array := types.List{
 		ElemType: types.StringType,
@@ -224,21 +478,36 @@ func flattenSANs(
 		if len(tfDNSSANs.Elems) > 0 {
 			var stateDNSSans []string
 			_ = tfDNSSANs.ElementsAs(nil, &stateDNSSans, true)
-			dnsSANs = sortInSameOrder(dnsSANs, stateDNSSans)
+			if compareAsSet && sansEqual(SAN_TYPE_DNS, dnsSANs, stateDNSSans) {
+				// Keep the configured order and formatting when Keyfactor Command returns the same set of SANs
+				dnsSANs = stateDNSSans
+			} else {
+				dnsSANs = sortInSameOrder(dnsSANs, stateDNSSans)
+			}
 		} else {
 			sort.Strings(dnsSANs)
 		}
 		if len(tfIPSANs.Elems) > 0 {
 			var stateIPSans []string
 			_ = tfIPSANs.ElementsAs(nil, &stateIPSans, true)
-			ipSANs = sortInSameOrder(ipSANs, stateIPSans)
+			if compareAsSet && sansEqual(SAN_TYPE_IP, ipSANs, stateIPSans) {
+				// Keep the configured order and formatting when Keyfactor Command returns the same set of SANs
+				ipSANs = stateIPSans
+			} else {
+				ipSANs = sortInSameOrder(ipSANs, stateIPSans)
+			}
 		} else {
 			sort.Strings(ipSANs)
 		}
 		if len(tfURISANs.Elems) > 0 {
 			var stateURISans []string
 			_ = tfURISANs.ElementsAs(nil, &stateURISans, true)
-			uriSANs = sortInSameOrder(uriSANs, stateURISans)
+			if compareAsSet && sansEqual(SAN_TYPE_URI, uriSANs, stateURISans) {
+				// Keep the configured order and formatting when Keyfactor Command returns the same set of SANs
+				uriSANs = stateURISans
+			} else {
+				uriSANs = sortInSameOrder(uriSANs, stateURISans)
+			}
 		} else {
 			sort.Strings(uriSANs)
 		}
@@ -594,3 +863,455 @@ func sortInSameOrder(unsortedList, sortedList []string) []string {
 	}
 	return sorted
 }
+
+func lookupTemplate(kfClient *api.Client, templateName string) (*api.GetTemplateResponse, error) {
+	templates, err := kfClient.GetTemplates()
+	if err != nil {
+		return nil, err
+	}
+	for _, template := range templates {
+		if strings.EqualFold(template.CommonName, templateName) || strings.EqualFold(template.TemplateName, templateName) {
+			t := template
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("template '%s' was not found on Keyfactor Command", templateName)
+}
+
+func validateEnrollmentFields(template *api.GetTemplateResponse, fields map[string]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	declared := make(map[string]api.TemplateEnrollmentFields)
+	var declaredNames []string
+	for _, ef := range template.EnrollmentFields {
+		declared[ef.Name] = ef
+		declaredNames = append(declaredNames, ef.Name)
+	}
+	sort.Strings(declaredNames)
+
+	var names []string
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := fields[name]
+		ef, ok := declared[name]
+		if !ok {
+			diags.AddError(
+				ERR_SUMMARY_INVALID_CERTIFICATE_RESOURCE,
+				fmt.Sprintf(
+					"Enrollment field '%s' is not declared on template '%s'. Valid enrollment fields are: [%s]",
+					name,
+					template.CommonName,
+					strings.Join(declaredNames, ", "),
+				),
+			)
+			continue
+		}
+		switch ef.DataType {
+		case TEMPLATE_ENROLLMENT_FIELD_TYPE_STRING:
+			continue
+		case TEMPLATE_ENROLLMENT_FIELD_TYPE_MULTIPLE_CHOICE:
+			valid := false
+			for _, option := range ef.Options {
+				if option == value {
+					valid = true
+					break
+				}
+			}
+			if !valid {
+				diags.AddError(
+					ERR_SUMMARY_INVALID_CERTIFICATE_RESOURCE,
+					fmt.Sprintf(
+						"Value '%s' is not a valid option for enrollment field '%s' on template '%s'. Valid options are: [%s]",
+						value,
+						name,
+						template.CommonName,
+						strings.Join(ef.Options, ", "),
+					),
+				)
+			}
+		default:
+			diags.AddError(
+				ERR_SUMMARY_INVALID_CERTIFICATE_RESOURCE,
+				fmt.Sprintf(
+					"Enrollment field '%s' on template '%s' has unsupported data type %d.",
+					name,
+					template.CommonName,
+					ef.DataType,
+				),
+			)
+		}
+	}
+	return diags
+}
+
+// validatePopulateMissingValuesFromAD checks that subject values can be populated from Active Directory for
+// enrollments against a template. Only templates that belong to an Active Directory forest have a directory to read
+// the values from.
+func validatePopulateMissingValuesFromAD(template *api.GetTemplateResponse) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if template.ForestRoot == "" {
+		diags.AddAttributeError(
+			path.Root("populate_missing_values_from_ad"),
+			ERR_SUMMARY_INVALID_CERTIFICATE_RESOURCE,
+			fmt.Sprintf(
+				"Template '%s' doesn't belong to an Active Directory forest, so missing values can't be populated from Active Directory.",
+				template.CommonName,
+			),
+		)
+	}
+	return diags
+}
+
+// certificatePolicyRequest holds the planned subject, SANs and public key of a certificate enrollment so they can be
+// checked against the policy of a certificate template before the enrollment is submitted.
+type certificatePolicyRequest struct {
+	CommonName         string
+	Organization       string
+	OrganizationalUnit string
+	Locality           string
+	State              string
+	Country            string
+	DNSSANs            []string
+	IPSANs             []string
+	URISANs            []string
+	PublicKey          interface{}
+}
+
+func (req certificatePolicyRequest) subjectPartValues(subjectPart string) ([]string, bool) {
+	var values []string
+	switch strings.ToUpper(subjectPart) {
+	case "CN":
+		values = []string{req.CommonName}
+	case "O":
+		values = []string{req.Organization}
+	case "OU":
+		values = []string{req.OrganizationalUnit}
+	case "L":
+		values = []string{req.Locality}
+	case "ST", "S":
+		values = []string{req.State}
+	case "C":
+		values = []string{req.Country}
+	case "DNS":
+		return req.DNSSANs, true
+	case "IP", "IP4", "IPV4", "IP6", "IPV6":
+		return req.IPSANs, true
+	case "URI":
+		return req.URISANs, true
+	default:
+		return nil, false
+	}
+	if values[0] == "" {
+		return nil, true
+	}
+	return values, true
+}
+
+func parseCSR(csr string) (*x509.CertificateRequest, error) {
+	block, _ := pem.Decode([]byte(csr))
+	if block == nil {
+		return nil, fmt.Errorf("unable to decode CSR PEM")
+	}
+	return x509.ParseCertificateRequest(block.Bytes)
+}
+
+func knownListStrings(list types.List) ([]string, bool) {
+	if list.Unknown {
+		return nil, false
+	}
+	var values []string
+	for _, elem := range list.Elems {
+		s, ok := elem.(types.String)
+		if !ok || s.Unknown {
+			return nil, false
+		}
+		if !s.Null {
+			values = append(values, s.Value)
+		}
+	}
+	return values, true
+}
+
+func validateTemplatePolicy(template *api.GetTemplateResponse, req certificatePolicyRequest) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Subject and SAN regexes
+	for _, regex := range template.TemplateRegexes {
+		values, ok := req.subjectPartValues(regex.SubjectPart)
+		if !ok {
+			continue
+		}
+		re, err := regexp.Compile(regex.RegEx)
+		if err != nil {
+			diags.AddWarning(
+				"Unable to evaluate template regular expression.",
+				fmt.Sprintf(
+					"Template '%s' regular expression '%s' for '%s' could not be evaluated and will only be enforced by Keyfactor Command: %s",
+					template.CommonName,
+					regex.RegEx,
+					regex.SubjectPart,
+					err.Error(),
+				),
+			)
+			continue
+		}
+		for _, value := range values {
+			if !re.MatchString(value) {
+				detail := fmt.Sprintf(
+					"Value '%s' for '%s' does not match the regular expression '%s' required by template '%s'.",
+					value,
+					regex.SubjectPart,
+					regex.RegEx,
+					template.CommonName,
+				)
+				if regex.Error != "" {
+					detail += " " + regex.Error
+				}
+				diags.AddError(ERR_SUMMARY_TEMPLATE_POLICY, detail)
+			}
+		}
+	}
+
+	// RFC 2818 enforcement requires the CN to be present as a DNS SAN
+	if template.RFCEnforcement {
+		if len(req.DNSSANs) == 0 {
+			diags.AddError(
+				ERR_SUMMARY_TEMPLATE_POLICY,
+				fmt.Sprintf("Template '%s' enforces RFC 2818 and requires at least one DNS SAN.", template.CommonName),
+			)
+		} else if req.CommonName != "" {
+			found := false
+			for _, san := range req.DNSSANs {
+				if strings.EqualFold(san, req.CommonName) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				diags.AddError(
+					ERR_SUMMARY_TEMPLATE_POLICY,
+					fmt.Sprintf(
+						"Template '%s' enforces RFC 2818 and common name '%s' must also be included in dns_sans.",
+						template.CommonName,
+						req.CommonName,
+					),
+				)
+			}
+		}
+	}
+
+	// Key type and size can only be checked when the key is supplied by the caller in a CSR
+	if req.PublicKey != nil {
+		var keyType string
+		var keySize int
+		switch key := req.PublicKey.(type) {
+		case *rsa2.PublicKey:
+			keyType = "RSA"
+			keySize = key.N.BitLen()
+		case *ecdsa.PublicKey:
+			keyType = "ECC"
+			keySize = key.Curve.Params().BitSize
+		}
+
+		templateKeyType := strings.ToUpper(template.KeyType)
+		if templateKeyType == "ECDSA" || templateKeyType == "EC" {
+			templateKeyType = "ECC"
+		}
+		if keyType != "" && templateKeyType != "" && templateKeyType != keyType {
+			diags.AddError(
+				ERR_SUMMARY_TEMPLATE_POLICY,
+				fmt.Sprintf(
+					"CSR key type '%s' is not allowed by template '%s' which requires key type '%s'.",
+					keyType,
+					template.CommonName,
+					template.KeyType,
+				),
+			)
+		} else if minKeySize, err := strconv.Atoi(template.KeySize); err == nil && keySize > 0 && keySize < minKeySize {
+			diags.AddError(
+				ERR_SUMMARY_TEMPLATE_POLICY,
+				fmt.Sprintf(
+					"CSR key size %d is smaller than the minimum key size %d allowed by template '%s'.",
+					keySize,
+					minKeySize,
+					template.CommonName,
+				),
+			)
+		}
+	}
+
+	return diags
+}
+
+func validateAllowedRequester(kfClient *api.Client, template *api.GetTemplateResponse, clientAuth api.AuthConfig) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !template.UseAllowedRequesters {
+		return diags
+	}
+
+	identities, err := kfClient.GetSecurityIdentities()
+	if err != nil {
+		diags.AddWarning(
+			"Unable to verify allowed requesters.",
+			fmt.Sprintf(
+				"Could not list security identities to check the allowed requesters of template '%s', this will only be enforced by Keyfactor Command: %s",
+				template.CommonName,
+				err.Error(),
+			),
+		)
+		return diags
+	}
+
+	username := clientAuth.Username
+	domain := clientAuth.Domain
+	if parts := strings.SplitN(username, "\\", 2); len(parts) == 2 {
+		domain = parts[0]
+		username = parts[1]
+	}
+	shortDomain := strings.SplitN(domain, ".", 2)[0]
+
+	for _, identity := range identities {
+		parts := strings.SplitN(identity.AccountName, "\\", 2)
+		if len(parts) != 2 || !strings.EqualFold(parts[1], username) {
+			continue
+		}
+		if !strings.EqualFold(parts[0], domain) && !strings.EqualFold(parts[0], shortDomain) {
+			continue
+		}
+		for _, role := range identity.Roles {
+			for _, requester := range template.AllowedRequesters {
+				if strings.EqualFold(role.Name, requester) {
+					return diags
+				}
+			}
+		}
+		diags.AddError(
+			ERR_SUMMARY_TEMPLATE_POLICY,
+			fmt.Sprintf(
+				"Identity '%s' is not an allowed requester of template '%s'. Allowed requesters are: [%s]",
+				identity.AccountName,
+				template.CommonName,
+				strings.Join(template.AllowedRequesters, ", "),
+			),
+		)
+		return diags
+	}
+
+	diags.AddWarning(
+		"Unable to verify allowed requesters.",
+		fmt.Sprintf(
+			"No security identity was found for '%s\\%s', it may be granted access through a group. Allowed requesters of template '%s' will only be enforced by Keyfactor Command.",
+			domain,
+			username,
+			template.CommonName,
+		),
+	)
+	return diags
+}
+
+// normalizeSAN returns the canonical form of a SAN used for comparison. DNS names are compared case-insensitively
+// without a trailing dot and IP addresses are compared in their canonical form.
+func normalizeSAN(sanType string, san string) string {
+	san = strings.TrimSpace(san)
+	switch sanType {
+	case SAN_TYPE_DNS:
+		return strings.TrimSuffix(strings.ToLower(san), ".")
+	case SAN_TYPE_IP:
+		if ip := net.ParseIP(san); ip != nil {
+			return ip.String()
+		}
+		return strings.ToLower(san)
+	default:
+		return san
+	}
+}
+
+// sansEqual compares two SAN lists as sets after normalizing each value.
+func sansEqual(sanType string, a []string, b []string) bool {
+	aSet := make(map[string]bool)
+	for _, san := range a {
+		aSet[normalizeSAN(sanType, san)] = true
+	}
+	bSet := make(map[string]bool)
+	for _, san := range b {
+		bSet[normalizeSAN(sanType, san)] = true
+	}
+	if len(aSet) != len(bSet) {
+		return false
+	}
+	for san := range aSet {
+		if !bSet[san] {
+			return false
+		}
+	}
+	return true
+}
+
+// sanListsEqual compares two Terraform SAN lists as sets. Unknown lists are never considered equal, and null and
+// empty lists are considered equal.
+func sanListsEqual(sanType string, a types.List, b types.List) bool {
+	aSANs, aKnown := knownListStrings(a)
+	bSANs, bKnown := knownListStrings(b)
+	if !aKnown || !bKnown {
+		return false
+	}
+	return sansEqual(sanType, aSANs, bSANs)
+}
+
+func flattenCertificateLocations(locations []api.CertificateLocations) []KeyfactorCertificateLocation {
+	result := []KeyfactorCertificateLocation{}
+	for _, location := range locations {
+		result = append(
+			result, KeyfactorCertificateLocation{
+				StoreId:       types.String{Value: location.CertStoreId},
+				ClientMachine: types.String{Value: location.StoreMachine},
+				StorePath:     types.String{Value: location.StorePath},
+				StoreType:     types.Int64{Value: int64(location.StoreType)},
+				Alias:         types.String{Value: location.Alias},
+			},
+		)
+	}
+	return result
+}
+
+func certificateLocationAttributes() map[string]tfsdk.Attribute {
+	return map[string]tfsdk.Attribute{
+		"certificate_store_id": {
+			Type:        types.StringType,
+			Computed:    true,
+			Description: "GUID of the certificate store the certificate is in.",
+		},
+		"client_machine": {
+			Type:        types.StringType,
+			Computed:    true,
+			Description: "Client machine of the certificate store.",
+		},
+		"store_path": {
+			Type:        types.StringType,
+			Computed:    true,
+			Description: "Path of the certificate store on the client machine.",
+		},
+		"store_type": {
+			Type:        types.Int64Type,
+			Computed:    true,
+			Description: "Integer ID of the certificate store type.",
+		},
+		"alias": {
+			Type:        types.StringType,
+			Computed:    true,
+			Description: "Alias of the certificate in the certificate store.",
+		},
+	}
+}
+
+// sanListsMatch compares two Terraform SAN lists, as sets when compareAsSet is true and as lists otherwise.
+func sanListsMatch(compareAsSet bool, sanType string, a types.List, b types.List) bool {
+	if compareAsSet {
+		return sanListsEqual(sanType, a, b)
+	}
+	return a.Equal(b)
+}
@@ -0,0 +1,224 @@
+package keyfactor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDistinguishedName(t *testing.T) {
+	tests := []struct {
+		name    string
+		dn      string
+		want    [][]dnAttribute
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			dn:   "",
+			want: nil,
+		},
+		{
+			name: "simple",
+			dn:   "CN=example.com,O=Example Inc,C=US",
+			want: [][]dnAttribute{
+				{{Type: "CN", Value: "example.com"}},
+				{{Type: "O", Value: "Example Inc"}},
+				{{Type: "C", Value: "US"}},
+			},
+		},
+		{
+			name: "spaces after separators",
+			dn:   "CN=example.com, O=Example Inc, C=US",
+			want: [][]dnAttribute{
+				{{Type: "CN", Value: "example.com"}},
+				{{Type: "O", Value: "Example Inc"}},
+				{{Type: "C", Value: "US"}},
+			},
+		},
+		{
+			name: "escaped comma",
+			dn:   `CN=Doe\, John,O=Example\, Inc.`,
+			want: [][]dnAttribute{
+				{{Type: "CN", Value: "Doe, John"}},
+				{{Type: "O", Value: "Example, Inc."}},
+			},
+		},
+		{
+			name: "escaped special characters",
+			dn:   `CN=a\+b\;c\<d\>e\"f\\g,OU=\#hash,L=\ padded\ `,
+			want: [][]dnAttribute{
+				{{Type: "CN", Value: `a+b;c<d>e"f\g`}},
+				{{Type: "OU", Value: "#hash"}},
+				{{Type: "L", Value: " padded "}},
+			},
+		},
+		{
+			name: "hex escaped UTF-8",
+			dn:   `CN=Lu\C4\8Di\C4\87,C=HR`,
+			want: [][]dnAttribute{
+				{{Type: "CN", Value: "Lučić"}},
+				{{Type: "C", Value: "HR"}},
+			},
+		},
+		{
+			name: "multi-valued RDN",
+			dn:   "CN=example.com+SERIALNUMBER=1234,O=Example",
+			want: [][]dnAttribute{
+				{{Type: "CN", Value: "example.com"}, {Type: "SERIALNUMBER", Value: "1234"}},
+				{{Type: "O", Value: "Example"}},
+			},
+		},
+		{
+			name: "hex encoded BER value",
+			dn:   "1.3.6.1.4.1.1466.0=#04024869,O=Test",
+			want: [][]dnAttribute{
+				{{Type: "1.3.6.1.4.1.1466.0", Value: "Hi"}},
+				{{Type: "O", Value: "Test"}},
+			},
+		},
+		{
+			name: "quoted value",
+			dn:   `CN="Doe, John",O=Example`,
+			want: [][]dnAttribute{
+				{{Type: "CN", Value: "Doe, John"}},
+				{{Type: "O", Value: "Example"}},
+			},
+		},
+		{
+			name:    "missing equals",
+			dn:      "CN=example.com,Example",
+			wantErr: true,
+		},
+		{
+			name:    "trailing separator",
+			dn:      "CN=example.com,",
+			wantErr: true,
+		},
+		{
+			name:    "invalid hex value",
+			dn:      "CN=#zz",
+			wantErr: true,
+		},
+		{
+			name:    "unterminated quoted value",
+			dn:      `CN="example.com`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDistinguishedName(tt.dn)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDistinguishedName(%q) error = %v, wantErr %v", tt.dn, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseDistinguishedName(%q) = %v, want %v", tt.dn, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandSubject(t *testing.T) {
+	tests := []struct {
+		name string
+		dn   string
+		want subjectDN
+	}{
+		{
+			name: "domain components are not countries",
+			dn:   "CN=host,DC=example,DC=com,C=US",
+			want: subjectDN{CommonName: "host", Country: "US", DomainComponents: []string{"example", "com"}},
+		},
+		{
+			name: "country inside another value",
+			dn:   `CN=C=US,O=Example`,
+			want: subjectDN{CommonName: "C=US", Organization: "Example"},
+		},
+		{
+			name: "organizational unit is not organization",
+			dn:   "OU=Engineering,O=Example",
+			want: subjectDN{Organization: "Example", OrganizationalUnit: "Engineering"},
+		},
+		{
+			name: "extended attributes",
+			dn:   "CN=host,E=admin@example.com,STREET=1 Main St,STREET=Suite 2,POSTALCODE=12345,SERIALNUMBER=42",
+			want: subjectDN{
+				CommonName:    "host",
+				Email:         "admin@example.com",
+				StreetAddress: []string{"1 Main St", "Suite 2"},
+				PostalCode:    "12345",
+				SerialNumber:  "42",
+			},
+		},
+		{
+			name: "attribute OIDs and keywords",
+			dn:   "2.5.4.3=host,emailAddress=admin@example.com,S=Ohio,0.9.2342.19200300.100.1.25=com",
+			want: subjectDN{CommonName: "host", Email: "admin@example.com", State: "Ohio", DomainComponents: []string{"com"}},
+		},
+		{
+			name: "first value is used",
+			dn:   "CN=first,CN=second",
+			want: subjectDN{CommonName: "first"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandSubject(tt.dn)
+			if err != nil {
+				t.Fatalf("expandSubject(%q) error = %v", tt.dn, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expandSubject(%q) = %+v, want %+v", tt.dn, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatDistinguishedName(t *testing.T) {
+	tests := []struct {
+		name    string
+		subject subjectDN
+		want    string
+	}{
+		{
+			name:    "escaped values",
+			subject: subjectDN{CommonName: "Doe, John", Organization: `a+b"c`, Locality: " #padded "},
+			want:    `CN=Doe\, John,O=a\+b\"c,L=\ #padded\ `,
+		},
+		{
+			name: "extended attributes",
+			subject: subjectDN{
+				CommonName:       "host",
+				Email:            "admin@example.com",
+				StreetAddress:    []string{"1 Main St"},
+				PostalCode:       "12345",
+				Country:          "US",
+				DomainComponents: []string{"example", "com"},
+			},
+			want: "CN=host,E=admin@example.com,STREET=1 Main St,POSTALCODE=12345,C=US,DC=example,DC=com",
+		},
+		{
+			name:    "leading hash",
+			subject: subjectDN{CommonName: "#1"},
+			want:    `CN=\#1`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := formatDistinguishedName(tt.subject)
+			if got != tt.want {
+				t.Fatalf("formatDistinguishedName() = %q, want %q", got, tt.want)
+			}
+			parsed, err := expandSubject(got)
+			if err != nil {
+				t.Fatalf("expandSubject(%q) error = %v", got, err)
+			}
+			if !reflect.DeepEqual(parsed, tt.subject) {
+				t.Errorf("expandSubject(%q) = %+v, want %+v", got, parsed, tt.subject)
+			}
+		})
+	}
+}
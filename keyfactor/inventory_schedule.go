@@ -0,0 +1,394 @@
+package keyfactor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Keyfactor/keyfactor-go-client/v2/api"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// storeInventorySchedule is the Keyfactor Command schedule model of certificate store inventory jobs. The client's
+// api.InventorySchedule has no weekly schedule, so schedules are read and written with it only when they fit.
+type storeInventorySchedule struct {
+	Immediate   *bool                  `json:"Immediate,omitempty"`
+	Interval    *api.InventoryInterval `json:"Interval,omitempty"`
+	Daily       *api.InventoryDaily    `json:"Daily,omitempty"`
+	Weekly      *storeInventoryWeekly  `json:"Weekly,omitempty"`
+	ExactlyOnce *api.InventoryOnce     `json:"ExactlyOnce,omitempty"`
+}
+
+type storeInventoryWeekly struct {
+	// Days are day names, older Command versions return them as day of week numbers
+	Days []interface{} `json:"Days"`
+	Time string        `json:"Time"`
+}
+
+var inventoryScheduleAttrTypes = map[string]attr.Type{
+	"immediate":        types.BoolType,
+	"interval_minutes": types.Int64Type,
+	"daily_time":       types.StringType,
+	"weekly_days":      types.ListType{ElemType: types.StringType},
+	"weekly_time":      types.StringType,
+	"exactly_once":     types.StringType,
+}
+
+func inventoryScheduleAttributes(computed bool) map[string]tfsdk.Attribute {
+	return jobScheduleAttributes("inventory", "the certificate store is created or updated", computed)
+}
+
+// jobScheduleAttributes returns the attributes of a schedule block for orchestrator jobs of the given kind, which run
+// immediately after the given event when `immediate` is set.
+func jobScheduleAttributes(job string, event string, computed bool) map[string]tfsdk.Attribute {
+	article := "a"
+	if strings.ContainsAny(job[:1], "aeiou") {
+		article = "an"
+	}
+	return map[string]tfsdk.Attribute{
+		"immediate": {
+			Type:        types.BoolType,
+			Optional:    !computed,
+			Computed:    computed,
+			Description: fmt.Sprintf("Run a single %s job as soon as %s.", job, event),
+		},
+		"interval_minutes": {
+			Type:        types.Int64Type,
+			Optional:    !computed,
+			Computed:    computed,
+			Description: fmt.Sprintf("Run %s %s job every given number of minutes.", article, job),
+		},
+		"daily_time": {
+			Type:        types.StringType,
+			Optional:    !computed,
+			Computed:    computed,
+			Description: fmt.Sprintf("Run %s %s job every day at the given UTC time of day, formatted as `HH:MM`.", article, job),
+		},
+		"weekly_days": {
+			Type:        types.ListType{ElemType: types.StringType},
+			Optional:    !computed,
+			Computed:    computed,
+			Description: fmt.Sprintf("Days of the week to run %s %s job on, such as `Monday`. Requires `weekly_time`.", article, job),
+		},
+		"weekly_time": {
+			Type:        types.StringType,
+			Optional:    !computed,
+			Computed:    computed,
+			Description: fmt.Sprintf("UTC time of day, formatted as `HH:MM`, to run the weekly %s job at. Requires `weekly_days`.", job),
+		},
+		"exactly_once": {
+			Type:        types.StringType,
+			Optional:    !computed,
+			Computed:    computed,
+			Description: fmt.Sprintf("RFC 3339 timestamp to run a single %s job at.", job),
+		},
+	}
+}
+
+// validateInventorySchedule checks that an inventory schedule configures exactly one kind of schedule with valid values.
+func validateInventorySchedule(ctx context.Context, scheduleList types.List, schedulePath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if scheduleList.Null || scheduleList.Unknown || len(scheduleList.Elems) == 0 {
+		return diags
+	}
+
+	var schedules []CertificateStoreInventorySchedule
+	diags.Append(scheduleList.ElementsAs(ctx, &schedules, true)...)
+	if diags.HasError() {
+		return diags
+	}
+	schedule := schedules[0]
+	schedulePath = schedulePath.AtListIndex(0)
+
+	kinds := 0
+	if schedule.Immediate.Value || schedule.Immediate.Unknown {
+		kinds++
+	}
+	if !schedule.IntervalMinutes.Null {
+		kinds++
+		if !schedule.IntervalMinutes.Unknown && schedule.IntervalMinutes.Value <= 0 {
+			diags.AddAttributeError(schedulePath.AtName("interval_minutes"), ERR_SUMMARY_INVENTORY_SCHEDULE, "'interval_minutes' must be greater than 0.")
+		}
+	}
+	if !schedule.DailyTime.Null {
+		kinds++
+		validateInventoryScheduleClock(&diags, schedulePath.AtName("daily_time"), schedule.DailyTime)
+	}
+	if !schedule.WeeklyDays.Null || !schedule.WeeklyTime.Null {
+		kinds++
+		if schedule.WeeklyDays.Null || schedule.WeeklyTime.Null {
+			diags.AddAttributeError(schedulePath, ERR_SUMMARY_INVENTORY_SCHEDULE, "'weekly_days' and 'weekly_time' must be set together.")
+		}
+		validateInventoryScheduleClock(&diags, schedulePath.AtName("weekly_time"), schedule.WeeklyTime)
+		for i, day := range schedule.WeeklyDays.Elems {
+			dayValue, ok := day.(types.String)
+			if !ok || dayValue.Unknown || dayValue.Null {
+				continue
+			}
+			if _, err := parseWeekday(dayValue.Value); err != nil {
+				diags.AddAttributeError(schedulePath.AtName("weekly_days").AtListIndex(i), ERR_SUMMARY_INVENTORY_SCHEDULE, err.Error())
+			}
+		}
+	}
+	if !schedule.ExactlyOnce.Null {
+		kinds++
+		if !schedule.ExactlyOnce.Unknown {
+			if _, err := time.Parse(time.RFC3339, schedule.ExactlyOnce.Value); err != nil {
+				diags.AddAttributeError(
+					schedulePath.AtName("exactly_once"),
+					ERR_SUMMARY_INVENTORY_SCHEDULE,
+					fmt.Sprintf("'%s' is not a valid RFC 3339 timestamp: %s", schedule.ExactlyOnce.Value, err.Error()),
+				)
+			}
+		}
+	}
+
+	if kinds != 1 {
+		diags.AddAttributeError(
+			schedulePath,
+			ERR_SUMMARY_INVENTORY_SCHEDULE,
+			"Exactly one of 'immediate', 'interval_minutes', 'daily_time', 'weekly_days' with 'weekly_time' or 'exactly_once' must be set.",
+		)
+	}
+	return diags
+}
+
+func validateInventoryScheduleClock(diags *diag.Diagnostics, attributePath path.Path, value types.String) {
+	if value.Null || value.Unknown {
+		return
+	}
+	if _, err := time.Parse("15:04", value.Value); err != nil {
+		diags.AddAttributeError(
+			attributePath,
+			ERR_SUMMARY_INVENTORY_SCHEDULE,
+			fmt.Sprintf("'%s' is not a valid time of day, use the 24 hour 'HH:MM' format.", value.Value),
+		)
+	}
+}
+
+func parseWeekday(day string) (time.Weekday, error) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(d.String(), day) {
+			return d, nil
+		}
+	}
+	return time.Sunday, fmt.Errorf("'%s' is not a day of the week", day)
+}
+
+// sameWeekdays reports whether two lists of day names hold the same days, ignoring order and case.
+func sameWeekdays(a []string, b []string) bool {
+	weekdays := func(days []string) map[time.Weekday]bool {
+		set := map[time.Weekday]bool{}
+		for _, day := range days {
+			weekday, err := parseWeekday(day)
+			if err != nil {
+				return nil
+			}
+			set[weekday] = true
+		}
+		return set
+	}
+	setA, setB := weekdays(a), weekdays(b)
+	if setA == nil || setB == nil || len(setA) != len(setB) {
+		return false
+	}
+	for weekday := range setA {
+		if !setB[weekday] {
+			return false
+		}
+	}
+	return true
+}
+
+// expandInventorySchedule converts an inventory_schedule block to the Keyfactor Command schedule model. An empty
+// schedule turns scheduled inventory off.
+func expandInventorySchedule(ctx context.Context, scheduleList types.List) (*storeInventorySchedule, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	result := &storeInventorySchedule{}
+	if scheduleList.Null || len(scheduleList.Elems) == 0 {
+		return result, diags
+	}
+
+	var schedules []CertificateStoreInventorySchedule
+	diags.Append(scheduleList.ElementsAs(ctx, &schedules, true)...)
+	if diags.HasError() {
+		return result, diags
+	}
+	schedule := schedules[0]
+
+	switch {
+	case schedule.Immediate.Value:
+		result.Immediate = boolToPointer(true)
+	case !schedule.IntervalMinutes.Null:
+		result.Interval = &api.InventoryInterval{Minutes: int(schedule.IntervalMinutes.Value)}
+	case !schedule.DailyTime.Null:
+		result.Daily = &api.InventoryDaily{Time: inventoryScheduleTime(schedule.DailyTime.Value)}
+	case !schedule.WeeklyDays.Null:
+		var days []string
+		diags.Append(schedule.WeeklyDays.ElementsAs(ctx, &days, false)...)
+		result.Weekly = &storeInventoryWeekly{Time: inventoryScheduleTime(schedule.WeeklyTime.Value)}
+		for _, day := range days {
+			weekday, _ := parseWeekday(day)
+			result.Weekly.Days = append(result.Weekly.Days, weekday.String())
+		}
+	case !schedule.ExactlyOnce.Null:
+		runAt, err := time.Parse(time.RFC3339, schedule.ExactlyOnce.Value)
+		if err != nil {
+			diags.AddError(ERR_SUMMARY_INVENTORY_SCHEDULE, fmt.Sprintf("'%s' is not a valid RFC 3339 timestamp: %s", schedule.ExactlyOnce.Value, err.Error()))
+			return result, diags
+		}
+		result.ExactlyOnce = &api.InventoryOnce{Time: runAt.UTC().Format(time.RFC3339)}
+	}
+	return result, diags
+}
+
+// inventoryScheduleTime returns the timestamp Keyfactor Command expects for a daily or weekly time of day. Only the
+// time of day of the timestamp is used.
+func inventoryScheduleTime(clock string) string {
+	t, _ := time.Parse("15:04", clock)
+	now := time.Now().UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, time.UTC).Format(time.RFC3339)
+}
+
+// inventoryScheduleClock returns the UTC time of day of a timestamp returned by Keyfactor Command as `HH:MM`.
+func inventoryScheduleClock(timestamp string) string {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "15:04"} {
+		if t, err := time.Parse(layout, timestamp); err == nil {
+			return t.UTC().Format("15:04")
+		}
+	}
+	return timestamp
+}
+
+// clientSchedule returns the schedule for the client's store create and update calls, which can't hold weekly
+// schedules. Weekly schedules are set with setStoreInventorySchedule afterwards.
+func (s storeInventorySchedule) clientSchedule() *api.InventorySchedule {
+	return &api.InventorySchedule{
+		Immediate:   s.Immediate,
+		Interval:    s.Interval,
+		Daily:       s.Daily,
+		ExactlyOnce: s.ExactlyOnce,
+	}
+}
+
+// flattenInventorySchedule converts a schedule returned by Keyfactor Command to an inventory_schedule block. Values from
+// prior are kept when they describe the same schedule, and an immediate schedule is kept after its job has run.
+func flattenInventorySchedule(ctx context.Context, schedule *storeInventorySchedule, prior types.List) types.List {
+	objectType := types.ObjectType{AttrTypes: inventoryScheduleAttrTypes}
+	result := types.List{ElemType: objectType, Elems: []attr.Value{}}
+
+	var priorSchedule CertificateStoreInventorySchedule
+	if !prior.Null && !prior.Unknown && len(prior.Elems) > 0 {
+		var priorSchedules []CertificateStoreInventorySchedule
+		if diags := prior.ElementsAs(ctx, &priorSchedules, true); !diags.HasError() {
+			priorSchedule = priorSchedules[0]
+		}
+	}
+
+	attrs := map[string]attr.Value{
+		"immediate":        types.Bool{Null: true},
+		"interval_minutes": types.Int64{Null: true},
+		"daily_time":       types.String{Null: true},
+		"weekly_days":      types.List{ElemType: types.StringType, Null: true},
+		"weekly_time":      types.String{Null: true},
+		"exactly_once":     types.String{Null: true},
+	}
+	switch {
+	case schedule == nil && priorSchedule.Immediate.Value:
+		// The schedule of an immediate inventory job is cleared once it has run
+		return prior
+	case schedule == nil:
+		return result
+	case schedule.Immediate != nil && *schedule.Immediate:
+		attrs["immediate"] = types.Bool{Value: true}
+	case schedule.Interval != nil:
+		attrs["interval_minutes"] = types.Int64{Value: int64(schedule.Interval.Minutes)}
+	case schedule.Daily != nil:
+		attrs["daily_time"] = types.String{Value: inventoryScheduleClock(schedule.Daily.Time)}
+	case schedule.Weekly != nil:
+		var days []string
+		for _, day := range schedule.Weekly.Days {
+			switch d := day.(type) {
+			case string:
+				days = append(days, d)
+			case float64:
+				days = append(days, time.Weekday(int(d)).String())
+			}
+		}
+		weeklyDays := flattenStringList(days)
+		if priorDays, ok := knownListStrings(priorSchedule.WeeklyDays); ok && sameWeekdays(priorDays, days) {
+			// Command doesn't keep the order of the days
+			weeklyDays = priorSchedule.WeeklyDays
+		}
+		attrs["weekly_days"] = weeklyDays
+		attrs["weekly_time"] = types.String{Value: inventoryScheduleClock(schedule.Weekly.Time)}
+	case schedule.ExactlyOnce != nil:
+		exactlyOnce := types.String{Value: schedule.ExactlyOnce.Time}
+		if runAt, err := time.Parse(time.RFC3339, schedule.ExactlyOnce.Time); err == nil {
+			exactlyOnce.Value = runAt.UTC().Format(time.RFC3339)
+			if priorRunAt, err := time.Parse(time.RFC3339, priorSchedule.ExactlyOnce.Value); err == nil && priorRunAt.Equal(runAt) {
+				exactlyOnce = priorSchedule.ExactlyOnce
+			}
+		}
+		attrs["exactly_once"] = exactlyOnce
+	default:
+		if priorSchedule.Immediate.Value {
+			// The schedule of an immediate inventory job is cleared once it has run
+			return prior
+		}
+		return result
+	}
+
+	result.Elems = append(result.Elems, types.Object{AttrTypes: inventoryScheduleAttrTypes, Attrs: attrs})
+	return result
+}
+
+// getStoreInventorySchedule reads the inventory schedule of a certificate store, including weekly schedules that the
+// client's certificate store model drops.
+func getStoreInventorySchedule(ctx context.Context, p provider, storeId string) (*storeInventorySchedule, error) {
+	var store struct {
+		InventorySchedule *storeInventorySchedule `json:"InventorySchedule"`
+	}
+	err := p.commandRequest(ctx, http.MethodGet, "CertificateStores/"+storeId, nil, nil, &store)
+	if err != nil {
+		return nil, err
+	}
+	return store.InventorySchedule, nil
+}
+
+// setStoreInventorySchedule replaces the inventory schedule of a certificate store.
+func setStoreInventorySchedule(ctx context.Context, p provider, storeId string, schedule *storeInventorySchedule) error {
+	body := map[string]interface{}{
+		"StoreIds": []string{storeId},
+		"Schedule": schedule,
+	}
+	return p.commandRequest(ctx, http.MethodPost, "CertificateStores/Schedule", nil, body, nil)
+}
+
+// storeScheduleFromClient converts a schedule read with the client, for when the store can't be read directly.
+func storeScheduleFromClient(schedule api.InventorySchedule) *storeInventorySchedule {
+	return &storeInventorySchedule{
+		Immediate:   schedule.Immediate,
+		Interval:    schedule.Interval,
+		Daily:       schedule.Daily,
+		ExactlyOnce: schedule.ExactlyOnce,
+	}
+}
+
+// readStoreInventorySchedule returns the inventory schedule of a certificate store, falling back to the schedule
+// already read with the client when the store can't be read directly.
+func readStoreInventorySchedule(ctx context.Context, p provider, storeId string, fallback api.InventorySchedule) *storeInventorySchedule {
+	schedule, err := getStoreInventorySchedule(ctx, p, storeId)
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Unable to read inventory schedule of certificate store '%s', weekly schedules can't be read: %s", storeId, err.Error()))
+		return storeScheduleFromClient(fallback)
+	}
+	return schedule
+}
@@ -0,0 +1,144 @@
+package keyfactor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testInventoryScheduleList(attrs map[string]attr.Value) types.List {
+	values := map[string]attr.Value{
+		"immediate":        types.Bool{Null: true},
+		"interval_minutes": types.Int64{Null: true},
+		"daily_time":       types.String{Null: true},
+		"weekly_days":      types.List{ElemType: types.StringType, Null: true},
+		"weekly_time":      types.String{Null: true},
+		"exactly_once":     types.String{Null: true},
+	}
+	for name, value := range attrs {
+		values[name] = value
+	}
+	return types.List{
+		ElemType: types.ObjectType{AttrTypes: inventoryScheduleAttrTypes},
+		Elems:    []attr.Value{types.Object{AttrTypes: inventoryScheduleAttrTypes, Attrs: values}},
+	}
+}
+
+func TestInventoryScheduleRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule types.List
+	}{
+		{
+			name:     "immediate",
+			schedule: testInventoryScheduleList(map[string]attr.Value{"immediate": types.Bool{Value: true}}),
+		},
+		{
+			name:     "interval",
+			schedule: testInventoryScheduleList(map[string]attr.Value{"interval_minutes": types.Int64{Value: 60}}),
+		},
+		{
+			name:     "daily",
+			schedule: testInventoryScheduleList(map[string]attr.Value{"daily_time": types.String{Value: "23:30"}}),
+		},
+		{
+			name: "weekly",
+			schedule: testInventoryScheduleList(map[string]attr.Value{
+				"weekly_days": flattenStringList([]string{"Monday", "friday"}),
+				"weekly_time": types.String{Value: "02:00"},
+			}),
+		},
+		{
+			name:     "exactly once",
+			schedule: testInventoryScheduleList(map[string]attr.Value{"exactly_once": types.String{Value: "2030-01-01T02:00:00Z"}}),
+		},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expanded, diags := expandInventorySchedule(ctx, tt.schedule)
+			if diags.HasError() {
+				t.Fatalf("expandInventorySchedule() diagnostics = %v", diags)
+			}
+			got := flattenInventorySchedule(ctx, expanded, tt.schedule)
+			if !got.Equal(tt.schedule) {
+				t.Errorf("flattenInventorySchedule() = %v, want %v", got, tt.schedule)
+			}
+		})
+	}
+}
+
+func TestFlattenInventoryScheduleWeeklyDays(t *testing.T) {
+	prior := testInventoryScheduleList(map[string]attr.Value{
+		"weekly_days": flattenStringList([]string{"Friday", "Monday"}),
+		"weekly_time": types.String{Value: "02:00"},
+	})
+
+	tests := []struct {
+		name string
+		days []interface{}
+		want []string
+	}{
+		{
+			name: "reordered days keep the prior order",
+			days: []interface{}{"Monday", "Friday"},
+			want: []string{"Friday", "Monday"},
+		},
+		{
+			name: "day numbers",
+			days: []interface{}{float64(1), float64(5)},
+			want: []string{"Friday", "Monday"},
+		},
+		{
+			name: "changed days",
+			days: []interface{}{"Monday", "Tuesday"},
+			want: []string{"Monday", "Tuesday"},
+		},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := &storeInventorySchedule{Weekly: &storeInventoryWeekly{Days: tt.days, Time: "2024-01-01T02:00:00Z"}}
+			got := flattenInventorySchedule(ctx, schedule, prior)
+			want := testInventoryScheduleList(map[string]attr.Value{
+				"weekly_days": flattenStringList(tt.want),
+				"weekly_time": types.String{Value: "02:00"},
+			})
+			if !got.Equal(want) {
+				t.Errorf("flattenInventorySchedule() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestFlattenInventoryScheduleClearedImmediate(t *testing.T) {
+	prior := testInventoryScheduleList(map[string]attr.Value{"immediate": types.Bool{Value: true}})
+
+	tests := []struct {
+		name     string
+		schedule *storeInventorySchedule
+	}{
+		{
+			name:     "null schedule",
+			schedule: nil,
+		},
+		{
+			name:     "empty schedule",
+			schedule: &storeInventorySchedule{},
+		},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := flattenInventorySchedule(ctx, tt.schedule, prior)
+			if !got.Equal(prior) {
+				t.Errorf("flattenInventorySchedule() = %v, want %v", got, prior)
+			}
+		})
+	}
+}
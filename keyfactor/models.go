@@ -45,12 +45,17 @@ type KeyfactorCertificate struct {
 	// CSR Request Fields
 	CSR types.String `tfsdk:"csr"`
 	// Subject Fields
-	CommonName         types.String `tfsdk:"common_name"`
-	Locality           types.String `tfsdk:"locality"`
-	State              types.String `tfsdk:"state"`
-	Country            types.String `tfsdk:"country"`
-	Organization       types.String `tfsdk:"organization"`
-	OrganizationalUnit types.String `tfsdk:"organizational_unit"`
+	CommonName          types.String `tfsdk:"common_name"`
+	Locality            types.String `tfsdk:"locality"`
+	State               types.String `tfsdk:"state"`
+	Country             types.String `tfsdk:"country"`
+	Organization        types.String `tfsdk:"organization"`
+	OrganizationalUnit  types.String `tfsdk:"organizational_unit"`
+	Email               types.String `tfsdk:"email"`
+	StreetAddress       types.List   `tfsdk:"street_address"`
+	PostalCode          types.String `tfsdk:"postal_code"`
+	SubjectSerialNumber types.String `tfsdk:"subject_serial_number"`
+	DomainComponents    types.List   `tfsdk:"domain_components"`
 	// SAN Fields
 	DNSSANs types.List `tfsdk:"dns_sans"`
 	IPSANs  types.List `tfsdk:"ip_sans"`
@@ -72,15 +77,131 @@ type KeyfactorCertificate struct {
 	CertificateId        types.Int64  `tfsdk:"certificate_id"`
 	Metadata             types.Map    `tfsdk:"metadata"`
 	CollectionId         types.Int64  `tfsdk:"collection_id"`
+	// Enrollment Fields
+	EnrollmentFields            types.Map  `tfsdk:"enrollment_fields"`
+	PopulateMissingValuesFromAD types.Bool `tfsdk:"populate_missing_values_from_ad"`
+	CompareSANsAsSet            types.Bool `tfsdk:"compare_sans_as_set"`
+}
+
+type KeyfactorCertificateDataSource struct {
+	ID types.String `tfsdk:"identifier"`
+	// CSR Request Fields
+	CSR types.String `tfsdk:"csr"`
+	// Subject Fields
+	CommonName          types.String `tfsdk:"common_name"`
+	Locality            types.String `tfsdk:"locality"`
+	State               types.String `tfsdk:"state"`
+	Country             types.String `tfsdk:"country"`
+	Organization        types.String `tfsdk:"organization"`
+	OrganizationalUnit  types.String `tfsdk:"organizational_unit"`
+	Email               types.String `tfsdk:"email"`
+	StreetAddress       types.List   `tfsdk:"street_address"`
+	PostalCode          types.String `tfsdk:"postal_code"`
+	SubjectSerialNumber types.String `tfsdk:"subject_serial_number"`
+	DomainComponents    types.List   `tfsdk:"domain_components"`
+	// SAN Fields
+	DNSSANs types.List `tfsdk:"dns_sans"`
+	IPSANs  types.List `tfsdk:"ip_sans"`
+	URISANs types.List `tfsdk:"uri_sans"`
+	// Certificate Identity Fields
+	SerialNumber types.String `tfsdk:"serial_number"`
+	IssuerDN     types.String `tfsdk:"issuer_dn"`
+	Thumbprint   types.String `tfsdk:"thumbprint"`
+	// Certificate Data Fields
+	PEM         types.String `tfsdk:"certificate_pem"`
+	PEMCACert   types.String `tfsdk:"ca_certificate"`
+	PEMChain    types.String `tfsdk:"certificate_chain"`
+	PrivateKey  types.String `tfsdk:"private_key"`
+	KeyPassword types.String `tfsdk:"key_password"`
+	// Keyfactor Fields
+	CertificateAuthority types.String `tfsdk:"certificate_authority"`
+	CertificateTemplate  types.String `tfsdk:"certificate_template"`
+	RequestId            types.Int64  `tfsdk:"command_request_id"`
+	CertificateId        types.Int64  `tfsdk:"certificate_id"`
+	Metadata             types.Map    `tfsdk:"metadata"`
+	CollectionId         types.Int64  `tfsdk:"collection_id"`
+	// Deployment Fields
+	Locations []KeyfactorCertificateLocation `tfsdk:"locations"`
+}
+
+type KeyfactorCertificateLocation struct {
+	StoreId       types.String `tfsdk:"certificate_store_id"`
+	ClientMachine types.String `tfsdk:"client_machine"`
+	StorePath     types.String `tfsdk:"store_path"`
+	StoreType     types.Int64  `tfsdk:"store_type"`
+	Alias         types.String `tfsdk:"alias"`
+}
+
+type KeyfactorCertificateSummary struct {
+	CertificateId       types.Int64                    `tfsdk:"certificate_id"`
+	CommonName          types.String                   `tfsdk:"common_name"`
+	Thumbprint          types.String                   `tfsdk:"thumbprint"`
+	SerialNumber        types.String                   `tfsdk:"serial_number"`
+	IssuerDN            types.String                   `tfsdk:"issuer_dn"`
+	NotBefore           types.String                   `tfsdk:"not_before"`
+	NotAfter            types.String                   `tfsdk:"not_after"`
+	CertificateTemplate types.String                   `tfsdk:"certificate_template"`
+	Locations           []KeyfactorCertificateLocation `tfsdk:"locations"`
+	Metadata            types.Map                      `tfsdk:"metadata"`
+}
+
+type KeyfactorCertificates struct {
+	Query            types.String                  `tfsdk:"query"`
+	IssuedCNContains types.String                  `tfsdk:"issued_cn_contains"`
+	Template         types.String                  `tfsdk:"template"`
+	IssuerDN         types.String                  `tfsdk:"issuer_dn"`
+	ExpiresBefore    types.String                  `tfsdk:"expires_before"`
+	Metadata         types.Map                     `tfsdk:"metadata"`
+	CollectionId     types.Int64                   `tfsdk:"collection_id"`
+	IncludeRevoked   types.Bool                    `tfsdk:"include_revoked"`
+	Certificates     []KeyfactorCertificateSummary `tfsdk:"certificates"`
+}
+
+type KeyfactorCertificateHistoryEvent struct {
+	Id        types.Int64  `tfsdk:"id"`
+	Timestamp types.String `tfsdk:"timestamp"`
+	Operation types.String `tfsdk:"operation"`
+	User      types.String `tfsdk:"user"`
+	Comment   types.String `tfsdk:"comment"`
+}
+
+type KeyfactorCertificateHistory struct {
+	CertificateId types.Int64                        `tfsdk:"certificate_id"`
+	CollectionId  types.Int64                        `tfsdk:"collection_id"`
+	History       []KeyfactorCertificateHistoryEvent `tfsdk:"history"`
 }
 
 type KeyfactorCertificateDeployment struct {
-	ID               types.String `tfsdk:"id"`
-	CertificateId    types.Int64  `tfsdk:"certificate_id"`
-	CertificateAlias types.String `tfsdk:"certificate_alias"`
-	StoreId          types.String `tfsdk:"certificate_store_id"`
-	KeyPassword      types.String `tfsdk:"key_password"`
-	JobParameters    types.Map    `tfsdk:"job_parameters"`
+	ID                    types.String `tfsdk:"id"`
+	CertificateId         types.Int64  `tfsdk:"certificate_id"`
+	CertificateThumbprint types.String `tfsdk:"certificate_thumbprint"`
+	CertificateAlias      types.String `tfsdk:"certificate_alias"`
+	StoreId               types.String `tfsdk:"certificate_store_id"`
+	KeyPassword           types.String `tfsdk:"key_password"`
+	IncludePrivateKey     types.Bool   `tfsdk:"include_private_key"`
+	Overwrite             types.Bool   `tfsdk:"overwrite"`
+	RotateInPlace         types.Bool   `tfsdk:"rotate_in_place"`
+	JobParameters         types.Map    `tfsdk:"job_parameters"`
+	WaitForCompletion     types.Bool   `tfsdk:"wait_for_completion"`
+	JobIds                types.List   `tfsdk:"job_ids"`
+	JobStatus             types.String `tfsdk:"job_status"`
+	Schedule              types.List   `tfsdk:"schedule"`
+	Stores                types.Set    `tfsdk:"store"`
+	ContainerName         types.String `tfsdk:"container_name"`
+	StoreQuery            types.String `tfsdk:"store_query"`
+	SelectedStoreIds      types.List   `tfsdk:"selected_store_ids"`
+}
+
+type KeyfactorCertificateDeploymentSchedule struct {
+	ExactlyOnce types.String `tfsdk:"exactly_once"`
+}
+
+type KeyfactorCertificateDeploymentStore struct {
+	StoreId           types.String `tfsdk:"certificate_store_id"`
+	CertificateAlias  types.String `tfsdk:"certificate_alias"`
+	IncludePrivateKey types.Bool   `tfsdk:"include_private_key"`
+	Overwrite         types.Bool   `tfsdk:"overwrite"`
+	JobParameters     types.Map    `tfsdk:"job_parameters"`
 }
 
 type CSRCertificate struct {
@@ -118,6 +239,32 @@ type CertificateRequest struct {
 }
 
 type CertificateStore struct {
+	ID                    types.String `tfsdk:"id"`
+	ContainerID           types.Int64  `tfsdk:"container_id"`
+	ContainerName         types.String `tfsdk:"container_name"`
+	AgentId               types.String `tfsdk:"agent_id"`
+	AgentIdentifier       types.String `tfsdk:"agent_identifier"`
+	AgentAssigned         types.Bool   `tfsdk:"agent_assigned"`
+	ClientMachine         types.String `tfsdk:"client_machine"`
+	DisplayName           types.String `tfsdk:"display_name"`
+	StorePath             types.String `tfsdk:"store_path"`
+	StoreType             types.String `tfsdk:"store_type"`
+	Approved              types.Bool   `tfsdk:"approved"`
+	ApproveDiscovered     types.Bool   `tfsdk:"approve_discovered"`
+	CreateIfMissing       types.Bool   `tfsdk:"create_if_missing"`
+	Properties            types.Map    `tfsdk:"properties"`
+	SetNewPasswordAllowed types.Bool   `tfsdk:"set_new_password_allowed"`
+	ServerUsername        types.String `tfsdk:"server_username"`
+	ServerPassword        types.String `tfsdk:"server_password"`
+	ServerUseSsl          types.Bool   `tfsdk:"server_use_ssl"`
+	StorePassword         types.String `tfsdk:"store_password"`
+	InventorySchedule     types.List   `tfsdk:"inventory_schedule"`
+	ServerUsernamePam     types.List   `tfsdk:"server_username_pam"`
+	ServerPasswordPam     types.List   `tfsdk:"server_password_pam"`
+	StorePasswordPam      types.List   `tfsdk:"store_password_pam"`
+}
+
+type CertificateStoreDataSource struct {
 	ID                    types.String `tfsdk:"id"`
 	ContainerID           types.Int64  `tfsdk:"container_id"`
 	ContainerName         types.String `tfsdk:"container_name"`
@@ -136,23 +283,182 @@ type CertificateStore struct {
 	ServerPassword        types.String `tfsdk:"server_password"`
 	ServerUseSsl          types.Bool   `tfsdk:"server_use_ssl"`
 	StorePassword         types.String `tfsdk:"store_password"`
-	InventorySchedule     types.String `tfsdk:"inventory_schedule"`
+	InventorySchedule     types.List   `tfsdk:"inventory_schedule"`
 }
 
-type CertificateStoreCredential struct {
-	ServerUsername struct {
-		Value struct {
-			SecretValue string `json:"SecretValue"`
-		} `json:"value"`
-	} `json:"ServerUsername"`
-	ServerPassword struct {
-		Value struct {
-			SecretValue string `json:"SecretValue"`
-		} `json:"value"`
-	} `json:"ServerPassword"`
-	ServerUseSsl struct {
-		Value string `json:"value"`
-	} `json:"ServerUseSsl"`
+type CertificateStoreContainer struct {
+	ID                 types.Int64  `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	StoreType          types.String `tfsdk:"store_type"`
+	OverwriteSchedules types.Bool   `tfsdk:"overwrite_schedules"`
+	InventorySchedule  types.List   `tfsdk:"inventory_schedule"`
+}
+
+type CertificateStoreContainerDataSource struct {
+	ID                 types.Int64                       `tfsdk:"id"`
+	Name               types.String                      `tfsdk:"name"`
+	StoreType          types.String                      `tfsdk:"store_type"`
+	OverwriteSchedules types.Bool                        `tfsdk:"overwrite_schedules"`
+	InventorySchedule  types.List                        `tfsdk:"inventory_schedule"`
+	Stores             []CertificateStoreContainerMember `tfsdk:"stores"`
+}
+
+type CertificateStoreContainerMember struct {
+	ID            types.String `tfsdk:"id"`
+	ClientMachine types.String `tfsdk:"client_machine"`
+	StorePath     types.String `tfsdk:"store_path"`
+}
+
+type StoreDiscoveryJob struct {
+	ID                 types.String `tfsdk:"id"`
+	AgentIdentifier    types.String `tfsdk:"agent_identifier"`
+	AgentId            types.String `tfsdk:"agent_id"`
+	ClientMachine      types.String `tfsdk:"client_machine"`
+	StoreType          types.String `tfsdk:"store_type"`
+	Directories        types.List   `tfsdk:"directories"`
+	IgnoredDirectories types.List   `tfsdk:"ignored_directories"`
+	Extensions         types.List   `tfsdk:"extensions"`
+	NamePatterns       types.List   `tfsdk:"name_patterns"`
+	FollowSymlinks     types.Bool   `tfsdk:"follow_symlinks"`
+	ServerUsername     types.String `tfsdk:"server_username"`
+	ServerPassword     types.String `tfsdk:"server_password"`
+	ServerUseSsl       types.Bool   `tfsdk:"server_use_ssl"`
+	Schedule           types.List   `tfsdk:"schedule"`
+	ServerUsernamePam  types.List   `tfsdk:"server_username_pam"`
+	ServerPasswordPam  types.List   `tfsdk:"server_password_pam"`
+}
+
+type DiscoveredStores struct {
+	ID              types.String      `tfsdk:"id"`
+	AgentIdentifier types.String      `tfsdk:"agent_identifier"`
+	StoreType       types.String      `tfsdk:"store_type"`
+	ClientMachine   types.String      `tfsdk:"client_machine"`
+	Stores          []DiscoveredStore `tfsdk:"stores"`
+}
+
+type DiscoveredStore struct {
+	ID            types.String `tfsdk:"id"`
+	ClientMachine types.String `tfsdk:"client_machine"`
+	StorePath     types.String `tfsdk:"store_path"`
+	StoreType     types.String `tfsdk:"store_type"`
+	AgentId       types.String `tfsdk:"agent_id"`
+}
+
+type CertificateStoreInventoryDataSource struct {
+	ID                 types.String                     `tfsdk:"id"`
+	CertificateStoreID types.String                     `tfsdk:"certificate_store_id"`
+	Refresh            types.Bool                       `tfsdk:"refresh"`
+	InventoriedAt      types.String                     `tfsdk:"inventoried_at"`
+	Aliases            []CertificateStoreInventoryAlias `tfsdk:"aliases"`
+}
+
+type CertificateStoreInventoryAlias struct {
+	Alias             types.String                           `tfsdk:"alias"`
+	PrivateKeyPresent types.Bool                             `tfsdk:"private_key_present"`
+	Certificates      []CertificateStoreInventoryCertificate `tfsdk:"certificates"`
+}
+
+type CertificateStoreInventoryCertificate struct {
+	ID           types.Int64  `tfsdk:"id"`
+	Thumbprint   types.String `tfsdk:"thumbprint"`
+	SerialNumber types.String `tfsdk:"serial_number"`
+	IssuedDN     types.String `tfsdk:"issued_dn"`
+	NotBefore    types.String `tfsdk:"not_before"`
+	NotAfter     types.String `tfsdk:"not_after"`
+	Expired      types.Bool   `tfsdk:"expired"`
+}
+
+type CertificateStorePamReference struct {
+	ProviderName types.String `tfsdk:"provider_name"`
+	Parameters   types.Map    `tfsdk:"parameters"`
+}
+
+type CertificateStoreInventorySchedule struct {
+	Immediate       types.Bool   `tfsdk:"immediate"`
+	IntervalMinutes types.Int64  `tfsdk:"interval_minutes"`
+	DailyTime       types.String `tfsdk:"daily_time"`
+	WeeklyDays      types.List   `tfsdk:"weekly_days"`
+	WeeklyTime      types.String `tfsdk:"weekly_time"`
+	ExactlyOnce     types.String `tfsdk:"exactly_once"`
+}
+
+type CertificateStoreType struct {
+	ID                    types.Int64                          `tfsdk:"id"`
+	Name                  types.String                         `tfsdk:"name"`
+	ShortName             types.String                         `tfsdk:"short_name"`
+	Capability            types.String                         `tfsdk:"capability"`
+	LocalStore            types.Bool                           `tfsdk:"local_store"`
+	ServerRequired        types.Bool                           `tfsdk:"server_required"`
+	BlueprintAllowed      types.Bool                           `tfsdk:"blueprint_allowed"`
+	SupportsAdd           types.Bool                           `tfsdk:"supports_add"`
+	SupportsRemove        types.Bool                           `tfsdk:"supports_remove"`
+	SupportsCreate        types.Bool                           `tfsdk:"supports_create"`
+	SupportsDiscovery     types.Bool                           `tfsdk:"supports_discovery"`
+	SupportsReenrollment  types.Bool                           `tfsdk:"supports_reenrollment"`
+	PrivateKeyAllowed     types.String                         `tfsdk:"private_key_allowed"`
+	CustomAliasAllowed    types.String                         `tfsdk:"custom_alias_allowed"`
+	SupportsEntryPassword types.Bool                           `tfsdk:"supports_entry_password"`
+	StorePasswordRequired types.Bool                           `tfsdk:"store_password_required"`
+	PasswordStyle         types.String                         `tfsdk:"password_style"`
+	JobProperties         types.List                           `tfsdk:"job_properties"`
+	Properties            []CertificateStoreTypeProperty       `tfsdk:"properties"`
+	EntryParameters       []CertificateStoreTypeEntryParameter `tfsdk:"entry_parameters"`
+}
+
+type CertificateStoreTypeProperty struct {
+	Name         types.String `tfsdk:"name"`
+	DisplayName  types.String `tfsdk:"display_name"`
+	Type         types.String `tfsdk:"type"`
+	DependsOn    types.String `tfsdk:"depends_on"`
+	DefaultValue types.String `tfsdk:"default_value"`
+	Required     types.Bool   `tfsdk:"required"`
+}
+
+type CertificateStoreTypeEntryParameter struct {
+	Name                       types.String `tfsdk:"name"`
+	DisplayName                types.String `tfsdk:"display_name"`
+	Type                       types.String `tfsdk:"type"`
+	DependsOn                  types.String `tfsdk:"depends_on"`
+	DefaultValue               types.String `tfsdk:"default_value"`
+	Options                    types.String `tfsdk:"options"`
+	RequiredWhenHasPrivateKey  types.Bool   `tfsdk:"required_when_has_private_key"`
+	RequiredWhenOnAdd          types.Bool   `tfsdk:"required_when_on_add"`
+	RequiredWhenOnRemove       types.Bool   `tfsdk:"required_when_on_remove"`
+	RequiredWhenOnReenrollment types.Bool   `tfsdk:"required_when_on_reenrollment"`
+}
+
+type CertificateStoreTypeDataSource struct {
+	ID                    types.Int64                              `tfsdk:"id"`
+	Name                  types.String                             `tfsdk:"name"`
+	ShortName             types.String                             `tfsdk:"short_name"`
+	Capability            types.String                             `tfsdk:"capability"`
+	LocalStore            types.Bool                               `tfsdk:"local_store"`
+	ServerRequired        types.Bool                               `tfsdk:"server_required"`
+	BlueprintAllowed      types.Bool                               `tfsdk:"blueprint_allowed"`
+	SupportsAdd           types.Bool                               `tfsdk:"supports_add"`
+	SupportsRemove        types.Bool                               `tfsdk:"supports_remove"`
+	SupportsCreate        types.Bool                               `tfsdk:"supports_create"`
+	SupportsDiscovery     types.Bool                               `tfsdk:"supports_discovery"`
+	SupportsReenrollment  types.Bool                               `tfsdk:"supports_reenrollment"`
+	PrivateKeyAllowed     types.String                             `tfsdk:"private_key_allowed"`
+	CustomAliasAllowed    types.String                             `tfsdk:"custom_alias_allowed"`
+	SupportsEntryPassword types.Bool                               `tfsdk:"supports_entry_password"`
+	StorePasswordRequired types.Bool                               `tfsdk:"store_password_required"`
+	PasswordStyle         types.String                             `tfsdk:"password_style"`
+	JobProperties         types.List                               `tfsdk:"job_properties"`
+	Properties            []CertificateStoreTypePropertyDataSource `tfsdk:"properties"`
+	EntryParameters       []CertificateStoreTypeEntryParameter     `tfsdk:"entry_parameters"`
+}
+
+type CertificateStoreTypePropertyDataSource struct {
+	Name          types.String `tfsdk:"name"`
+	DisplayName   types.String `tfsdk:"display_name"`
+	Type          types.String `tfsdk:"type"`
+	DependsOn     types.String `tfsdk:"depends_on"`
+	DefaultValue  types.String `tfsdk:"default_value"`
+	AllowedValues types.List   `tfsdk:"allowed_values"`
+	Required      types.Bool   `tfsdk:"required"`
+	Secret        types.Bool   `tfsdk:"secret"`
 }
 
 type CertificateTemplate struct {
@@ -182,3 +488,29 @@ type CertificateTemplateRoleBinding struct {
 	RoleName      types.String `tfsdk:"role_name"`
 	TemplateNames types.List   `tfsdk:"template_short_names"`
 }
+
+type PamProvider struct {
+	ID               types.Int64  `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	ProviderType     types.String `tfsdk:"provider_type"`
+	Area             types.Int64  `tfsdk:"area"`
+	Parameters       types.Map    `tfsdk:"parameters"`
+	SecretParameters types.Map    `tfsdk:"secret_parameters"`
+}
+
+type PamProviderTypes struct {
+	Types []PamProviderTypeDataSource `tfsdk:"types"`
+}
+
+type PamProviderTypeDataSource struct {
+	ID         types.String                     `tfsdk:"id"`
+	Name       types.String                     `tfsdk:"name"`
+	Parameters []PamProviderTypeParamDataSource `tfsdk:"parameters"`
+}
+
+type PamProviderTypeParamDataSource struct {
+	Name          types.String `tfsdk:"name"`
+	DisplayName   types.String `tfsdk:"display_name"`
+	Secret        types.Bool   `tfsdk:"secret"`
+	InstanceLevel types.Bool   `tfsdk:"instance_level"`
+}
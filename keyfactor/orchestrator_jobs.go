@@ -0,0 +1,122 @@
+package keyfactor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// orchestratorJobHistory is a completed orchestrator job as returned by the Keyfactor Command job history API.
+type orchestratorJobHistory struct {
+	JobHistoryId   int    `json:"JobHistoryId"`
+	AgentMachine   string `json:"AgentMachine"`
+	JobId          string `json:"JobId"`
+	JobType        string `json:"JobType"`
+	OperationStart string `json:"OperationStart"`
+	OperationEnd   string `json:"OperationEnd"`
+	Message        string `json:"Message"`
+	Result         int    `json:"Result"`
+	Status         int    `json:"Status"`
+	StorePath      string `json:"StorePath"`
+	ClientMachine  string `json:"ClientMachine"`
+}
+
+// getOrchestratorJobHistory returns the job history entries of the orchestrator jobs with the given IDs.
+func (p provider) getOrchestratorJobHistory(ctx context.Context, jobIds []string) ([]orchestratorJobHistory, error) {
+	var clauses []string
+	for _, jobId := range jobIds {
+		clauses = append(clauses, fmt.Sprintf("JobId -eq \"%s\"", jobId))
+	}
+
+	params := url.Values{}
+	params.Set("pq.queryString", strings.Join(clauses, " OR "))
+	params.Set("pq.returnLimit", strconv.Itoa(DEFAULT_QUERY_RETURN_SIZE))
+
+	var history []orchestratorJobHistory
+	for page := 1; ; page++ {
+		params.Set("pq.pageReturned", strconv.Itoa(page))
+		var pageResults []orchestratorJobHistory
+		err := p.commandRequest(ctx, http.MethodGet, "OrchestratorJobs/JobHistory", params, nil, &pageResults)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, pageResults...)
+		if len(pageResults) < DEFAULT_QUERY_RETURN_SIZE {
+			break
+		}
+	}
+	return history, nil
+}
+
+// completedOrchestratorJobs returns the latest history entry of each job in jobIds that has reported a result, keyed by
+// the lowercase job ID.
+func (p provider) completedOrchestratorJobs(ctx context.Context, jobIds []string) (map[string]orchestratorJobHistory, error) {
+	history, err := p.getOrchestratorJobHistory(ctx, jobIds)
+	if err != nil {
+		return nil, err
+	}
+
+	completed := make(map[string]orchestratorJobHistory)
+	for _, job := range history {
+		if job.Result == ORCHESTRATOR_JOB_RESULT_UNKNOWN {
+			continue
+		}
+		jobId := strings.ToLower(job.JobId)
+		if previous, found := completed[jobId]; !found || job.JobHistoryId > previous.JobHistoryId {
+			completed[jobId] = job
+		}
+	}
+	return completed, nil
+}
+
+// waitForOrchestratorJobs polls the orchestrator job history until every job in jobIds has reported a result, and
+// returns the latest history entry of each job keyed by the lowercase job ID. It gives up after timeout or when ctx is
+// cancelled.
+func (p provider) waitForOrchestratorJobs(ctx context.Context, jobIds []string, timeout time.Duration) (map[string]orchestratorJobHistory, error) {
+	deadline := time.Now().Add(timeout)
+	retryDelay := 2 * time.Second
+	for {
+		completed, err := p.completedOrchestratorJobs(ctx, jobIds)
+		if err != nil {
+			return nil, err
+		}
+		if len(completed) >= len(jobIds) {
+			return completed, nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil, fmt.Errorf("timed out after %v waiting for orchestrator jobs %s to complete", timeout, strings.Join(jobIds, ", "))
+		}
+		if retryDelay > remaining {
+			retryDelay = remaining
+		}
+		tflog.Debug(ctx, fmt.Sprintf("%v of %v orchestrator jobs completed. Retrying in %v", len(completed), len(jobIds), retryDelay))
+		if err := sleepContext(ctx, retryDelay); err != nil {
+			return nil, err
+		}
+		retryDelay = retryDelay * SLEEP_DURATION_MULTIPLIER
+		if retryDelay > time.Minute {
+			retryDelay = time.Minute
+		}
+	}
+}
+
+// sleepContext waits for d to pass. It returns early with the context error when ctx is cancelled, for example when
+// Terraform is interrupted.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,208 @@
+package keyfactor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// storePamReference is a secret that Keyfactor Command reads from a PAM provider instead of storing it.
+type storePamReference struct {
+	Provider   int               `json:"Provider"`
+	Parameters map[string]string `json:"Parameters"`
+}
+
+// storePamPropertyValue is a PAM reference in the {"value": ...} shape of certificate store properties.
+type storePamPropertyValue struct {
+	Value *storePamReference `json:"value"`
+}
+
+// storePamReferences are the credentials of a certificate store that are read from a PAM provider.
+type storePamReferences struct {
+	ServerUsername *storePamReference
+	ServerPassword *storePamReference
+	StorePassword  *storePamReference
+}
+
+var pamReferenceAttrTypes = map[string]attr.Type{
+	"provider_name": types.StringType,
+	"parameters":    types.MapType{ElemType: types.StringType},
+}
+
+// pamReferenceBlock returns the schema of a block that reads a credential from a PAM provider.
+func pamReferenceBlock(attribute string) tfsdk.Block {
+	return tfsdk.Block{
+		NestingMode: tfsdk.BlockNestingModeList,
+		MaxItems:    1,
+		Description: fmt.Sprintf("Reads `%s` from a PAM provider configured in Keyfactor Command, so the secret isn't stored in Terraform state. Conflicts with `%s`.", attribute, attribute),
+		Attributes: map[string]tfsdk.Attribute{
+			"provider_name": {
+				Type:        types.StringType,
+				Required:    true,
+				Description: "Name of the PAM provider in Keyfactor Command.",
+			},
+			"parameters": {
+				Type:        types.MapType{ElemType: types.StringType},
+				Required:    true,
+				Description: "Parameters that identify the secret in the PAM provider, such as `SecretId` for Delinea or `Safe` and `Object` for CyberArk. The parameter names depend on the PAM provider type.",
+			},
+		},
+	}
+}
+
+// validateStorePamReferences reports credentials that are set both directly and as a PAM reference.
+func validateStorePamReferences(config CertificateStore) diag.Diagnostics {
+	var diags diag.Diagnostics
+	credentials := []struct {
+		attribute string
+		value     types.String
+		reference types.List
+	}{
+		{"server_username", config.ServerUsername, config.ServerUsernamePam},
+		{"server_password", config.ServerPassword, config.ServerPasswordPam},
+		{"store_password", config.StorePassword, config.StorePasswordPam},
+	}
+	for _, credential := range credentials {
+		diags.Append(validatePamReferenceConflict(credential.attribute, credential.value, credential.reference)...)
+	}
+	return diags
+}
+
+// validatePamReferenceConflict reports a credential attribute that is set together with its `_pam` block.
+func validatePamReferenceConflict(attribute string, value types.String, reference types.List) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !value.Null && len(reference.Elems) > 0 {
+		diags.AddAttributeError(
+			path.Root(attribute+"_pam"),
+			ERR_SUMMARY_PAM_REFERENCE,
+			fmt.Sprintf("Only one of '%s' or '%s_pam' can be set.", attribute, attribute),
+		)
+	}
+	return diags
+}
+
+// expandPamReference converts a PAM reference block to the Keyfactor Command model, looking up the provider by name.
+// It returns nil when the block isn't set.
+func expandPamReference(ctx context.Context, p provider, list types.List, attributePath path.Path) (*storePamReference, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if list.Null || list.Unknown || len(list.Elems) == 0 {
+		return nil, diags
+	}
+
+	var references []CertificateStorePamReference
+	diags.Append(list.ElementsAs(ctx, &references, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	parameters := map[string]string{}
+	diags.Append(references[0].Parameters.ElementsAs(ctx, &parameters, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	pamProvider, err := lookupPamProvider(ctx, p, references[0].ProviderName.Value)
+	if err != nil {
+		diags.AddAttributeError(
+			attributePath.AtListIndex(0).AtName("provider_name"),
+			ERR_SUMMARY_PAM_REFERENCE,
+			fmt.Sprintf("Could not retrieve PAM provider '%s' from Keyfactor Command: %s", references[0].ProviderName.Value, err.Error()),
+		)
+		return nil, diags
+	}
+	return &storePamReference{Provider: pamProvider.Id, Parameters: parameters}, diags
+}
+
+// expandStorePamReferences returns the credentials of a certificate store that are read from a PAM provider.
+func expandStorePamReferences(ctx context.Context, p provider, store CertificateStore) (storePamReferences, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var references storePamReferences
+	var referenceDiags diag.Diagnostics
+
+	references.ServerUsername, referenceDiags = expandPamReference(ctx, p, store.ServerUsernamePam, path.Root("server_username_pam"))
+	diags.Append(referenceDiags...)
+	references.ServerPassword, referenceDiags = expandPamReference(ctx, p, store.ServerPasswordPam, path.Root("server_password_pam"))
+	diags.Append(referenceDiags...)
+	references.StorePassword, referenceDiags = expandPamReference(ctx, p, store.StorePasswordPam, path.Root("store_password_pam"))
+	diags.Append(referenceDiags...)
+	return references, diags
+}
+
+// properties returns the PAM references that are sent as certificate store properties, by property name.
+func (r storePamReferences) properties() map[string]*storePamReference {
+	properties := map[string]*storePamReference{}
+	if r.ServerUsername != nil {
+		properties["ServerUsername"] = r.ServerUsername
+	}
+	if r.ServerPassword != nil {
+		properties["ServerPassword"] = r.ServerPassword
+	}
+	return properties
+}
+
+// setStorePassword replaces the password of a certificate store. The client's password model only takes literal
+// values, so PAM references are set with this afterwards.
+func setStorePassword(ctx context.Context, p provider, storeId string, password *storePamReference) error {
+	body := map[string]interface{}{
+		"Id":          storeId,
+		"NewPassword": password,
+	}
+	return p.commandRequest(ctx, http.MethodPut, "CertificateStores/Password", nil, body, nil)
+}
+
+// flattenPamReference converts the value of a certificate store property to a PAM reference block. The block is empty
+// when the property isn't read from a PAM provider, and prior is kept when the provider can't be looked up.
+func flattenPamReference(ctx context.Context, p provider, remote map[string]interface{}, name string, prior types.List) types.List {
+	objectType := types.ObjectType{AttrTypes: pamReferenceAttrTypes}
+	result := types.List{ElemType: objectType, Elems: []attr.Value{}}
+	if remote == nil {
+		return prior
+	}
+
+	value := remote[name]
+	if wrapped, ok := value.(map[string]interface{}); ok {
+		if inner, ok := wrapped["value"]; ok {
+			value = inner
+		}
+	}
+	reference, ok := value.(map[string]interface{})
+	if !ok || reference["Provider"] == nil {
+		return result
+	}
+
+	var providerId int
+	switch id := reference["Provider"].(type) {
+	case float64:
+		providerId = int(id)
+	case string:
+		providerId, _ = strconv.Atoi(id)
+	}
+	pamProvider, err := getPamProvider(ctx, p, providerId)
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Unable to read PAM provider %v of certificate store property '%s': %s", reference["Provider"], name, err.Error()))
+		return prior
+	}
+
+	parameters := types.Map{ElemType: types.StringType, Elems: map[string]attr.Value{}}
+	if remoteParameters, ok := reference["Parameters"].(map[string]interface{}); ok {
+		for key, parameter := range remoteParameters {
+			parameters.Elems[key] = types.String{Value: fmt.Sprint(parameter)}
+		}
+	}
+
+	result.Elems = append(result.Elems, types.Object{
+		AttrTypes: pamReferenceAttrTypes,
+		Attrs: map[string]attr.Value{
+			"provider_name": types.String{Value: pamProvider.Name},
+			"parameters":    parameters,
+		},
+	})
+	return result
+}
@@ -0,0 +1,133 @@
+package keyfactor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// sansRequiresReplaceModifier requires the resource to be replaced when a SAN list changes. When compare_sans_as_set
+// is true the planned and current SANs are compared as sets, so that reordering a list or a normalized value returned
+// by Keyfactor Command does not replace the certificate.
+type sansRequiresReplaceModifier struct {
+	sanType string
+}
+
+func sansRequiresReplace(sanType string) tfsdk.AttributePlanModifier {
+	return sansRequiresReplaceModifier{sanType: sanType}
+}
+
+func (m sansRequiresReplaceModifier) Description(_ context.Context) string {
+	return fmt.Sprintf("Requires replacement when the %s SANs change, ignoring order when compare_sans_as_set is true.", m.sanType)
+}
+
+func (m sansRequiresReplaceModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m sansRequiresReplaceModifier) Modify(
+	ctx context.Context,
+	request tfsdk.ModifyAttributePlanRequest,
+	response *tfsdk.ModifyAttributePlanResponse,
+) {
+	if request.AttributePlan == nil || request.AttributeState == nil {
+		return
+	}
+	if request.State.Raw.IsNull() || request.Plan.Raw.IsNull() {
+		// Nothing to replace on create or destroy
+		return
+	}
+
+	planSANs, ok := request.AttributePlan.(types.List)
+	if !ok {
+		return
+	}
+	stateSANs, ok := request.AttributeState.(types.List)
+	if !ok {
+		return
+	}
+
+	var compareAsSet types.Bool
+	diags := request.Plan.GetAttribute(ctx, path.Root("compare_sans_as_set"), &compareAsSet)
+	response.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	if sanListsMatch(compareAsSet.Value, m.sanType, planSANs, stateSANs) {
+		return
+	}
+	response.RequiresReplace = true
+}
+
+// defaultValueModifier plans a fixed value for an optional and computed attribute that is omitted from the
+// configuration, so that removing the attribute reverts Keyfactor Command to its default instead of keeping the value
+// from state.
+type defaultValueModifier struct {
+	value attr.Value
+}
+
+func defaultValue(value attr.Value) tfsdk.AttributePlanModifier {
+	return defaultValueModifier{value: value}
+}
+
+func (m defaultValueModifier) Description(_ context.Context) string {
+	return fmt.Sprintf("Defaults to %s when not configured.", m.value)
+}
+
+func (m defaultValueModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m defaultValueModifier) Modify(
+	_ context.Context,
+	request tfsdk.ModifyAttributePlanRequest,
+	response *tfsdk.ModifyAttributePlanResponse,
+) {
+	if request.AttributeConfig == nil || request.Plan.Raw.IsNull() {
+		return
+	}
+	if !request.AttributeConfig.IsNull() {
+		return
+	}
+	response.AttributePlan = m.value
+}
+
+// requiresReplaceIfSetModifier requires the resource to be replaced when the attribute changes from a value in state.
+// Setting an attribute that is null in state, for example after an import that can't read it back, is an in-place
+// update instead.
+type requiresReplaceIfSetModifier struct{}
+
+func requiresReplaceIfSet() tfsdk.AttributePlanModifier {
+	return requiresReplaceIfSetModifier{}
+}
+
+func (m requiresReplaceIfSetModifier) Description(_ context.Context) string {
+	return "Requires replacement when the value changes, unless it is not set in state."
+}
+
+func (m requiresReplaceIfSetModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m requiresReplaceIfSetModifier) Modify(
+	_ context.Context,
+	request tfsdk.ModifyAttributePlanRequest,
+	response *tfsdk.ModifyAttributePlanResponse,
+) {
+	if request.AttributePlan == nil || request.AttributeState == nil {
+		return
+	}
+	if request.State.Raw.IsNull() || request.Plan.Raw.IsNull() {
+		// Nothing to replace on create or destroy
+		return
+	}
+	if request.AttributeState.IsNull() || request.AttributePlan.Equal(request.AttributeState) {
+		return
+	}
+	response.RequiresReplace = true
+}
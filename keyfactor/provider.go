@@ -22,6 +22,7 @@ func New() tfsdk.Provider {
 type provider struct {
 	configured bool
 	client     *api.Client
+	clientAuth api.AuthConfig
 }
 
 // GetSchema
@@ -260,6 +261,7 @@ func (p *provider) Configure(ctx context.Context, req tfsdk.ConfigureProviderReq
 		}
 		connected = true
 		p.client = c
+		p.clientAuth = clientAuth
 		p.configured = true
 		return
 	}
@@ -268,24 +270,35 @@ func (p *provider) Configure(ctx context.Context, req tfsdk.ConfigureProviderReq
 // GetResources - Defines provider resources
 func (p *provider) GetResources(_ context.Context) (map[string]tfsdk.ResourceType, diag.Diagnostics) {
 	return map[string]tfsdk.ResourceType{
-		"keyfactor_identity":               resourceSecurityIdentityType{},
-		"keyfactor_certificate":            resourceKeyfactorCertificateType{},
-		"keyfactor_certificate_store":      resourceCertificateStoreType{},
-		"keyfactor_certificate_deployment": resourceKeyfactorCertificateDeploymentType{},
-		"keyfactor_role":                   resourceSecurityRoleType{},
-		"keyfactor_template_role_binding":  resourceCertificateTemplateRoleBindingType{},
+		"keyfactor_identity":                    resourceSecurityIdentityType{},
+		"keyfactor_certificate":                 resourceKeyfactorCertificateType{},
+		"keyfactor_certificate_store":           resourceCertificateStoreType{},
+		"keyfactor_certificate_store_type":      resourceKeyfactorCertificateStoreTypeType{},
+		"keyfactor_certificate_store_container": resourceCertificateStoreContainerType{},
+		"keyfactor_certificate_deployment":      resourceKeyfactorCertificateDeploymentType{},
+		"keyfactor_pam_provider":                resourcePamProviderType{},
+		"keyfactor_role":                        resourceSecurityRoleType{},
+		"keyfactor_store_discovery_job":         resourceStoreDiscoveryJobType{},
+		"keyfactor_template_role_binding":       resourceCertificateTemplateRoleBindingType{},
 	}, nil
 }
 
 // GetDataSources - Defines provider data sources
 func (p *provider) GetDataSources(_ context.Context) (map[string]tfsdk.DataSourceType, diag.Diagnostics) {
 	return map[string]tfsdk.DataSourceType{
-		"keyfactor_agent":                dataSourceAgentType{},
-		"keyfactor_certificate":          dataSourceCertificateType{},
-		"keyfactor_certificate_store":    dataSourceCertificateStoreType{},
-		"keyfactor_certificate_template": dataSourceCertificateTemplateType{},
-		"keyfactor_role":                 dataSourceSecurityRoleType{},
-		"keyfactor_identity":             dataSourceSecurityIdentityType{},
+		"keyfactor_agent":                       dataSourceAgentType{},
+		"keyfactor_certificate":                 dataSourceCertificateType{},
+		"keyfactor_certificates":                dataSourceCertificatesType{},
+		"keyfactor_certificate_history":         dataSourceCertificateHistoryType{},
+		"keyfactor_certificate_store":           dataSourceCertificateStoreType{},
+		"keyfactor_certificate_store_type":      dataSourceCertificateStoreTypeType{},
+		"keyfactor_certificate_store_container": dataSourceCertificateStoreContainerType{},
+		"keyfactor_certificate_store_inventory": dataSourceCertificateStoreInventoryType{},
+		"keyfactor_certificate_template":        dataSourceCertificateTemplateType{},
+		"keyfactor_discovered_stores":           dataSourceDiscoveredStoresType{},
+		"keyfactor_pam_provider_types":          dataSourcePamProviderTypesType{},
+		"keyfactor_role":                        dataSourceSecurityRoleType{},
+		"keyfactor_identity":                    dataSourceSecurityIdentityType{},
 	}, nil
 }
 
@@ -9,17 +9,35 @@ import (
 	"encoding/json"
 	"encoding/pem"
 	"fmt"
+	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/Keyfactor/keyfactor-go-client/v2/api"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// pfxEnrollmentRequest is the body of a PFX enrollment with the subject given as a distinguished name, which allows
+// subject attributes the Keyfactor Go client has no fields for.
+type pfxEnrollmentRequest struct {
+	CustomFriendlyName          string                 `json:"CustomFriendlyName,omitempty"`
+	Password                    string                 `json:"Password"`
+	PopulateMissingValuesFromAD bool                   `json:"PopulateMissingValuesFromAD"`
+	Subject                     string                 `json:"Subject"`
+	IncludeChain                bool                   `json:"IncludeChain"`
+	CertificateAuthority        string                 `json:"CertificateAuthority"`
+	Template                    string                 `json:"Template"`
+	Timestamp                   string                 `json:"Timestamp"`
+	SANs                        map[string][]string    `json:"SANs,omitempty"`
+	Metadata                    map[string]interface{} `json:"Metadata,omitempty"`
+	AdditionalEnrollmentFields  map[string]interface{} `json:"AdditionalEnrollmentFields,omitempty"`
+}
+
 type resourceKeyfactorCertificateType struct{}
 
 func (r resourceKeyfactorCertificateType) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
@@ -81,6 +99,41 @@ func (r resourceKeyfactorCertificateType) GetSchema(_ context.Context) (tfsdk.Sc
 				PlanModifiers: []tfsdk.AttributePlanModifier{tfsdk.RequiresReplace()},
 				Description:   "Subject organizational unit (OU) of the certificate",
 			},
+			"email": {
+				Type:          types.StringType,
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{tfsdk.RequiresReplace()},
+				Description:   "Subject email address (E) of the certificate. Can only be set on PFX enrollments.",
+			},
+			"street_address": {
+				Type:          types.ListType{ElemType: types.StringType},
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{tfsdk.RequiresReplace()},
+				Description:   "Subject street address (STREET) values of the certificate. Can only be set on PFX enrollments.",
+			},
+			"postal_code": {
+				Type:          types.StringType,
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{tfsdk.RequiresReplace()},
+				Description:   "Subject postal code (POSTALCODE) of the certificate. Can only be set on PFX enrollments.",
+			},
+			"subject_serial_number": {
+				Type:          types.StringType,
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{tfsdk.RequiresReplace()},
+				Description:   "Subject serial number (SERIALNUMBER) of the certificate. This is not the certificate serial number. Can only be set on PFX enrollments.",
+			},
+			"domain_components": {
+				Type:          types.ListType{ElemType: types.StringType},
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{tfsdk.RequiresReplace()},
+				Description:   "Subject domain components (DC) of the certificate in the order they appear in the subject. Can only be set on PFX enrollments.",
+			},
 			"certificate_authority": {
 				Type:          types.StringType,
 				Required:      true,
@@ -99,35 +152,28 @@ func (r resourceKeyfactorCertificateType) GetSchema(_ context.Context) (tfsdk.Sc
 			"dns_sans": {
 				Type:          types.ListType{ElemType: types.StringType},
 				Optional:      true,
-				PlanModifiers: []tfsdk.AttributePlanModifier{tfsdk.RequiresReplace()},
-				Description:   "List of DNS names to use as subjects of the certificate. ",
-				//DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
-				//	// For some reason Terraform detects this particular function as having drift; this function
-				//	// gives us a definitive answer.
-				//	return !d.HasChange(k)
-				//},
+				PlanModifiers: []tfsdk.AttributePlanModifier{sansRequiresReplace(SAN_TYPE_DNS)},
+				Description:   "List of DNS names to use as subjects of the certificate. Order and case are ignored when `compare_sans_as_set` is `true`.",
 			},
 			"uri_sans": {
 				Type:          types.ListType{ElemType: types.StringType},
 				Optional:      true,
-				PlanModifiers: []tfsdk.AttributePlanModifier{tfsdk.RequiresReplace()},
-				Description:   "List of URIs to use as subjects of the certificate. ",
-				//DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
-				//	// For some reason Terraform detects this particular function as having drift; this function
-				//	// gives us a definitive answer.
-				//	return !d.HasChange(k)
-				//},
+				PlanModifiers: []tfsdk.AttributePlanModifier{sansRequiresReplace(SAN_TYPE_URI)},
+				Description:   "List of URIs to use as subjects of the certificate. Order is ignored when `compare_sans_as_set` is `true`.",
 			},
 			"ip_sans": {
 				Type:          types.ListType{ElemType: types.StringType},
 				Optional:      true,
-				PlanModifiers: []tfsdk.AttributePlanModifier{tfsdk.RequiresReplace()},
-				Description:   "List of DNS names to use as subjects of the certificate. ",
-				//DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
-				//	// For some reason Terraform detects this particular function as having drift; this function
-				//	// gives us a definitive answer.
-				//	return !d.HasChange(k)
-				//},
+				PlanModifiers: []tfsdk.AttributePlanModifier{sansRequiresReplace(SAN_TYPE_IP)},
+				Description:   "List of IP addresses to use as subjects of the certificate. Order and address formatting are ignored when `compare_sans_as_set` is `true`.",
+			},
+			"compare_sans_as_set": {
+				Type:     types.BoolType,
+				Optional: true,
+				Description: "Compare `dns_sans`, `ip_sans` and `uri_sans` as sets. DNS names are compared without case and " +
+					"trailing dot, and IP addresses in canonical form. Reordering a list or a value normalized by Keyfactor " +
+					"Command then never replaces the certificate. Defaults to `false`, which replaces the certificate on any " +
+					"change to a SAN list.",
 			},
 			"metadata": {
 				Type: types.MapType{
@@ -170,6 +216,24 @@ func (r resourceKeyfactorCertificateType) GetSchema(_ context.Context) (tfsdk.Sc
 					"the Keyfactor Command docs: https://software.keyfactor.com/Core-OnPrem/Current/Content/ReferenceGuide/CertificatePermissions.htm?Highlight=collection%20permissions",
 				PlanModifiers: []tfsdk.AttributePlanModifier{tfsdk.RequiresReplace()},
 			},
+			"enrollment_fields": {
+				Type: types.MapType{
+					ElemType: types.StringType,
+				},
+				Optional:      true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{tfsdk.RequiresReplace()},
+				Description: "Custom enrollment field name-value pairs to submit with the enrollment request. Names must " +
+					"match the `enrollment_fields` declared on the certificate template, and values for multiple choice " +
+					"fields must be one of the field's options.",
+			},
+			"populate_missing_values_from_ad": {
+				Type:          types.BoolType,
+				Optional:      true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{tfsdk.RequiresReplace()},
+				Description: "Populate missing subject values from Active Directory on PFX enrollment. Only applies to " +
+					"PFX enrollments and requires a template from an Active Directory forest. Subject attributes that aren't " +
+					"configured stay unset in state, so the values filled in from Active Directory don't replace the certificate.",
+			},
 			"certificate_id": {
 				Type:        types.Int64Type,
 				Computed:    true,
@@ -282,10 +346,45 @@ func (r resourceKeyfactorCertificate) Create(
 		metadata[k] = strings.Replace(elm.String(), "\"", "", -1)
 	}
 
+	var enrollmentFields map[string]string
+	var additionalEnrollmentFields map[string]interface{}
+	if !plan.EnrollmentFields.IsNull() && len(plan.EnrollmentFields.Elems) > 0 {
+		tflog.Debug(ctx, fmt.Sprintf("Parsing enrollment fields: %s", plan.EnrollmentFields))
+		diags = plan.EnrollmentFields.ElementsAs(ctx, &enrollmentFields, false)
+		response.Diagnostics.Append(diags...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+
+		additionalEnrollmentFields = make(map[string]interface{})
+		for k, v := range enrollmentFields {
+			additionalEnrollmentFields[k] = v
+		}
+	}
+
 	sans := append(dnsSANs, ipSANs...)
 	sans = append(sans, uriSANs...)
 	ctx = tflog.SetField(ctx, "sans", sans)
 
+	plannedSubject := subjectDN{
+		CommonName:         plan.CommonName.Value,
+		Organization:       plan.Organization.Value,
+		OrganizationalUnit: plan.OrganizationalUnit.Value,
+		Locality:           plan.Locality.Value,
+		State:              plan.State.Value,
+		Country:            plan.Country.Value,
+		Email:              plan.Email.Value,
+		PostalCode:         plan.PostalCode.Value,
+		SerialNumber:       plan.SubjectSerialNumber.Value,
+	}
+	diags = plan.StreetAddress.ElementsAs(ctx, &plannedSubject.StreetAddress, true)
+	response.Diagnostics.Append(diags...)
+	diags = plan.DomainComponents.ElementsAs(ctx, &plannedSubject.DomainComponents, true)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
 	var autoPassword string
 	var lookupPassword string
 
@@ -299,6 +398,20 @@ func (r resourceKeyfactorCertificate) Create(
 			)
 			return
 		}
+		if plannedSubject.hasExtendedAttributes() {
+			response.Diagnostics.AddError(
+				ERR_SUMMARY_INVALID_CERTIFICATE_RESOURCE,
+				"You cannot set email, street_address, postal_code, subject_serial_number or domain_components when using a CSR.",
+			)
+			return
+		}
+		if plan.PopulateMissingValuesFromAD.Value {
+			response.Diagnostics.AddError(
+				ERR_SUMMARY_INVALID_CERTIFICATE_RESOURCE,
+				"You cannot set populate_missing_values_from_ad when using a CSR.",
+			)
+			return
+		}
 
 		tflog.Debug(ctx, "Creating certificate from CSR.")
 
@@ -315,7 +428,8 @@ func (r resourceKeyfactorCertificate) Create(
 				DNS: dnsSANs,
 				URI: uriSANs,
 			},
-			Metadata: metadata,
+			Metadata:                   metadata,
+			AdditionalEnrollmentFields: additionalEnrollmentFields,
 		}
 		tflog.Trace(
 			ctx, "Passing args to Keyfactor API.", map[string]interface{}{
@@ -372,6 +486,11 @@ func (r resourceKeyfactorCertificate) Create(
 		//	return
 		//}
 
+		subject, sErr := expandCertificateSubject(leaf)
+		if sErr != nil {
+			tflog.Warn(ctx, "Unable to parse enrolled certificate subject: "+sErr.Error())
+		}
+
 		// Set state
 		var result = KeyfactorCertificate{
 			ID: types.String{
@@ -380,29 +499,37 @@ func (r resourceKeyfactorCertificate) Create(
 					enrollResponse.CertificateInformation.KeyfactorID,
 				),
 			},
-			CSR:                  types.String{Value: csr},
-			CommonName:           plan.CommonName,
-			Organization:         plan.Organization,
-			OrganizationalUnit:   plan.OrganizationalUnit,
-			Locality:             plan.Locality,
-			State:                plan.State,
-			Country:              plan.Country,
-			DNSSANs:              plan.DNSSANs,
-			IPSANs:               plan.IPSANs,
-			URISANs:              plan.URISANs,
-			SerialNumber:         types.String{Value: enrollResponse.CertificateInformation.SerialNumber},
-			IssuerDN:             types.String{Value: enrollResponse.CertificateInformation.IssuerDN},
-			Thumbprint:           types.String{Value: enrollResponse.CertificateInformation.Thumbprint},
-			PEM:                  types.String{Value: leaf},
-			PEMCACert:            types.String{Value: caCert},
-			PEMChain:             types.String{Value: fullChain},
-			PrivateKey:           types.String{Value: plan.PrivateKey.Value, Null: true},
-			KeyPassword:          types.String{Value: plan.KeyPassword.Value, Null: true},
-			CertificateAuthority: plan.CertificateAuthority,
-			CertificateId:        types.Int64{Value: int64(enrollResponse.CertificateInformation.KeyfactorID)},
-			CertificateTemplate:  plan.CertificateTemplate,
-			Metadata:             plan.Metadata,
-			CollectionId:         plan.CollectionId,
+			CSR:                         types.String{Value: csr},
+			CommonName:                  plan.CommonName,
+			Organization:                plan.Organization,
+			OrganizationalUnit:          plan.OrganizationalUnit,
+			Email:                       types.String{Value: subject.Email, Null: isNullString(subject.Email)},
+			StreetAddress:               flattenStringList(subject.StreetAddress),
+			PostalCode:                  types.String{Value: subject.PostalCode, Null: isNullString(subject.PostalCode)},
+			SubjectSerialNumber:         types.String{Value: subject.SerialNumber, Null: isNullString(subject.SerialNumber)},
+			DomainComponents:            flattenStringList(subject.DomainComponents),
+			Locality:                    plan.Locality,
+			State:                       plan.State,
+			Country:                     plan.Country,
+			DNSSANs:                     plan.DNSSANs,
+			IPSANs:                      plan.IPSANs,
+			URISANs:                     plan.URISANs,
+			SerialNumber:                types.String{Value: enrollResponse.CertificateInformation.SerialNumber},
+			IssuerDN:                    types.String{Value: enrollResponse.CertificateInformation.IssuerDN},
+			Thumbprint:                  types.String{Value: enrollResponse.CertificateInformation.Thumbprint},
+			PEM:                         types.String{Value: leaf},
+			PEMCACert:                   types.String{Value: caCert},
+			PEMChain:                    types.String{Value: fullChain},
+			PrivateKey:                  types.String{Value: plan.PrivateKey.Value, Null: true},
+			KeyPassword:                 types.String{Value: plan.KeyPassword.Value, Null: true},
+			CertificateAuthority:        plan.CertificateAuthority,
+			CertificateId:               types.Int64{Value: int64(enrollResponse.CertificateInformation.KeyfactorID)},
+			CertificateTemplate:         plan.CertificateTemplate,
+			Metadata:                    plan.Metadata,
+			CollectionId:                plan.CollectionId,
+			EnrollmentFields:            plan.EnrollmentFields,
+			PopulateMissingValuesFromAD: plan.PopulateMissingValuesFromAD,
+			CompareSANsAsSet:            plan.CompareSANsAsSet,
 		}
 
 		diags = response.State.Set(ctx, result)
@@ -430,7 +557,7 @@ func (r resourceKeyfactorCertificate) Create(
 		PFXArgs := &api.EnrollPFXFctArgsV2{
 			CustomFriendlyName:          plan.CommonName.Value,
 			Password:                    lookupPassword,
-			PopulateMissingValuesFromAD: false, //TODO: Add support for this
+			PopulateMissingValuesFromAD: plan.PopulateMissingValuesFromAD.Value,
 			CertificateAuthority:        plan.CertificateAuthority.Value,
 			Template:                    plan.CertificateTemplate.Value,
 			IncludeChain:                true,    //TODO: Add support for this
@@ -441,7 +568,8 @@ func (r resourceKeyfactorCertificate) Create(
 				DNS: dnsSANs,
 				URI: uriSANs,
 			},
-			Metadata: metadata,
+			Metadata:                   metadata,
+			AdditionalEnrollmentFields: additionalEnrollmentFields,
 			Subject: &api.CertificateSubject{
 				SubjectCommonName:         plan.CommonName.Value,
 				SubjectLocality:           plan.Locality.Value,
@@ -467,8 +595,15 @@ func (r resourceKeyfactorCertificate) Create(
 		ctx = tflog.SetField(ctx, "pfx_args", string(jsonData))
 		tflog.Debug(ctx, fmt.Sprintf("PFXArgs: %s", string(jsonData)))
 		tflog.Debug(ctx, fmt.Sprintf("Creating PFX certificate %s on Keyfactor.", PFXArgs.Subject.SubjectCommonName))
-		tflog.Debug(ctx, "Calling EnrollPFXV2.")
-		enrollResponse, err := r.p.client.EnrollPFXV2(PFXArgs)
+		var enrollResponse *api.EnrollResponseV2
+		if plannedSubject.hasExtendedAttributes() {
+			// The client only submits the subject attributes it has fields for
+			tflog.Debug(ctx, "Submitting PFX enrollment with extended subject attributes.")
+			enrollResponse, err = enrollPFXWithSubject(ctx, r.p, PFXArgs, formatDistinguishedName(plannedSubject))
+		} else {
+			tflog.Debug(ctx, "Calling EnrollPFXV2.")
+			enrollResponse, err = r.p.client.EnrollPFXV2(PFXArgs)
+		}
 		if err != nil {
 			tflog.Error(ctx, "No response from Keyfactor Command after PFX enrollment.")
 			response.Diagnostics.AddError(
@@ -611,6 +746,11 @@ func (r resourceKeyfactorCertificate) Create(
 			),
 		)
 
+		subject, sErr := expandCertificateSubject(leaf)
+		if sErr != nil {
+			tflog.Warn(ctx, "Unable to parse enrolled certificate subject: "+sErr.Error())
+		}
+
 		fullChain := leaf + chain
 		// Set state
 		tflog.Info(
@@ -619,32 +759,41 @@ func (r resourceKeyfactorCertificate) Create(
 		)
 		tflog.Debug(ctx, "Creating state object")
 		var result = KeyfactorCertificate{
-			ID:                   types.String{Value: fmt.Sprintf("%v", enrolledId)},
-			CSR:                  plan.CSR,
-			CommonName:           plan.CommonName,
-			Organization:         plan.Organization,
-			OrganizationalUnit:   plan.OrganizationalUnit,
-			Locality:             plan.Locality,
-			State:                plan.State,
-			Country:              plan.Country,
-			DNSSANs:              plan.DNSSANs,
-			IPSANs:               plan.IPSANs,
-			URISANs:              plan.URISANs,
-			SerialNumber:         types.String{Value: enrolledSerialNumber},
-			IssuerDN:             types.String{Value: enrolledIssuerDN},
-			Thumbprint:           types.String{Value: enrolledThumbprint},
-			PEM:                  types.String{Value: leaf},
-			PEMCACert:            types.String{Value: chain},
-			PEMChain:             types.String{Value: fullChain},
-			PrivateKey:           types.String{Value: pKey},
-			KeyPassword:          plan.KeyPassword,
-			CertificateAuthority: plan.CertificateAuthority,
-			CertificateTemplate:  plan.CertificateTemplate,
-			CertificateId:        types.Int64{Value: int64(enrolledId)},
-			RequestId:            types.Int64{Value: int64(enrollResponse.CertificateInformation.KeyfactorRequestID)},
-			Metadata:             plan.Metadata,
-			CollectionId:         plan.CollectionId,
+			ID:                          types.String{Value: fmt.Sprintf("%v", enrolledId)},
+			CSR:                         plan.CSR,
+			CommonName:                  plan.CommonName,
+			Organization:                plan.Organization,
+			OrganizationalUnit:          plan.OrganizationalUnit,
+			Email:                       types.String{Value: subject.Email, Null: isNullString(subject.Email)},
+			StreetAddress:               flattenStringList(subject.StreetAddress),
+			PostalCode:                  types.String{Value: subject.PostalCode, Null: isNullString(subject.PostalCode)},
+			SubjectSerialNumber:         types.String{Value: subject.SerialNumber, Null: isNullString(subject.SerialNumber)},
+			DomainComponents:            flattenStringList(subject.DomainComponents),
+			Locality:                    plan.Locality,
+			State:                       plan.State,
+			Country:                     plan.Country,
+			DNSSANs:                     plan.DNSSANs,
+			IPSANs:                      plan.IPSANs,
+			URISANs:                     plan.URISANs,
+			SerialNumber:                types.String{Value: enrolledSerialNumber},
+			IssuerDN:                    types.String{Value: enrolledIssuerDN},
+			Thumbprint:                  types.String{Value: enrolledThumbprint},
+			PEM:                         types.String{Value: leaf},
+			PEMCACert:                   types.String{Value: chain},
+			PEMChain:                    types.String{Value: fullChain},
+			PrivateKey:                  types.String{Value: pKey},
+			KeyPassword:                 plan.KeyPassword,
+			CertificateAuthority:        plan.CertificateAuthority,
+			CertificateTemplate:         plan.CertificateTemplate,
+			CertificateId:               types.Int64{Value: int64(enrolledId)},
+			RequestId:                   types.Int64{Value: int64(enrollResponse.CertificateInformation.KeyfactorRequestID)},
+			Metadata:                    plan.Metadata,
+			CollectionId:                plan.CollectionId,
+			EnrollmentFields:            plan.EnrollmentFields,
+			PopulateMissingValuesFromAD: plan.PopulateMissingValuesFromAD,
+			CompareSANsAsSet:            plan.CompareSANsAsSet,
 		}
+		keepPlannedSubject(&result, plan)
 
 		tflog.Debug(ctx, "Setting state")
 		diags = response.State.Set(ctx, result)
@@ -725,33 +874,41 @@ func (r resourceKeyfactorCertificate) Read(
 		nullValue := types.String{Null: true}
 		nullList := types.List{Null: true, ElemType: types.StringType}
 		emptyResult := KeyfactorCertificate{
-			ID:                 nullValue,
-			CSR:                nullValue,
-			CommonName:         nullValue,
-			Locality:           nullValue,
-			State:              nullValue,
-			Country:            nullValue,
-			Organization:       nullValue,
-			OrganizationalUnit: nullValue,
-			DNSSANs:            nullList,
-			IPSANs:             nullList,
-			URISANs:            nullList,
-			SerialNumber:       nullValue,
-			IssuerDN:           nullValue,
-			Thumbprint:         nullValue,
-			PEM:                nullValue,
-			PEMCACert:          nullValue,
-			PEMChain:           nullValue,
-			PrivateKey:         nullValue,
-			KeyPassword:        state.KeyPassword,
+			ID:                  nullValue,
+			CSR:                 nullValue,
+			CommonName:          nullValue,
+			Locality:            nullValue,
+			State:               nullValue,
+			Country:             nullValue,
+			Organization:        nullValue,
+			OrganizationalUnit:  nullValue,
+			Email:               nullValue,
+			StreetAddress:       nullList,
+			PostalCode:          nullValue,
+			SubjectSerialNumber: nullValue,
+			DomainComponents:    nullList,
+			DNSSANs:             nullList,
+			IPSANs:              nullList,
+			URISANs:             nullList,
+			SerialNumber:        nullValue,
+			IssuerDN:            nullValue,
+			Thumbprint:          nullValue,
+			PEM:                 nullValue,
+			PEMCACert:           nullValue,
+			PEMChain:            nullValue,
+			PrivateKey:          nullValue,
+			KeyPassword:         state.KeyPassword,
 			//PEM:                  state.PEM,
 			//PEMChain:             state.PEMChain,
 			//PrivateKey:           state.PrivateKey,
 			//KeyPassword:          state.KeyPassword,
-			CertificateAuthority: nullValue,
-			CertificateTemplate:  nullValue,
-			Metadata:             types.Map{Null: true, ElemType: types.StringType},
-			CertificateId:        types.Int64{Null: true},
+			CertificateAuthority:        nullValue,
+			CertificateTemplate:         nullValue,
+			Metadata:                    types.Map{Null: true, ElemType: types.StringType},
+			CertificateId:               types.Int64{Null: true},
+			EnrollmentFields:            state.EnrollmentFields,
+			PopulateMissingValuesFromAD: state.PopulateMissingValuesFromAD,
+			CompareSANsAsSet:            state.CompareSANsAsSet,
 		}
 		diags = response.State.Set(ctx, &emptyResult)
 		response.Diagnostics.Append(diags...)
@@ -788,9 +945,16 @@ func (r resourceKeyfactorCertificate) Read(
 	}
 
 	tflog.Debug(ctx, "Calling expandSubject")
-	cn, ou, o, l, st, c := expandSubject(cResp.IssuedDN)
+	subject, sErr := expandSubject(cResp.IssuedDN)
+	if sErr != nil {
+		tflog.Warn(ctx, "Error parsing certificate subject, keeping subject from state.")
+		response.Diagnostics.AddWarning(
+			ERR_SUMMARY_CERTIFICATE_RESOURCE_READ,
+			fmt.Sprintf("Could not parse subject of certificate '%s', the subject in state is kept: %s", state.ID.Value, sErr.Error()),
+		)
+	}
 	tflog.Debug(ctx, "Calling flattenSANs")
-	dnsSans, ipSans, uriSans := flattenSANs(cResp.SubjectAltNameElements, state.DNSSANs, state.IPSANs, state.URISANs)
+	dnsSans, ipSans, uriSans := flattenSANs(cResp.SubjectAltNameElements, state.DNSSANs, state.IPSANs, state.URISANs, state.CompareSANsAsSet.Value)
 	ctx = tflog.SetField(ctx, "dns_sans", dnsSans)
 	ctx = tflog.SetField(ctx, "ip_sans", ipSans)
 	ctx = tflog.SetField(ctx, "uri_sans", uriSans)
@@ -992,25 +1156,30 @@ func (r resourceKeyfactorCertificate) Read(
 	if state.CSR.Value != "" {
 		tflog.Debug(ctx, "Creating state object for certificate with CSR.")
 		result = KeyfactorCertificate{
-			ID:                 types.String{Value: fmt.Sprintf("%v", cResp.Id)},
-			CSR:                types.String{Value: csr, Null: isNullString(csr)},
-			CommonName:         types.String{Value: cn.Value, Null: true},
-			Locality:           types.String{Value: l.Value, Null: true},
-			State:              types.String{Value: st.Value, Null: true},
-			Country:            types.String{Value: c.Value, Null: true},
-			Organization:       types.String{Value: o.Value, Null: true},
-			OrganizationalUnit: types.String{Value: ou.Value, Null: true},
-			DNSSANs:            state.DNSSANs,
-			IPSANs:             state.IPSANs,
-			URISANs:            state.URISANs,
-			SerialNumber:       types.String{Value: cResp.SerialNumber, Null: isNullString(cResp.SerialNumber)},
-			IssuerDN:           types.String{Value: issuerDN, Null: isNullString(issuerDN)},
-			Thumbprint:         types.String{Value: cResp.Thumbprint, Null: isNullString(cResp.Thumbprint)},
-			PEM:                types.String{Value: leaf, Null: isNullString(leaf)},
-			PEMCACert:          types.String{Value: chain, Null: isNullString(chain)},
-			PEMChain:           types.String{Value: fullChain, Null: isNullString(fullChain)},
-			PrivateKey:         state.PrivateKey,
-			KeyPassword:        state.KeyPassword,
+			ID:                  types.String{Value: fmt.Sprintf("%v", cResp.Id)},
+			CSR:                 types.String{Value: csr, Null: isNullString(csr)},
+			CommonName:          types.String{Value: subject.CommonName, Null: true},
+			Locality:            types.String{Value: subject.Locality, Null: true},
+			State:               types.String{Value: subject.State, Null: true},
+			Country:             types.String{Value: subject.Country, Null: true},
+			Organization:        types.String{Value: subject.Organization, Null: true},
+			OrganizationalUnit:  types.String{Value: subject.OrganizationalUnit, Null: true},
+			Email:               types.String{Value: subject.Email, Null: isNullString(subject.Email)},
+			StreetAddress:       flattenStringList(subject.StreetAddress),
+			PostalCode:          types.String{Value: subject.PostalCode, Null: isNullString(subject.PostalCode)},
+			SubjectSerialNumber: types.String{Value: subject.SerialNumber, Null: isNullString(subject.SerialNumber)},
+			DomainComponents:    flattenStringList(subject.DomainComponents),
+			DNSSANs:             state.DNSSANs,
+			IPSANs:              state.IPSANs,
+			URISANs:             state.URISANs,
+			SerialNumber:        types.String{Value: cResp.SerialNumber, Null: isNullString(cResp.SerialNumber)},
+			IssuerDN:            types.String{Value: issuerDN, Null: isNullString(issuerDN)},
+			Thumbprint:          types.String{Value: cResp.Thumbprint, Null: isNullString(cResp.Thumbprint)},
+			PEM:                 types.String{Value: leaf, Null: isNullString(leaf)},
+			PEMCACert:           types.String{Value: chain, Null: isNullString(chain)},
+			PEMChain:            types.String{Value: fullChain, Null: isNullString(fullChain)},
+			PrivateKey:          state.PrivateKey,
+			KeyPassword:         state.KeyPassword,
 			//PEM:                  state.PEM,
 			//PEMChain:             state.PEMChain,
 			//PrivateKey:           state.PrivateKey,
@@ -1019,33 +1188,41 @@ func (r resourceKeyfactorCertificate) Read(
 				Value: cResp.CertificateAuthorityName,
 				Null:  isNullString(cResp.CertificateAuthorityName),
 			},
-			CertificateTemplate: state.CertificateTemplate,
-			Metadata:            metadata,
-			CertificateId:       types.Int64{Value: int64(cResp.Id), Null: isNullId(cResp.Id)},
-			CollectionId:        state.CollectionId,
+			CertificateTemplate:         state.CertificateTemplate,
+			Metadata:                    metadata,
+			CertificateId:               types.Int64{Value: int64(cResp.Id), Null: isNullId(cResp.Id)},
+			CollectionId:                state.CollectionId,
+			EnrollmentFields:            state.EnrollmentFields,
+			PopulateMissingValuesFromAD: state.PopulateMissingValuesFromAD,
+			CompareSANsAsSet:            state.CompareSANsAsSet,
 		}
 	} else {
 		tflog.Debug(ctx, "Creating state object for certificate PFX.")
 		result = KeyfactorCertificate{
-			ID:                 types.String{Value: fmt.Sprintf("%v", cResp.Id)},
-			CSR:                types.String{Value: csr, Null: isNullString(csr)},
-			CommonName:         cn,
-			Locality:           types.String{Value: l.Value, Null: isNullString(l.Value)},
-			State:              types.String{Value: st.Value, Null: isNullString(st.Value)},
-			Country:            types.String{Value: c.Value, Null: isNullString(c.Value)},
-			Organization:       types.String{Value: o.Value, Null: isNullString(o.Value)},
-			OrganizationalUnit: types.String{Value: ou.Value, Null: isNullString(ou.Value)},
-			DNSSANs:            dnsSans,
-			IPSANs:             ipSans,
-			URISANs:            uriSans,
-			SerialNumber:       types.String{Value: cResp.SerialNumber, Null: isNullString(cResp.SerialNumber)},
-			IssuerDN:           types.String{Value: issuerDN, Null: isNullString(issuerDN)},
-			Thumbprint:         types.String{Value: cResp.Thumbprint, Null: isNullString(cResp.Thumbprint)},
-			PEM:                types.String{Value: leaf, Null: isNullString(leaf)},
-			PEMCACert:          types.String{Value: chain, Null: isNullString(chain)},
-			PEMChain:           types.String{Value: fullChain, Null: isNullString(fullChain)},
-			PrivateKey:         types.String{Value: pKey, Null: isNullString(pKey)},
-			KeyPassword:        state.KeyPassword,
+			ID:                  types.String{Value: fmt.Sprintf("%v", cResp.Id)},
+			CSR:                 types.String{Value: csr, Null: isNullString(csr)},
+			CommonName:          types.String{Value: subject.CommonName},
+			Locality:            types.String{Value: subject.Locality, Null: isNullString(subject.Locality)},
+			State:               types.String{Value: subject.State, Null: isNullString(subject.State)},
+			Country:             types.String{Value: subject.Country, Null: isNullString(subject.Country)},
+			Organization:        types.String{Value: subject.Organization, Null: isNullString(subject.Organization)},
+			OrganizationalUnit:  types.String{Value: subject.OrganizationalUnit, Null: isNullString(subject.OrganizationalUnit)},
+			Email:               types.String{Value: subject.Email, Null: isNullString(subject.Email)},
+			StreetAddress:       flattenStringList(subject.StreetAddress),
+			PostalCode:          types.String{Value: subject.PostalCode, Null: isNullString(subject.PostalCode)},
+			SubjectSerialNumber: types.String{Value: subject.SerialNumber, Null: isNullString(subject.SerialNumber)},
+			DomainComponents:    flattenStringList(subject.DomainComponents),
+			DNSSANs:             dnsSans,
+			IPSANs:              ipSans,
+			URISANs:             uriSans,
+			SerialNumber:        types.String{Value: cResp.SerialNumber, Null: isNullString(cResp.SerialNumber)},
+			IssuerDN:            types.String{Value: issuerDN, Null: isNullString(issuerDN)},
+			Thumbprint:          types.String{Value: cResp.Thumbprint, Null: isNullString(cResp.Thumbprint)},
+			PEM:                 types.String{Value: leaf, Null: isNullString(leaf)},
+			PEMCACert:           types.String{Value: chain, Null: isNullString(chain)},
+			PEMChain:            types.String{Value: fullChain, Null: isNullString(fullChain)},
+			PrivateKey:          types.String{Value: pKey, Null: isNullString(pKey)},
+			KeyPassword:         state.KeyPassword,
 			//PEM:                  state.PEM,
 			//PEMChain:             state.PEMChain,
 			//PrivateKey:           state.PrivateKey,
@@ -1054,12 +1231,20 @@ func (r resourceKeyfactorCertificate) Read(
 				Value: cResp.CertificateAuthorityName,
 				Null:  isNullString(cResp.CertificateAuthorityName),
 			},
-			CertificateTemplate: state.CertificateTemplate,
-			Metadata:            metadata,
-			CertificateId:       types.Int64{Value: int64(cResp.Id), Null: isNullId(cResp.Id)},
-			CollectionId:        state.CollectionId,
+			CertificateTemplate:         state.CertificateTemplate,
+			Metadata:                    metadata,
+			CertificateId:               types.Int64{Value: int64(cResp.Id), Null: isNullId(cResp.Id)},
+			CollectionId:                state.CollectionId,
+			EnrollmentFields:            state.EnrollmentFields,
+			PopulateMissingValuesFromAD: state.PopulateMissingValuesFromAD,
+			CompareSANsAsSet:            state.CompareSANsAsSet,
 		}
 	}
+	if sErr != nil {
+		keepStateSubject(&result, state)
+	} else if state.PopulateMissingValuesFromAD.Value {
+		keepUnsetADSubject(&result, state)
+	}
 
 	// Set state
 	tflog.Debug(ctx, "Setting state")
@@ -1165,28 +1350,36 @@ func (r resourceKeyfactorCertificate) Update(
 
 		// Set state
 		var result = KeyfactorCertificate{
-			ID:                   types.String{Value: state.ID.Value},
-			CSR:                  plan.CSR,
-			CommonName:           plan.CommonName,
-			Locality:             plan.Locality,
-			State:                plan.State,
-			Country:              plan.Country,
-			Organization:         plan.Organization,
-			OrganizationalUnit:   plan.OrganizationalUnit,
-			DNSSANs:              plan.DNSSANs,
-			IPSANs:               plan.IPSANs,
-			URISANs:              plan.URISANs,
-			SerialNumber:         plan.SerialNumber,
-			IssuerDN:             plan.IssuerDN,
-			Thumbprint:           plan.Thumbprint,
-			PEM:                  plan.PEM,
-			PEMCACert:            plan.PEMChain,
-			PEMChain:             types.String{Value: fmt.Sprintf("%s%s", plan.PEM.Value, plan.PEMChain.Value)},
-			PrivateKey:           plan.PrivateKey,
-			KeyPassword:          plan.KeyPassword,
-			CertificateAuthority: plan.CertificateAuthority,
-			CertificateTemplate:  plan.CertificateTemplate,
-			Metadata:             plan.Metadata,
+			ID:                          types.String{Value: state.ID.Value},
+			CSR:                         plan.CSR,
+			CommonName:                  plan.CommonName,
+			Locality:                    plan.Locality,
+			State:                       plan.State,
+			Country:                     plan.Country,
+			Organization:                plan.Organization,
+			OrganizationalUnit:          plan.OrganizationalUnit,
+			Email:                       state.Email,
+			StreetAddress:               state.StreetAddress,
+			PostalCode:                  state.PostalCode,
+			SubjectSerialNumber:         state.SubjectSerialNumber,
+			DomainComponents:            state.DomainComponents,
+			DNSSANs:                     plan.DNSSANs,
+			IPSANs:                      plan.IPSANs,
+			URISANs:                     plan.URISANs,
+			SerialNumber:                plan.SerialNumber,
+			IssuerDN:                    plan.IssuerDN,
+			Thumbprint:                  plan.Thumbprint,
+			PEM:                         plan.PEM,
+			PEMCACert:                   plan.PEMChain,
+			PEMChain:                    types.String{Value: fmt.Sprintf("%s%s", plan.PEM.Value, plan.PEMChain.Value)},
+			PrivateKey:                  plan.PrivateKey,
+			KeyPassword:                 plan.KeyPassword,
+			CertificateAuthority:        plan.CertificateAuthority,
+			CertificateTemplate:         plan.CertificateTemplate,
+			Metadata:                    plan.Metadata,
+			EnrollmentFields:            plan.EnrollmentFields,
+			PopulateMissingValuesFromAD: plan.PopulateMissingValuesFromAD,
+			CompareSANsAsSet:            plan.CompareSANsAsSet,
 		}
 
 		diags = response.State.Set(ctx, result)
@@ -1231,29 +1424,37 @@ func (r resourceKeyfactorCertificate) Update(
 		// Set state
 		tflog.Debug(ctx, "Creating KeyfactorCertificate state object")
 		var result = KeyfactorCertificate{
-			ID:                   state.ID,
-			CSR:                  state.CSR,
-			CommonName:           state.CommonName,
-			Locality:             state.Locality,
-			State:                state.State,
-			Country:              state.Country,
-			Organization:         state.Organization,
-			OrganizationalUnit:   state.OrganizationalUnit,
-			DNSSANs:              state.DNSSANs,
-			IPSANs:               state.IPSANs,
-			URISANs:              state.URISANs,
-			SerialNumber:         state.SerialNumber,
-			IssuerDN:             state.IssuerDN,
-			Thumbprint:           state.Thumbprint,
-			PEM:                  state.PEM,
-			PEMCACert:            state.PEMCACert,
-			PEMChain:             state.PEMChain,
-			PrivateKey:           state.PrivateKey,
-			KeyPassword:          plan.KeyPassword,
-			CertificateId:        state.CertificateId,
-			CertificateAuthority: state.CertificateAuthority,
-			CertificateTemplate:  state.CertificateTemplate,
-			Metadata:             plan.Metadata,
+			ID:                          state.ID,
+			CSR:                         state.CSR,
+			CommonName:                  state.CommonName,
+			Locality:                    state.Locality,
+			State:                       state.State,
+			Country:                     state.Country,
+			Organization:                state.Organization,
+			OrganizationalUnit:          state.OrganizationalUnit,
+			Email:                       state.Email,
+			StreetAddress:               state.StreetAddress,
+			PostalCode:                  state.PostalCode,
+			SubjectSerialNumber:         state.SubjectSerialNumber,
+			DomainComponents:            state.DomainComponents,
+			DNSSANs:                     plan.DNSSANs,
+			IPSANs:                      plan.IPSANs,
+			URISANs:                     plan.URISANs,
+			SerialNumber:                state.SerialNumber,
+			IssuerDN:                    state.IssuerDN,
+			Thumbprint:                  state.Thumbprint,
+			PEM:                         state.PEM,
+			PEMCACert:                   state.PEMCACert,
+			PEMChain:                    state.PEMChain,
+			PrivateKey:                  state.PrivateKey,
+			KeyPassword:                 plan.KeyPassword,
+			CertificateId:               state.CertificateId,
+			CertificateAuthority:        state.CertificateAuthority,
+			CertificateTemplate:         state.CertificateTemplate,
+			Metadata:                    plan.Metadata,
+			EnrollmentFields:            plan.EnrollmentFields,
+			PopulateMissingValuesFromAD: plan.PopulateMissingValuesFromAD,
+			CompareSANsAsSet:            plan.CompareSANsAsSet,
 		}
 
 		diags = response.State.Set(ctx, result)
@@ -1351,6 +1552,159 @@ func (r resourceKeyfactorCertificate) Delete(
 	tflog.Info(ctx, fmt.Sprintf("Certificate '%s' removed from state.", certificateId))
 }
 
+func (r resourceKeyfactorCertificate) ModifyPlan(
+	ctx context.Context,
+	request tfsdk.ModifyResourcePlanRequest,
+	response *tfsdk.ModifyResourcePlanResponse,
+) {
+	if request.Plan.Raw.IsNull() || !r.p.configured {
+		// Nothing to check on destroy or when the provider can't reach Keyfactor Command yet
+		return
+	}
+
+	var plan KeyfactorCertificate
+	diags := request.Plan.Get(ctx, &plan)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if !request.State.Raw.IsNull() {
+		var state KeyfactorCertificate
+		diags = request.State.Get(ctx, &state)
+		response.Diagnostics.Append(diags...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+		// Only check the template policy when the plan results in a new enrollment. Extended subject attributes that
+		// aren't configured are unknown in the plan.
+		if plan.CSR.Equal(state.CSR) &&
+			plan.CommonName.Equal(state.CommonName) &&
+			plan.Organization.Equal(state.Organization) &&
+			plan.OrganizationalUnit.Equal(state.OrganizationalUnit) &&
+			plan.Locality.Equal(state.Locality) &&
+			plan.State.Equal(state.State) &&
+			plan.Country.Equal(state.Country) &&
+			(plan.Email.Unknown || plan.Email.Equal(state.Email)) &&
+			(plan.StreetAddress.Unknown || plan.StreetAddress.Equal(state.StreetAddress)) &&
+			(plan.PostalCode.Unknown || plan.PostalCode.Equal(state.PostalCode)) &&
+			(plan.SubjectSerialNumber.Unknown || plan.SubjectSerialNumber.Equal(state.SubjectSerialNumber)) &&
+			(plan.DomainComponents.Unknown || plan.DomainComponents.Equal(state.DomainComponents)) &&
+			sanListsMatch(plan.CompareSANsAsSet.Value, SAN_TYPE_DNS, plan.DNSSANs, state.DNSSANs) &&
+			sanListsMatch(plan.CompareSANsAsSet.Value, SAN_TYPE_IP, plan.IPSANs, state.IPSANs) &&
+			sanListsMatch(plan.CompareSANsAsSet.Value, SAN_TYPE_URI, plan.URISANs, state.URISANs) &&
+			plan.CertificateTemplate.Equal(state.CertificateTemplate) &&
+			plan.EnrollmentFields.Equal(state.EnrollmentFields) &&
+			plan.PopulateMissingValuesFromAD.Equal(state.PopulateMissingValuesFromAD) {
+			return
+		}
+	}
+
+	if plan.PopulateMissingValuesFromAD.Value && !plan.CSR.Unknown && plan.CSR.Value != "" {
+		response.Diagnostics.AddAttributeError(
+			path.Root("populate_missing_values_from_ad"),
+			ERR_SUMMARY_INVALID_CERTIFICATE_RESOURCE,
+			"You cannot set populate_missing_values_from_ad when using a CSR.",
+		)
+		return
+	}
+
+	if plan.CertificateTemplate.Unknown || plan.CertificateTemplate.Null {
+		tflog.Debug(ctx, "Certificate template is not known, skipping template policy check.")
+		return
+	}
+
+	templateName := plan.CertificateTemplate.Value
+	ctx = tflog.SetField(ctx, "certificate_template", templateName)
+	tflog.Info(ctx, fmt.Sprintf("Checking planned certificate against template '%s' policy.", templateName))
+	template, err := lookupTemplate(r.p.client, templateName)
+	if err != nil {
+		response.Diagnostics.AddError(ERR_SUMMARY_TEMPLATE_READ, err.Error())
+		return
+	}
+
+	dnsSANs, dnsKnown := knownListStrings(plan.DNSSANs)
+	ipSANs, ipKnown := knownListStrings(plan.IPSANs)
+	uriSANs, uriKnown := knownListStrings(plan.URISANs)
+	policyRequest := certificatePolicyRequest{
+		CommonName:         plan.CommonName.Value,
+		Organization:       plan.Organization.Value,
+		OrganizationalUnit: plan.OrganizationalUnit.Value,
+		Locality:           plan.Locality.Value,
+		State:              plan.State.Value,
+		Country:            plan.Country.Value,
+		DNSSANs:            dnsSANs,
+		IPSANs:             ipSANs,
+		URISANs:            uriSANs,
+	}
+	subjectKnown := !plan.CommonName.Unknown && !plan.Organization.Unknown && !plan.OrganizationalUnit.Unknown &&
+		!plan.Locality.Unknown && !plan.State.Unknown && !plan.Country.Unknown
+
+	if plan.CSR.Unknown {
+		subjectKnown = false
+	} else if plan.CSR.Value != "" {
+		csr, csrErr := parseCSR(plan.CSR.Value)
+		if csrErr != nil {
+			response.Diagnostics.AddError(
+				ERR_SUMMARY_INVALID_CERTIFICATE_RESOURCE,
+				"Unable to parse csr: "+csrErr.Error(),
+			)
+			return
+		}
+		policyRequest.CommonName = csr.Subject.CommonName
+		policyRequest.Organization = strings.Join(csr.Subject.Organization, ",")
+		policyRequest.OrganizationalUnit = strings.Join(csr.Subject.OrganizationalUnit, ",")
+		policyRequest.Locality = strings.Join(csr.Subject.Locality, ",")
+		policyRequest.State = strings.Join(csr.Subject.Province, ",")
+		policyRequest.Country = strings.Join(csr.Subject.Country, ",")
+		policyRequest.PublicKey = csr.PublicKey
+		if len(dnsSANs) == 0 {
+			policyRequest.DNSSANs = csr.DNSNames
+		}
+		if len(ipSANs) == 0 {
+			for _, ip := range csr.IPAddresses {
+				policyRequest.IPSANs = append(policyRequest.IPSANs, ip.String())
+			}
+		}
+		if len(uriSANs) == 0 {
+			for _, uri := range csr.URIs {
+				policyRequest.URISANs = append(policyRequest.URISANs, uri.String())
+			}
+		}
+		subjectKnown = true
+	}
+
+	if subjectKnown && dnsKnown && ipKnown && uriKnown {
+		response.Diagnostics.Append(validateTemplatePolicy(template, policyRequest)...)
+	} else {
+		tflog.Debug(ctx, "Planned subject or SANs are not known yet, skipping template regex and RFC checks.")
+	}
+
+	if !plan.EnrollmentFields.Unknown && len(plan.EnrollmentFields.Elems) > 0 {
+		var enrollmentFields map[string]string
+		diags = plan.EnrollmentFields.ElementsAs(ctx, &enrollmentFields, false)
+		if !diags.HasError() {
+			response.Diagnostics.Append(validateEnrollmentFields(template, enrollmentFields)...)
+		}
+	}
+
+	if plan.PopulateMissingValuesFromAD.Value {
+		response.Diagnostics.Append(validatePopulateMissingValuesFromAD(template)...)
+	}
+
+	response.Diagnostics.Append(validateAllowedRequester(r.p.client, template, r.p.clientAuth)...)
+
+	if template.RequiresApproval {
+		response.Diagnostics.AddWarning(
+			"Certificate enrollment will require approval.",
+			fmt.Sprintf(
+				"Template '%s' requires approval. The enrollment request must be approved in Keyfactor Command before the certificate is issued.",
+				template.CommonName,
+			),
+		)
+	}
+}
+
 func (r resourceKeyfactorCertificate) ImportState(
 	ctx context.Context,
 	request tfsdk.ImportResourceStateRequest,
@@ -1432,29 +1786,45 @@ func (r resourceKeyfactorCertificate) ImportState(
 		return
 	}
 
+	subject, sErr := expandSubject(certificateData.IssuedDN)
+	if sErr != nil {
+		response.Diagnostics.AddWarning(
+			ERR_SUMMARY_CERTIFICATE_RESOURCE_READ,
+			fmt.Sprintf("Could not parse subject of certificate '%s', the subject attributes are not imported: %s", certificateId, sErr.Error()),
+		)
+	}
+
 	tflog.Debug(ctx, "Creating KeyfactorCertificate object")
 	var result = KeyfactorCertificate{
-		ID:                   types.String{Value: state.ID.Value},
-		CSR:                  types.String{Value: csr},
-		CommonName:           state.CommonName,
-		Locality:             state.Locality,
-		State:                state.State,
-		Country:              state.Country,
-		Organization:         state.Organization,
-		OrganizationalUnit:   state.OrganizationalUnit,
-		DNSSANs:              state.DNSSANs,
-		IPSANs:               state.IPSANs,
-		URISANs:              state.URISANs,
-		SerialNumber:         state.SerialNumber,
-		IssuerDN:             state.IssuerDN,
-		Thumbprint:           state.Thumbprint,
-		PEM:                  types.String{Value: leaf},
-		PEMChain:             types.String{Value: chain},
-		PrivateKey:           types.String{Value: priv},
-		KeyPassword:          types.String{Value: password},
-		CertificateAuthority: state.CertificateAuthority,
-		CertificateTemplate:  state.CertificateTemplate,
-		Metadata:             state.Metadata,
+		ID:                          types.String{Value: state.ID.Value},
+		CSR:                         types.String{Value: csr},
+		CommonName:                  state.CommonName,
+		Locality:                    state.Locality,
+		State:                       state.State,
+		Country:                     state.Country,
+		Organization:                state.Organization,
+		OrganizationalUnit:          state.OrganizationalUnit,
+		Email:                       types.String{Value: subject.Email, Null: isNullString(subject.Email)},
+		StreetAddress:               flattenStringList(subject.StreetAddress),
+		PostalCode:                  types.String{Value: subject.PostalCode, Null: isNullString(subject.PostalCode)},
+		SubjectSerialNumber:         types.String{Value: subject.SerialNumber, Null: isNullString(subject.SerialNumber)},
+		DomainComponents:            flattenStringList(subject.DomainComponents),
+		DNSSANs:                     state.DNSSANs,
+		IPSANs:                      state.IPSANs,
+		URISANs:                     state.URISANs,
+		SerialNumber:                state.SerialNumber,
+		IssuerDN:                    state.IssuerDN,
+		Thumbprint:                  state.Thumbprint,
+		PEM:                         types.String{Value: leaf},
+		PEMChain:                    types.String{Value: chain},
+		PrivateKey:                  types.String{Value: priv},
+		KeyPassword:                 types.String{Value: password},
+		CertificateAuthority:        state.CertificateAuthority,
+		CertificateTemplate:         state.CertificateTemplate,
+		Metadata:                    state.Metadata,
+		EnrollmentFields:            types.Map{ElemType: types.StringType, Null: true},
+		PopulateMissingValuesFromAD: types.Bool{Null: true},
+		CompareSANsAsSet:            types.Bool{Null: true},
 	}
 
 	// Set state
@@ -1468,6 +1838,63 @@ func (r resourceKeyfactorCertificate) ImportState(
 	tflog.Info(ctx, fmt.Sprintf("Certificate '%s' imported into state.", certificateId))
 }
 
+// keepStateSubject copies the subject attributes of state into result. It is used when the subject returned by Keyfactor
+// Command can't be parsed, so that an unchanged certificate isn't planned for replacement.
+func keepStateSubject(result *KeyfactorCertificate, state KeyfactorCertificate) {
+	result.CommonName = state.CommonName
+	result.Organization = state.Organization
+	result.OrganizationalUnit = state.OrganizationalUnit
+	result.Locality = state.Locality
+	result.State = state.State
+	result.Country = state.Country
+	result.Email = state.Email
+	result.StreetAddress = state.StreetAddress
+	result.PostalCode = state.PostalCode
+	result.SubjectSerialNumber = state.SubjectSerialNumber
+	result.DomainComponents = state.DomainComponents
+}
+
+// keepUnsetADSubject keeps the subject attributes that are unset in state unset in result. Keyfactor Command fills them
+// from Active Directory when populate_missing_values_from_ad is set, and they'd otherwise replace the certificate on
+// every plan.
+func keepUnsetADSubject(result *KeyfactorCertificate, state KeyfactorCertificate) {
+	if state.Organization.Null {
+		result.Organization = state.Organization
+	}
+	if state.OrganizationalUnit.Null {
+		result.OrganizationalUnit = state.OrganizationalUnit
+	}
+	if state.Locality.Null {
+		result.Locality = state.Locality
+	}
+	if state.State.Null {
+		result.State = state.State
+	}
+	if state.Country.Null {
+		result.Country = state.Country
+	}
+}
+
+// keepPlannedSubject keeps the configured extended subject attributes of plan in result, which otherwise holds the
+// values of the enrolled certificate. Attributes that aren't configured are unknown in the plan.
+func keepPlannedSubject(result *KeyfactorCertificate, plan KeyfactorCertificate) {
+	if !plan.Email.Unknown {
+		result.Email = plan.Email
+	}
+	if !plan.StreetAddress.Unknown {
+		result.StreetAddress = plan.StreetAddress
+	}
+	if !plan.PostalCode.Unknown {
+		result.PostalCode = plan.PostalCode
+	}
+	if !plan.SubjectSerialNumber.Unknown {
+		result.SubjectSerialNumber = plan.SubjectSerialNumber
+	}
+	if !plan.DomainComponents.Unknown {
+		result.DomainComponents = plan.DomainComponents
+	}
+}
+
 func (r resourceKeyfactorCertificate) CertLookupByRequestID(
 	ctx context.Context,
 	requestID int,
@@ -1770,3 +2197,41 @@ func (r resourceKeyfactorCertificate) HandlePendingCert(
 	}
 	return certResponse, nil
 }
+
+// enrollPFXWithSubject submits a PFX enrollment like EnrollPFXV2, with subject as the distinguished name of the
+// certificate instead of the subject fields of args.
+func enrollPFXWithSubject(
+	ctx context.Context,
+	p provider,
+	args *api.EnrollPFXFctArgsV2,
+	subject string,
+) (*api.EnrollResponseV2, error) {
+	body := pfxEnrollmentRequest{
+		CustomFriendlyName:          args.CustomFriendlyName,
+		Password:                    args.Password,
+		PopulateMissingValuesFromAD: args.PopulateMissingValuesFromAD,
+		Subject:                     subject,
+		IncludeChain:                args.IncludeChain,
+		CertificateAuthority:        args.CertificateAuthority,
+		Template:                    args.Template,
+		Timestamp:                   time.Now().UTC().Format(time.RFC3339),
+		Metadata:                    args.Metadata,
+		AdditionalEnrollmentFields:  args.AdditionalEnrollmentFields,
+	}
+	if args.SANs != nil {
+		body.SANs = map[string][]string{}
+		for sanType, values := range map[string][]string{"dns": args.SANs.DNS, "ip4": args.SANs.IP4, "ip6": args.SANs.IP6, "uri": args.SANs.URI} {
+			if len(values) > 0 {
+				body.SANs[sanType] = values
+			}
+		}
+	}
+
+	var enrollResponse api.EnrollResponseV2
+	headers := map[string]string{"x-certificateformat": args.CertFormat}
+	err := p.commandRequestWithHeaders(ctx, http.MethodPost, "Enrollment/PFX", nil, headers, body, &enrollResponse)
+	if err != nil {
+		return nil, err
+	}
+	return &enrollResponse, nil
+}
@@ -5,11 +5,20 @@ import (
 	"crypto/sha256"
 	"fmt"
 	"github.com/Keyfactor/keyfactor-go-client/v2/api"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -24,17 +33,46 @@ func (r resourceKeyfactorCertificateDeploymentType) GetSchema(_ context.Context)
 				Description: "A unique identifier for this certificate deployment.",
 			},
 			"certificate_id": {
-				Type:          types.Int64Type,
-				Required:      true,
-				Description:   "Keyfactor certificate ID",
-				PlanModifiers: []tfsdk.AttributePlanModifier{tfsdk.RequiresReplace()},
+				Type:        types.Int64Type,
+				Required:    true,
+				Description: "Keyfactor certificate ID. Changing this replaces the deployment unless `rotate_in_place` is set.",
+			},
+			"certificate_thumbprint": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "Thumbprint of the deployed certificate.",
+			},
+			"rotate_in_place": {
+				Type:     types.BoolType,
+				Optional: true,
+				Description: "When true, a change of `certificate_id`, for example after the certificate is renewed or re-enrolled, " +
+					"deploys the new certificate over the existing alias in each store and only then removes the previous certificate, " +
+					"instead of removing the previous certificate and replacing the deployment. Defaults to false.",
 			},
 			"certificate_store_id": {
 				Type:          types.StringType,
-				Required:      true,
-				Description:   "A string containing the GUID for the certificate store to which the certificate should be added.",
+				Optional:      true,
+				Description:   "A string containing the GUID for the certificate store to which the certificate should be added. Use `store` blocks instead to deploy the certificate to multiple stores.",
 				PlanModifiers: []tfsdk.AttributePlanModifier{tfsdk.RequiresReplace()},
 			},
+			"container_name": {
+				Type:     types.StringType,
+				Optional: true,
+				Description: "Deploy the certificate to every certificate store in the store container with this name. " +
+					"The container is resolved on every plan, so stores added to it later also get the certificate and stores removed from it are cleaned up. " +
+					"Can be combined with `store_query`.",
+			},
+			"store_query": {
+				Type:     types.StringType,
+				Optional: true,
+				Description: "Deploy the certificate to every certificate store matching this Keyfactor Command certificate store query. " +
+					"Ex: `ClientMachine -startswith \"web-\" AND CertStoreType -eq 2`. The query is resolved on every plan.",
+			},
+			"selected_store_ids": {
+				Type:        types.ListType{ElemType: types.StringType},
+				Computed:    true,
+				Description: "IDs of the certificate stores selected by `container_name` and `store_query`.",
+			},
 			"certificate_alias": {
 				Type:          types.StringType,
 				Required:      false,
@@ -46,14 +84,86 @@ func (r resourceKeyfactorCertificateDeploymentType) GetSchema(_ context.Context)
 				Type:          types.StringType,
 				Optional:      true,
 				Sensitive:     true,
-				Description:   "Password that protects PFX certificate, if the certificate was enrolled using PFX enrollment, or is password protected in general. This value cannot change, and Terraform will throw an error if a change is attempted.",
-				PlanModifiers: []tfsdk.AttributePlanModifier{tfsdk.RequiresReplace()},
+				Description:   "Password that protects PFX certificate, if the certificate was enrolled using PFX enrollment, or is password protected in general. Changing this replaces the deployment. The password can't be read back on import, so set it in the configuration after an import; adding it then doesn't redeploy the certificate.",
+				PlanModifiers: []tfsdk.AttributePlanModifier{requiresReplaceIfSet()},
+			},
+			"include_private_key": {
+				Type:        types.BoolType,
+				Optional:    true,
+				Description: "Deploy the private key along with the certificate. Set to false for trust stores that should only receive the public certificate. Defaults to true.",
+			},
+			"overwrite": {
+				Type:        types.BoolType,
+				Optional:    true,
+				Description: "Overwrite an existing certificate with the same alias in the store. When false the deployment fails if the alias already holds a different certificate. Defaults to true.",
 			},
 			"job_parameters": {
 				Type:        types.MapType{ElemType: types.StringType},
 				Optional:    true,
 				Description: "A map of entry parameters to be passed to the deployment job. These will only be used if the orchestrator extension supports them.",
 			},
+			"wait_for_completion": {
+				Type:     types.BoolType,
+				Optional: true,
+				Description: "Wait for the deployment jobs to complete and validate the store inventories before finishing the apply. " +
+					"Waiting gives up after 30 minutes. When false the IDs of the deployment jobs are recorded and their status is checked " +
+					"on later refreshes. Defaults to true.",
+			},
+			"job_ids": {
+				Type:        types.ListType{ElemType: types.StringType},
+				Computed:    true,
+				Description: "IDs of the orchestrator jobs of the last deployment.",
+			},
+			"job_status": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "Status of the orchestrator jobs of the last deployment. Either `Pending` or `Completed`.",
+			},
+		},
+		Blocks: map[string]tfsdk.Block{
+			"schedule": {
+				NestingMode: tfsdk.BlockNestingModeList,
+				MaxItems:    1,
+				Description: "When to run the deployment job. Jobs run immediately if not provided. Requires `wait_for_completion` to be false.",
+				Attributes: map[string]tfsdk.Attribute{
+					"exactly_once": {
+						Type:        types.StringType,
+						Required:    true,
+						Description: "RFC 3339 timestamp at which the deployment job runs. Ex: 2024-01-01T02:00:00Z",
+					},
+				},
+			},
+			"store": {
+				NestingMode: tfsdk.BlockNestingModeSet,
+				Description: "Certificate stores to deploy the certificate to. All stores are deployed to in a single job. Cannot be used with `certificate_store_id`.",
+				Attributes: map[string]tfsdk.Attribute{
+					"certificate_store_id": {
+						Type:        types.StringType,
+						Required:    true,
+						Description: "A string containing the GUID for the certificate store to which the certificate should be added.",
+					},
+					"certificate_alias": {
+						Type:        types.StringType,
+						Optional:    true,
+						Description: "A string providing an alias to be used for the certificate upon entry into the certificate store. If not provided deployment validation will be done by Command certificate ID.",
+					},
+					"include_private_key": {
+						Type:        types.BoolType,
+						Optional:    true,
+						Description: "Deploy the private key along with the certificate. Set to false for trust stores that should only receive the public certificate. Defaults to true.",
+					},
+					"overwrite": {
+						Type:        types.BoolType,
+						Optional:    true,
+						Description: "Overwrite an existing certificate with the same alias in the store. When false the deployment fails if the alias already holds a different certificate. Defaults to true.",
+					},
+					"job_parameters": {
+						Type:        types.MapType{ElemType: types.StringType},
+						Optional:    true,
+						Description: "A map of entry parameters to be passed to the deployment job for this store. These will only be used if the orchestrator extension supports them.",
+					},
+				},
+			},
 		},
 	}, nil
 }
@@ -68,6 +178,48 @@ type resourceKeyfactorCertificateDeployment struct {
 	p provider
 }
 
+func (r resourceKeyfactorCertificateDeployment) ValidateConfig(ctx context.Context, request tfsdk.ValidateResourceConfigRequest, response *tfsdk.ValidateResourceConfigResponse) {
+	var config KeyfactorCertificateDeployment
+	diags := request.Config.Get(ctx, &config)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	hasStoreBlocks := config.Stores.Unknown || len(config.Stores.Elems) > 0
+	hasSelector := !config.ContainerName.Null || !config.StoreQuery.Null
+	targets := 0
+	for _, set := range []bool{!config.StoreId.Null, hasStoreBlocks, hasSelector} {
+		if set {
+			targets++
+		}
+	}
+	if targets != 1 {
+		response.Diagnostics.AddError(
+			ERR_SUMMARY_DEPLOYMENT_CONFIG,
+			"Exactly one of 'certificate_store_id', 'store' blocks, or 'container_name' and 'store_query' must be provided.",
+		)
+		return
+	}
+	if hasStoreBlocks && (!config.CertificateAlias.Null || !config.JobParameters.Null ||
+		!config.IncludePrivateKey.Null || !config.Overwrite.Null) {
+		response.Diagnostics.AddError(
+			ERR_SUMMARY_DEPLOYMENT_CONFIG,
+			"'certificate_alias', 'include_private_key', 'overwrite' and 'job_parameters' cannot be used with 'store' blocks. Set them on each 'store' block instead.",
+		)
+	}
+	if len(config.Schedule.Elems) > 0 {
+		if config.WaitForCompletion.Null || (!config.WaitForCompletion.Unknown && config.WaitForCompletion.Value) {
+			response.Diagnostics.AddError(
+				ERR_SUMMARY_DEPLOYMENT_CONFIG,
+				"'wait_for_completion' must be false when a 'schedule' is provided.",
+			)
+		}
+		_, scheduleDiags := expandDeploymentSchedule(ctx, config)
+		response.Diagnostics.Append(scheduleDiags...)
+	}
+}
+
 func (r resourceKeyfactorCertificateDeployment) Create(ctx context.Context, request tfsdk.CreateResourceRequest,
 	response *tfsdk.CreateResourceResponse) {
 	if !r.p.configured {
@@ -92,16 +244,25 @@ func (r resourceKeyfactorCertificateDeployment) Create(ctx context.Context, requ
 
 	certificateId := plan.CertificateId.Value
 	certificateIdInt := int(certificateId)
-	storeId := plan.StoreId.Value
-	certificateAlias := plan.CertificateAlias.Value
-	keyPassword := plan.KeyPassword.Value
-	var jobParams map[string]string
-	_ = plan.JobParameters.ElementsAs(ctx, &jobParams, false)
-	hid := fmt.Sprintf("%v-%s-%s", certificateId, storeId, certificateAlias)
+	if plan.SelectedStoreIds.Unknown {
+		storeIds, err := resolveDeploymentSelector(ctx, r.p, plan)
+		if err != nil {
+			response.Diagnostics.AddError(
+				ERR_SUMMARY_CERT_STORE_READ,
+				"Unable to resolve certificate stores for deployment: "+err.Error(),
+			)
+			return
+		}
+		plan.SelectedStoreIds = flattenStringList(storeIds)
+	}
+	stores, sDiags := expandDeploymentStores(ctx, plan)
+	response.Diagnostics.Append(sDiags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
 
 	ctx = tflog.SetField(ctx, "certificate_id", certificateId)
-	ctx = tflog.SetField(ctx, "certificate_store_id", storeId)
-	ctx = tflog.SetField(ctx, "certificate_alias", certificateAlias)
+	ctx = tflog.SetField(ctx, "certificate_store_count", len(stores))
 	tflog.Info(ctx, "Create called on certificate deployment resource")
 
 	//Read cert from Keyfactor Command
@@ -113,52 +274,43 @@ func (r resourceKeyfactorCertificateDeployment) Create(ctx context.Context, requ
 	if err != nil {
 		response.Diagnostics.AddError(
 			"Deployment read error.",
-			fmt.Sprintf("Unknown error during read status of deployment of certificate '%s' to store '%s (%s)': "+err.Error(), certificateId, storeId, certificateAlias),
+			fmt.Sprintf("Unknown error during read of certificate '%v' for deployment: %s", certificateId, err.Error()),
 		)
+		return
 	}
 
-	//sans := plan.SANs
-	//metadata := plan.Metadata.Elems
-	//vErr := validateCertificatesInStore(ctx, kfClient, certificateIdInt, storeId, 1) // Initial check to see if the cert is already deployed
-	vErr := validateDeployment(ctx, kfClient, storeId, certificateAlias, certificateData, 1) // Initial check to see if the cert is already deployed
-	if vErr == nil {
-		response.Diagnostics.AddWarning(
-			"Duplicate deployment.",
-			fmt.Sprintf("Certificate '%v' is already deployed to '%s (%s)'", certificateId, storeId, certificateAlias),
-		)
-	} else {
-		addErr := addCertificateToStore(ctx, kfClient, certificateIdInt, certificateAlias, keyPassword, storeId, jobParams)
-		if addErr != nil {
-			response.Diagnostics.AddError(
-				"Certificate deployment error",
-				fmt.Sprintf("Unknown error during deploy of certificate '%v'(%s) to store '%s': "+addErr.Error(), certificateId, certificateAlias, storeId),
-			)
-		}
-		if response.Diagnostics.HasError() {
-			return
-		}
-
-		//vErr2 := validateCertificatesInStore(ctx, kfClient, certificateIdInt, storeId, 100000)
-		vErr2 := validateDeployment(ctx, kfClient, storeId, certificateAlias, certificateData, 1000000) // Initial check to see if the cert is already deployed
-		if vErr2 != nil {
-			response.Diagnostics.AddError(
-				"Deployment validation error.",
-				fmt.Sprintf("Unknown error during validation of deploy of certificate '%s' to store '%s (%s)': "+vErr.Error(), certificateId, storeId, certificateAlias),
-			)
-		}
-		if response.Diagnostics.HasError() {
-			return
-		}
+	schedule, scheduleDiags := expandDeploymentSchedule(ctx, plan)
+	response.Diagnostics.Append(scheduleDiags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	wait := plan.WaitForCompletion.Null || plan.WaitForCompletion.Value
+	jobIds, deployDiags := deployCertificateToStores(ctx, r.p, certificateIdInt, certificateData, stores, nil, schedule, wait)
+	response.Diagnostics.Append(deployDiags...)
+	if response.Diagnostics.HasError() {
+		return
 	}
 
 	// Set state
 	var result = KeyfactorCertificateDeployment{
-		ID:               types.String{Value: fmt.Sprintf("%x", sha256.Sum256([]byte(hid)))},
-		CertificateId:    plan.CertificateId,
-		StoreId:          plan.StoreId,
-		CertificateAlias: plan.CertificateAlias,
-		KeyPassword:      plan.KeyPassword,
-		JobParameters:    plan.JobParameters,
+		ID:                    types.String{Value: deploymentId(plan, stores)},
+		CertificateId:         plan.CertificateId,
+		CertificateThumbprint: types.String{Value: certificateData.Thumbprint},
+		StoreId:               plan.StoreId,
+		CertificateAlias:      plan.CertificateAlias,
+		KeyPassword:           plan.KeyPassword,
+		IncludePrivateKey:     plan.IncludePrivateKey,
+		Overwrite:             plan.Overwrite,
+		RotateInPlace:         plan.RotateInPlace,
+		JobParameters:         plan.JobParameters,
+		WaitForCompletion:     plan.WaitForCompletion,
+		JobIds:                flattenStringList(jobIds),
+		JobStatus:             deploymentJobStatus(wait, jobIds),
+		Schedule:              plan.Schedule,
+		Stores:                plan.Stores,
+		ContainerName:         plan.ContainerName,
+		StoreQuery:            plan.StoreQuery,
+		SelectedStoreIds:      plan.SelectedStoreIds,
 	}
 
 	diags = response.State.Set(ctx, result)
@@ -181,16 +333,9 @@ func (r resourceKeyfactorCertificateDeployment) Read(ctx context.Context, reques
 
 	certificateId := state.CertificateId.Value
 	certificateIdInt := int(certificateId)
-	storeId := state.StoreId.Value
-	//storeIdInt := int(storeId)
-	certificateAlias := state.CertificateAlias.Value
-	//keyPassword := state.KeyPassword.Value
-	//hid := fmt.Sprintf("%s-%s-%s", certificateId, storeId, certificateAlias)
 
 	ctx = tflog.SetField(ctx, "certificate_id", certificateId)
-	ctx = tflog.SetField(ctx, "certificate_store_id", storeId)
-	ctx = tflog.SetField(ctx, "certificate_alias", certificateAlias)
-	tflog.Info(ctx, "Create called on certificate deployment resource")
+	tflog.Info(ctx, "Read called on certificate deployment resource")
 
 	// Get certificate context
 	args := &api.GetCertificateContextArgs{
@@ -201,22 +346,104 @@ func (r resourceKeyfactorCertificateDeployment) Read(ctx context.Context, reques
 	if err != nil {
 		response.Diagnostics.AddError(
 			"Deployment read error.",
-			fmt.Sprintf("Unknown error during read status of deployment of certificate '%s' to store '%s (%s)': "+err.Error(), certificateId, storeId, certificateAlias),
+			fmt.Sprintf("Unknown error during read status of deployment of certificate '%v': %s", certificateId, err.Error()),
 		)
+		return
 	}
 	locations := certificateData.Locations
 	for _, location := range locations {
 		tflog.Debug(ctx, fmt.Sprintf("Certificate %v stored in location: %v", certificateIdInt, location))
 	}
 
+	// Compare the latest store inventories with the deployment to detect drift
+	stores, sDiags := expandDeploymentStores(ctx, state)
+	response.Diagnostics.Append(sDiags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	jobStatus := state.JobStatus
+	failed := make(map[string]bool)
+	if jobStatus.Value == DEPLOYMENT_JOB_STATUS_PENDING {
+		var jobIds []string
+		diags = state.JobIds.ElementsAs(ctx, &jobIds, true)
+		response.Diagnostics.Append(diags...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+		jobs := make(map[string]orchestratorJobHistory)
+		var jobErr error
+		if len(jobIds) > 0 {
+			jobs, jobErr = r.p.completedOrchestratorJobs(ctx, jobIds)
+		}
+		if jobErr != nil {
+			response.Diagnostics.AddError(
+				"Deployment read error.",
+				fmt.Sprintf("Unknown error during read of deployment job status of certificate '%v': %s", certificateId, jobErr.Error()),
+			)
+			return
+		}
+		if len(jobIds) == 0 || len(jobs) >= len(jobIds) {
+			var jobDiags diag.Diagnostics
+			failed, jobDiags = orchestratorJobFailures(kfClient, stores, jobs, certificateIdInt)
+			for _, d := range jobDiags {
+				// Failed stores are redeployed by the next apply
+				response.Diagnostics.AddWarning(d.Summary(), d.Detail())
+			}
+			jobStatus = types.String{Value: DEPLOYMENT_JOB_STATUS_COMPLETED}
+		}
+	}
+
+	drifted := make(map[string]bool)
+	if jobStatus.Value != DEPLOYMENT_JOB_STATUS_PENDING {
+		// Store inventories don't hold the certificate until the deployment jobs have run
+		drifted, err = findDeploymentDrift(ctx, kfClient, stores, certificateData)
+		if err != nil {
+			response.Diagnostics.AddError(
+				"Deployment read error.",
+				fmt.Sprintf("Unknown error during read of store inventory for deployment of certificate '%v': %s", certificateId, err.Error()),
+			)
+			return
+		}
+	}
+	for _, store := range stores {
+		if failed[store.CertificateStoreId] {
+			drifted[deploymentStoreKey(store)] = true
+		}
+	}
+	for _, store := range stores {
+		if drifted[deploymentStoreKey(store)] {
+			response.Diagnostics.AddWarning(
+				"Certificate deployment drift detected.",
+				fmt.Sprintf("Certificate '%v' is no longer deployed to store '%s (%s)' and will be redeployed.", certificateId, store.CertificateStoreId, store.Alias),
+			)
+		}
+	}
+	selectorMode := !state.ContainerName.Null || !state.StoreQuery.Null
+	if !selectorMode && len(stores) > 0 && len(drifted) == len(stores) {
+		response.State.RemoveResource(ctx)
+		return
+	}
+
 	// Set state
 	var result = KeyfactorCertificateDeployment{
-		ID:               state.ID,
-		CertificateId:    state.CertificateId,
-		StoreId:          state.StoreId,
-		CertificateAlias: state.CertificateAlias,
-		KeyPassword:      state.KeyPassword,
-		JobParameters:    state.JobParameters,
+		ID:                    state.ID,
+		CertificateId:         state.CertificateId,
+		CertificateThumbprint: types.String{Value: certificateData.Thumbprint},
+		StoreId:               state.StoreId,
+		CertificateAlias:      state.CertificateAlias,
+		KeyPassword:           state.KeyPassword,
+		IncludePrivateKey:     state.IncludePrivateKey,
+		Overwrite:             state.Overwrite,
+		RotateInPlace:         state.RotateInPlace,
+		JobParameters:         state.JobParameters,
+		WaitForCompletion:     state.WaitForCompletion,
+		JobIds:                state.JobIds,
+		JobStatus:             jobStatus,
+		Schedule:              state.Schedule,
+		Stores:                state.Stores,
+		ContainerName:         state.ContainerName,
+		StoreQuery:            state.StoreQuery,
+		SelectedStoreIds:      state.SelectedStoreIds,
 	}
 
 	diags = response.State.Set(ctx, result)
@@ -224,11 +451,41 @@ func (r resourceKeyfactorCertificateDeployment) Read(ctx context.Context, reques
 	if response.Diagnostics.HasError() {
 		return
 	}
+
+	if len(drifted) > 0 && selectorMode {
+		// Drop the drifted stores from the selection so the next plan adds them back
+		var remaining []string
+		for _, store := range stores {
+			if !drifted[deploymentStoreKey(store)] {
+				remaining = append(remaining, store.CertificateStoreId)
+			}
+		}
+		diags = response.State.SetAttribute(ctx, path.Root("selected_store_ids"), flattenStringList(remaining))
+		response.Diagnostics.Append(diags...)
+	} else if len(drifted) > 0 {
+		// Drop the drifted store blocks so the next plan adds them back
+		var blocks, remaining []KeyfactorCertificateDeploymentStore
+		diags = state.Stores.ElementsAs(ctx, &blocks, true)
+		response.Diagnostics.Append(diags...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+		for i, block := range blocks {
+			if !drifted[deploymentStoreKey(stores[i])] {
+				remaining = append(remaining, block)
+			}
+		}
+		diags = response.State.SetAttribute(ctx, path.Root("store"), remaining)
+		response.Diagnostics.Append(diags...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+	}
 }
 
 func (r resourceKeyfactorCertificateDeployment) Update(ctx context.Context, request tfsdk.UpdateResourceRequest, response *tfsdk.UpdateResourceResponse) {
 	// Get plan values
-	var plan KeyfactorCertificate
+	var plan KeyfactorCertificateDeployment
 	diags := request.Plan.Get(ctx, &plan)
 	response.Diagnostics.Append(diags...)
 	if response.Diagnostics.HasError() {
@@ -236,26 +493,197 @@ func (r resourceKeyfactorCertificateDeployment) Update(ctx context.Context, requ
 	}
 
 	// Get current state
-	var state KeyfactorCertificate
+	var state KeyfactorCertificateDeployment
 	diags = request.State.Get(ctx, &state)
 	response.Diagnostics.Append(diags...)
 	if response.Diagnostics.HasError() {
 		return
 	}
 
-	// API Actions
+	kfClient := r.p.client
+	certificateId := plan.CertificateId.Value
+	certificateIdInt := int(certificateId)
+	previousCertificateIdInt := int(state.CertificateId.Value)
+	rotated := previousCertificateIdInt != certificateIdInt
+	ctx = tflog.SetField(ctx, "certificate_id", certificateId)
+	tflog.Info(ctx, "Update called on certificate deployment resource")
+
+	planStores, sDiags := expandDeploymentStores(ctx, plan)
+	response.Diagnostics.Append(sDiags...)
+	stateStores, sDiags := expandDeploymentStores(ctx, state)
+	response.Diagnostics.Append(sDiags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if state.KeyPassword.Null {
+		// The key password isn't known after an import, so supplying it doesn't redeploy the certificate
+		for i := range stateStores {
+			stateStores[i].PfxPassword = plan.KeyPassword.Value
+		}
+	}
+
+	// Work out which store entries were added, changed or removed
+	existing := make(map[string]api.CertificateStore)
+	for _, store := range stateStores {
+		existing[deploymentStoreKey(store)] = store
+	}
+	var deploy []api.CertificateStore
+	redeploy := make(map[string]bool)
+	for _, store := range planStores {
+		key := deploymentStoreKey(store)
+		current, found := existing[key]
+		delete(existing, key)
+		if found && !rotated && reflect.DeepEqual(current, store) {
+			continue
+		}
+		if found {
+			// The entry is already in the store and is ours to replace
+			store.Overwrite = true
+			redeploy[key] = true
+		}
+		deploy = append(deploy, store)
+	}
+	var remove []api.CertificateStore
+	for _, store := range stateStores {
+		_, removed := existing[deploymentStoreKey(store)]
+		if removed || (rotated && store.Alias == "") {
+			// Entries without an alias are keyed by thumbprint, so the previous certificate isn't overwritten in place
+			remove = append(remove, store)
+		}
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Deploying certificate %v to %v stores and removing certificate %v from %v stores", certificateId, len(deploy), previousCertificateIdInt, len(remove)))
+
+	// Deploy before removing anything so rotations don't leave the stores without a certificate
+	certificateData, err := kfClient.GetCertificateContext(&api.GetCertificateContextArgs{
+		IncludeLocations: boolToPointer(true),
+		Id:               certificateIdInt,
+	})
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Deployment read error.",
+			fmt.Sprintf("Unknown error during read of certificate '%v' for deployment: %s", certificateId, err.Error()),
+		)
+		return
+	}
+	schedule, scheduleDiags := expandDeploymentSchedule(ctx, plan)
+	response.Diagnostics.Append(scheduleDiags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	wait := plan.WaitForCompletion.Null || plan.WaitForCompletion.Value
+	// Keep tracking the previous deployment jobs unless new ones are submitted
+	jobIdsValue, jobStatusValue := state.JobIds, state.JobStatus
+	if len(deploy) > 0 {
+		jobIds, deployDiags := deployCertificateToStores(ctx, r.p, certificateIdInt, certificateData, deploy, redeploy, schedule, wait)
+		response.Diagnostics.Append(deployDiags...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+		jobIdsValue, jobStatusValue = flattenStringList(jobIds), deploymentJobStatus(wait, jobIds)
+	}
+
+	if len(remove) > 0 {
+		remove, lDiags := lookupDeploymentAliases(kfClient, previousCertificateIdInt, remove)
+		response.Diagnostics.Append(lDiags...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+		err := removeCertificateAliasFromStore(ctx, kfClient, &remove, previousCertificateIdInt)
+		if err != nil && !strings.Contains(err.Error(), "not found") {
+			response.Diagnostics.AddError(
+				"Certificate deployment error",
+				fmt.Sprintf("Unknown error during removal of certificate '%v' from stores: %s", previousCertificateIdInt, err.Error()),
+			)
+			return
+		}
+	}
 
 	// Set state
-	tflog.Error(ctx, "Update called on certificate deployment resource")
-	response.Diagnostics.AddError(
-		"Certificate deployment updates not implemented.",
-		fmt.Sprintf("Error, only create and delete actions are supported for certificate deployments."),
-	)
+	var result = KeyfactorCertificateDeployment{
+		ID:                    state.ID,
+		CertificateId:         plan.CertificateId,
+		CertificateThumbprint: types.String{Value: certificateData.Thumbprint},
+		StoreId:               plan.StoreId,
+		CertificateAlias:      plan.CertificateAlias,
+		KeyPassword:           plan.KeyPassword,
+		IncludePrivateKey:     plan.IncludePrivateKey,
+		Overwrite:             plan.Overwrite,
+		RotateInPlace:         plan.RotateInPlace,
+		JobParameters:         plan.JobParameters,
+		WaitForCompletion:     plan.WaitForCompletion,
+		JobIds:                jobIdsValue,
+		JobStatus:             jobStatusValue,
+		Schedule:              plan.Schedule,
+		Stores:                plan.Stores,
+		ContainerName:         plan.ContainerName,
+		StoreQuery:            plan.StoreQuery,
+		SelectedStoreIds:      plan.SelectedStoreIds,
+	}
+
+	diags = response.State.Set(ctx, result)
+	response.Diagnostics.Append(diags...)
 	if response.Diagnostics.HasError() {
 		return
 	}
 }
 
+func (r resourceKeyfactorCertificateDeployment) ModifyPlan(
+	ctx context.Context,
+	request tfsdk.ModifyResourcePlanRequest,
+	response *tfsdk.ModifyResourcePlanResponse,
+) {
+	if request.Plan.Raw.IsNull() {
+		// Nothing to plan on destroy
+		return
+	}
+
+	var plan KeyfactorCertificateDeployment
+	diags := request.Plan.Get(ctx, &plan)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// Resolve the store selectors so that stores joining or leaving the selection show up in the plan
+	if plan.ContainerName.Null && plan.StoreQuery.Null {
+		diags = response.Plan.SetAttribute(ctx, path.Root("selected_store_ids"), types.List{ElemType: types.StringType, Null: true})
+		response.Diagnostics.Append(diags...)
+	} else if r.p.configured && !plan.ContainerName.Unknown && !plan.StoreQuery.Unknown {
+		storeIds, err := resolveDeploymentSelector(ctx, r.p, plan)
+		if err != nil {
+			response.Diagnostics.AddError(
+				ERR_SUMMARY_CERT_STORE_READ,
+				"Unable to resolve certificate stores for deployment: "+err.Error(),
+			)
+			return
+		}
+		diags = response.Plan.SetAttribute(ctx, path.Root("selected_store_ids"), flattenStringList(storeIds))
+		response.Diagnostics.Append(diags...)
+	}
+
+	if request.State.Raw.IsNull() {
+		// Nothing to compare against on create
+		return
+	}
+	var state KeyfactorCertificateDeployment
+	diags = request.State.Get(ctx, &state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.CertificateId.Equal(state.CertificateId) {
+		// The deployed certificate doesn't change
+		diags = response.Plan.SetAttribute(ctx, path.Root("certificate_thumbprint"), state.CertificateThumbprint)
+		response.Diagnostics.Append(diags...)
+		return
+	}
+	if !plan.RotateInPlace.Value {
+		response.RequiresReplace = append(response.RequiresReplace, path.Root("certificate_id"))
+	}
+}
+
 func (r resourceKeyfactorCertificateDeployment) Delete(ctx context.Context, request tfsdk.DeleteResourceRequest, response *tfsdk.DeleteResourceResponse) {
 	var state KeyfactorCertificateDeployment
 	diags := request.State.Get(ctx, &state)
@@ -269,54 +697,41 @@ func (r resourceKeyfactorCertificateDeployment) Delete(ctx context.Context, requ
 	kfClient := r.p.client
 
 	certificateId := state.CertificateId.Value
-	//certificateIdInt := int(certificateId)
-	storeId := state.StoreId.Value
-	//storeIdInt := int(storeId)
-	certificateAlias := state.CertificateAlias.Value
-	//keyPassword := state.KeyPassword.Value
-	//hid := fmt.Sprintf("%s-%s-%s", certificateId, storeId, certificateAlias)
-
-	if certificateAlias == "" {
-		// If no alias is provided then lookup the cert ID in keyfactor and use the alias from there
-		lookupCertResp, lkErr := kfClient.GetCertificateContext(&api.GetCertificateContextArgs{Id: int(certificateId)})
-		if lkErr != nil {
-			response.Diagnostics.AddWarning(
-				"Certificate removal error.",
-				fmt.Sprintf("Error looking up certificate '%s' in Keyfactor: "+lkErr.Error(), certificateId),
-			)
-			response.State.RemoveResource(ctx)
-			return
-		}
-		certificateAlias = lookupCertResp.Thumbprint // TODO: This is not always valid alias can be non-thumbprint
+	stores, sDiags := expandDeploymentStores(ctx, state)
+	response.Diagnostics.Append(sDiags...)
+	if response.Diagnostics.HasError() {
+		return
 	}
-	ctx = tflog.SetField(ctx, "certificate_id", certificateId)
-	ctx = tflog.SetField(ctx, "certificate_store_id", storeId)
-	ctx = tflog.SetField(ctx, "certificate_alias", certificateAlias)
-	tflog.Info(ctx, "Delete called on certificate deployment resource")
 
-	// Remove certificate from store
-	var diff []api.CertificateStore
-	certStoreRequest := api.CertificateStore{
-		CertificateStoreId: storeId,
-		Alias:              certificateAlias,
+	// If no alias is provided then lookup the cert ID in keyfactor and use the alias from there
+	stores, lDiags := lookupDeploymentAliases(kfClient, int(certificateId), stores)
+	for _, d := range lDiags {
+		// The certificate is gone, so there is nothing left to remove from the stores
+		response.Diagnostics.AddWarning(d.Summary(), d.Detail())
 	}
-	diff = append(diff, certStoreRequest)
+	if lDiags.HasError() {
+		response.State.RemoveResource(ctx)
+		return
+	}
+	ctx = tflog.SetField(ctx, "certificate_id", certificateId)
+	ctx = tflog.SetField(ctx, "certificate_store_count", len(stores))
+	tflog.Info(ctx, "Delete called on certificate deployment resource")
 
 	// Remove resource from state
 	//convert int64 to int
 	certId := int(certificateId)
 
-	err := removeCertificateAliasFromStore(ctx, kfClient, &diff, certId, certificateAlias)
+	err := removeCertificateAliasFromStore(ctx, kfClient, &stores, certId)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			response.Diagnostics.AddWarning(
 				"Certificate deployment not found.",
-				fmt.Sprintf("Certificate deployment '%v' to store '%s (%s)' not found, removing from state.", certificateId, storeId, certificateAlias),
+				fmt.Sprintf("Certificate deployment '%v' not found, removing from state: %s", certificateId, err.Error()),
 			)
 		} else {
 			response.Diagnostics.AddError(
 				"Certificate deployment error",
-				fmt.Sprintf("Unknown error during removal of certificate '%s' from store '%s (%s)': "+err.Error(), certificateId, storeId, certificateAlias),
+				fmt.Sprintf("Unknown error during removal of certificate '%v' from stores: %s", certificateId, err.Error()),
 			)
 		}
 
@@ -329,51 +744,499 @@ func (r resourceKeyfactorCertificateDeployment) Delete(ctx context.Context, requ
 }
 
 func (r resourceKeyfactorCertificateDeployment) ImportState(ctx context.Context, request tfsdk.ImportResourceStateRequest, response *tfsdk.ImportResourceStateResponse) {
-	tflog.Error(ctx, "Import called on certificate deployment resource")
-	response.Diagnostics.AddError(
-		"Certificate deployment imports not implemented.",
-		fmt.Sprintf("Error, only create and delete actions are supported for certificate deployments."),
-	)
+	tflog.Info(ctx, "Import called on certificate deployment resource")
+	kfClient := r.p.client
+
+	// Import IDs are either <store_id>/<alias> or <store_id>/<certificate_id>
+	importParts := strings.SplitN(request.ID, "/", 2)
+	if len(importParts) != 2 || importParts[0] == "" || importParts[1] == "" {
+		response.Diagnostics.AddError(
+			"Certificate deployment import error.",
+			fmt.Sprintf("Invalid import ID '%s'. Expected '<certificate_store_id>/<certificate_alias>' or '<certificate_store_id>/<certificate_id>'.", request.ID),
+		)
+		return
+	}
+	storeId := importParts[0]
+	ctx = tflog.SetField(ctx, "certificate_store_id", storeId)
+
+	inv, invErr := kfClient.GetCertStoreInventory(storeId)
+	if invErr != nil {
+		response.Diagnostics.AddError(
+			"Certificate deployment import error.",
+			fmt.Sprintf("Unable to read inventory of certificate store '%s' from Keyfactor Command: %s", storeId, invErr.Error()),
+		)
+		return
+	}
+	certificateAlias, certificateId := findInventoryEntry(inv, importParts[1])
+	if certificateId == 0 {
+		response.Diagnostics.AddError(
+			"Certificate deployment import error.",
+			fmt.Sprintf("No certificate with alias or certificate ID '%s' found in the inventory of certificate store '%s'.", importParts[1], storeId),
+		)
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Found certificate %v under alias '%s' in store %s", certificateId, certificateAlias, storeId))
+
+	certificateData, err := kfClient.GetCertificateContext(&api.GetCertificateContextArgs{Id: certificateId})
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Certificate deployment import error.",
+			fmt.Sprintf("Unable to read certificate '%v' from Keyfactor Command: %s", certificateId, err.Error()),
+		)
+		return
+	}
+
+	storeType, err := response.State.Schema.AttributeTypeAtPath(tftypes.NewAttributePath().WithAttributeName("store"))
+	if err != nil {
+		response.Diagnostics.AddError("Certificate deployment import error.", "Unable to read store block type: "+err.Error())
+		return
+	}
+	scheduleType, err := response.State.Schema.AttributeTypeAtPath(tftypes.NewAttributePath().WithAttributeName("schedule"))
+	if err != nil {
+		response.Diagnostics.AddError("Certificate deployment import error.", "Unable to read schedule block type: "+err.Error())
+		return
+	}
+
+	// An import by certificate ID leaves the alias unset, like a deployment configured without one
+	alias := types.String{Null: true}
+	if certificateAlias == importParts[1] {
+		alias = types.String{Value: certificateAlias}
+	}
+
+	// Deployment options and job IDs aren't stored by Command, so they're left unset
+	var result = KeyfactorCertificateDeployment{
+		CertificateId:         types.Int64{Value: int64(certificateId)},
+		CertificateThumbprint: types.String{Value: certificateData.Thumbprint},
+		StoreId:               types.String{Value: storeId},
+		CertificateAlias:      alias,
+		KeyPassword:           types.String{Null: true},
+		IncludePrivateKey:     types.Bool{Null: true},
+		Overwrite:             types.Bool{Null: true},
+		RotateInPlace:         types.Bool{Null: true},
+		JobParameters:         types.Map{ElemType: types.StringType, Null: true},
+		WaitForCompletion:     types.Bool{Null: true},
+		JobIds:                flattenStringList(nil),
+		JobStatus:             types.String{Value: DEPLOYMENT_JOB_STATUS_COMPLETED},
+		Schedule:              types.List{ElemType: scheduleType.(types.ListType).ElemType, Elems: []attr.Value{}},
+		Stores:                types.Set{ElemType: storeType.(types.SetType).ElemType, Elems: []attr.Value{}},
+		ContainerName:         types.String{Null: true},
+		StoreQuery:            types.String{Null: true},
+		SelectedStoreIds:      flattenStringList(nil),
+	}
+	result.ID = types.String{Value: deploymentId(result, nil)}
+
+	diags := response.State.Set(ctx, result)
+	response.Diagnostics.Append(diags...)
 	if response.Diagnostics.HasError() {
 		return
 	}
 }
 
-// addCertificateToStore adds certificate certId to each of the stores configured by stores. Note that stores is a list of
-// map[string]interface{} and contains the required configuration for api.AddCertificateToStores().
-func addCertificateToStore(ctx context.Context, conn *api.Client, certificateId int, certificateAlias string, keyPassword string, storeId string, jobParams map[string]string) error {
-	var storesStruct []api.CertificateStore
+// findInventoryEntry looks up a certificate store inventory entry by alias or, when no entry has that alias, by
+// Keyfactor Command certificate ID. It returns the alias of the entry and the ID of its leaf certificate, or an empty
+// alias and 0 when nothing matches.
+func findInventoryEntry(inv *[]api.CertStoreInventory, aliasOrId string) (string, int) {
+	if inv == nil {
+		return "", 0
+	}
+	for _, entry := range *inv {
+		if entry.Name != aliasOrId {
+			continue
+		}
+		if len(entry.Ids) > 0 {
+			return entry.Name, entry.Ids[0]
+		}
+		if len(entry.Certificates) > 0 {
+			return entry.Name, entry.Certificates[0].Id
+		}
+	}
+	certificateId, err := strconv.Atoi(aliasOrId)
+	if err != nil {
+		return "", 0
+	}
+	for _, entry := range *inv {
+		for _, cert := range entry.Certificates {
+			if cert.Id == certificateId {
+				return entry.Name, certificateId
+			}
+		}
+	}
+	return "", 0
+}
 
-	storeRequest := new(api.CertificateStore)
+// expandDeploymentStores returns the certificate store entries of a deployment, from the single store attributes, the
+// stores selected by container_name and store_query, or the store blocks.
+func expandDeploymentStores(ctx context.Context, deployment KeyfactorCertificateDeployment) ([]api.CertificateStore, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var stores []api.CertificateStore
+	keyPassword := deployment.KeyPassword.Value
 
-	storeRequest.CertificateStoreId = storeId
-	storeRequest.Alias = certificateAlias
+	if !deployment.StoreId.Null {
+		var jobParams map[string]string
+		diags.Append(deployment.JobParameters.ElementsAs(ctx, &jobParams, true)...)
+		stores = append(stores, api.CertificateStore{
+			CertificateStoreId: deployment.StoreId.Value,
+			Alias:              deployment.CertificateAlias.Value,
+			IncludePrivateKey:  deployment.IncludePrivateKey.Null || deployment.IncludePrivateKey.Value,
+			Overwrite:          deployment.Overwrite.Null || deployment.Overwrite.Value,
+			PfxPassword:        keyPassword,
+			JobParameters:      jobParams,
+		})
+		return stores, diags
+	}
 
-	storeRequest.IncludePrivateKey = true //todo: make this configurable
-	storeRequest.Overwrite = true
-	storeRequest.PfxPassword = keyPassword
-	storeRequest.JobParameters = jobParams
-	storesStruct = append(storesStruct, *storeRequest)
+	if !deployment.ContainerName.Null || !deployment.StoreQuery.Null {
+		var jobParams map[string]string
+		var storeIds []string
+		diags.Append(deployment.JobParameters.ElementsAs(ctx, &jobParams, true)...)
+		diags.Append(deployment.SelectedStoreIds.ElementsAs(ctx, &storeIds, true)...)
+		for _, storeId := range storeIds {
+			stores = append(stores, api.CertificateStore{
+				CertificateStoreId: storeId,
+				Alias:              deployment.CertificateAlias.Value,
+				IncludePrivateKey:  deployment.IncludePrivateKey.Null || deployment.IncludePrivateKey.Value,
+				Overwrite:          deployment.Overwrite.Null || deployment.Overwrite.Value,
+				PfxPassword:        keyPassword,
+				JobParameters:      jobParams,
+			})
+		}
+		return stores, diags
+	}
 
-	// We want Keyfactor to immediately apply these changes.
-	tflog.Debug(ctx, "Creating immediate request to add certificate to store")
+	var blocks []KeyfactorCertificateDeploymentStore
+	diags.Append(deployment.Stores.ElementsAs(ctx, &blocks, true)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	for _, block := range blocks {
+		var jobParams map[string]string
+		diags.Append(block.JobParameters.ElementsAs(ctx, &jobParams, true)...)
+		stores = append(stores, api.CertificateStore{
+			CertificateStoreId: block.StoreId.Value,
+			Alias:              block.CertificateAlias.Value,
+			IncludePrivateKey:  block.IncludePrivateKey.Null || block.IncludePrivateKey.Value,
+			Overwrite:          block.Overwrite.Null || block.Overwrite.Value,
+			PfxPassword:        keyPassword,
+			JobParameters:      jobParams,
+		})
+	}
+	return stores, diags
+}
 
-	schedule := &api.InventorySchedule{
-		Immediate: boolToPointer(true),
+// certificateStoreSummary is the subset of a Keyfactor Command certificate store needed to resolve deployment
+// selectors and to look stores up.
+type certificateStoreSummary struct {
+	Id            string `json:"Id"`
+	ContainerId   int    `json:"ContainerId"`
+	ClientMachine string `json:"ClientMachine"`
+	StorePath     string `json:"StorePath"`
+	CertStoreType int    `json:"CertStoreType"`
+}
+
+// resolveDeploymentSelector returns the sorted IDs of the certificate stores selected by the container_name and
+// store_query of a deployment.
+func resolveDeploymentSelector(ctx context.Context, p provider, deployment KeyfactorCertificateDeployment) ([]string, error) {
+	var containerId *int
+	if deployment.ContainerName.Value != "" {
+		containers, err := p.client.GetStoreContainers()
+		if err != nil {
+			return nil, err
+		}
+		if containers != nil {
+			for _, container := range *containers {
+				if strings.EqualFold(container.Name, deployment.ContainerName.Value) {
+					containerId = container.Id
+					break
+				}
+			}
+		}
+		if containerId == nil {
+			return nil, fmt.Errorf("certificate store container '%s' not found", deployment.ContainerName.Value)
+		}
+	}
+
+	var clauses []string
+	if containerId != nil {
+		clauses = append(clauses, fmt.Sprintf("ContainerId -eq %d", *containerId))
+	}
+	if deployment.StoreQuery.Value != "" {
+		clauses = append(clauses, "("+deployment.StoreQuery.Value+")")
+	}
+	params := url.Values{}
+	params.Set("pq.queryString", strings.Join(clauses, " AND "))
+	params.Set("pq.returnLimit", strconv.Itoa(DEFAULT_QUERY_RETURN_SIZE))
+
+	var storeIds []string
+	for page := 1; ; page++ {
+		params.Set("pq.pageReturned", strconv.Itoa(page))
+		var pageResults []certificateStoreSummary
+		err := p.commandRequest(ctx, http.MethodGet, "CertificateStores", params, nil, &pageResults)
+		if err != nil {
+			return nil, err
+		}
+		for _, store := range pageResults {
+			storeIds = append(storeIds, store.Id)
+		}
+		if len(pageResults) < DEFAULT_QUERY_RETURN_SIZE {
+			break
+		}
+	}
+	sort.Strings(storeIds)
+	tflog.Debug(ctx, fmt.Sprintf("Resolved %v certificate stores for deployment", len(storeIds)))
+	return storeIds, nil
+}
+
+// expandDeploymentSchedule returns the schedule of the deployment jobs. Jobs run immediately unless a schedule block
+// is provided.
+func expandDeploymentSchedule(ctx context.Context, deployment KeyfactorCertificateDeployment) (*api.InventorySchedule, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var schedules []KeyfactorCertificateDeploymentSchedule
+	diags.Append(deployment.Schedule.ElementsAs(ctx, &schedules, true)...)
+	if diags.HasError() || len(schedules) == 0 || schedules[0].ExactlyOnce.Unknown {
+		return &api.InventorySchedule{Immediate: boolToPointer(true)}, diags
+	}
+
+	runAt, err := time.Parse(time.RFC3339, schedules[0].ExactlyOnce.Value)
+	if err != nil {
+		diags.AddError(
+			ERR_SUMMARY_DEPLOYMENT_CONFIG,
+			fmt.Sprintf("Schedule 'exactly_once' value '%s' is not a valid RFC 3339 timestamp: %s", schedules[0].ExactlyOnce.Value, err.Error()),
+		)
+		return nil, diags
+	}
+	return &api.InventorySchedule{ExactlyOnce: &api.InventoryOnce{Time: runAt.UTC().Format(time.RFC3339)}}, diags
+}
+
+// deploymentJobStatus returns the job status recorded after submitting a deployment.
+func deploymentJobStatus(waited bool, jobIds []string) types.String {
+	if waited || len(jobIds) == 0 {
+		return types.String{Value: DEPLOYMENT_JOB_STATUS_COMPLETED}
+	}
+	return types.String{Value: DEPLOYMENT_JOB_STATUS_PENDING}
+}
+
+// deploymentStoreKey identifies a certificate store entry of a deployment.
+func deploymentStoreKey(store api.CertificateStore) string {
+	return fmt.Sprintf("%s/%s", store.CertificateStoreId, store.Alias)
+}
+
+// deploymentId returns the resource ID of a deployment. Single store deployments keep the ID format used before store
+// blocks were supported.
+func deploymentId(deployment KeyfactorCertificateDeployment, stores []api.CertificateStore) string {
+	hid := fmt.Sprintf("%v-%s-%s", deployment.CertificateId.Value, deployment.StoreId.Value, deployment.CertificateAlias.Value)
+	if deployment.StoreId.Null {
+		var keys []string
+		for _, store := range stores {
+			keys = append(keys, deploymentStoreKey(store))
+		}
+		sort.Strings(keys)
+		hid = fmt.Sprintf("%v-%s", deployment.CertificateId.Value, strings.Join(keys, ","))
+	}
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(hid)))
+}
+
+// lookupDeploymentAliases fills in the alias of store entries deployed without one, using the certificate thumbprint.
+func lookupDeploymentAliases(conn *api.Client, certificateId int, stores []api.CertificateStore) ([]api.CertificateStore, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var thumbprint string
+	for i, store := range stores {
+		if store.Alias != "" {
+			continue
+		}
+		if thumbprint == "" {
+			lookupCertResp, lkErr := conn.GetCertificateContext(&api.GetCertificateContextArgs{Id: certificateId})
+			if lkErr != nil {
+				diags.AddError(
+					"Certificate removal error.",
+					fmt.Sprintf("Error looking up certificate '%v' in Keyfactor: %s", certificateId, lkErr.Error()),
+				)
+				return stores, diags
+			}
+			thumbprint = lookupCertResp.Thumbprint // TODO: This is not always valid alias can be non-thumbprint
+		}
+		stores[i].Alias = thumbprint
+	}
+	return stores, diags
+}
+
+// deployCertificateToStores adds certificate certificateId to every store that doesn't already hold it in a single job
+// run on schedule and returns the IDs of the orchestrator jobs. When wait is true it waits for the jobs to complete and
+// then checks each store's inventory in parallel as final confirmation. Stores listed in redeploy are deployed to even
+// when the certificate is already present. Failures are reported per store.
+func deployCertificateToStores(
+	ctx context.Context,
+	p provider,
+	certificateId int,
+	certObj *api.GetCertificateResponse,
+	stores []api.CertificateStore,
+	redeploy map[string]bool,
+	schedule *api.InventorySchedule,
+	wait bool,
+) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	conn := p.client
+
+	// Initial check to see if the cert is already deployed
+	var pending []api.CertificateStore
+	initial := validateDeployments(ctx, conn, stores, certObj, 1)
+	for _, store := range stores {
+		key := deploymentStoreKey(store)
+		if _, missing := initial[key]; missing || redeploy[key] {
+			pending = append(pending, store)
+			continue
+		}
+		diags.AddWarning(
+			"Duplicate deployment.",
+			fmt.Sprintf("Certificate '%v' is already deployed to '%s (%s)'", certificateId, store.CertificateStoreId, store.Alias),
+		)
+	}
+	for _, store := range pending {
+		if aErr := validateAliasAvailable(conn, store, certObj); aErr != nil {
+			diags.AddError(
+				"Certificate deployment error",
+				fmt.Sprintf("Unable to deploy certificate '%v' to store '%s (%s)': %s", certificateId, store.CertificateStoreId, store.Alias, aErr.Error()),
+			)
+		}
+	}
+	if len(pending) == 0 || diags.HasError() {
+		return nil, diags
+	}
+
+	jobIds, addErr := addCertificateToStore(ctx, conn, certificateId, pending, schedule)
+	if addErr != nil {
+		diags.AddError(
+			"Certificate deployment error",
+			fmt.Sprintf("Unknown error during deploy of certificate '%v' to %v stores: %s", certificateId, len(pending), addErr.Error()),
+		)
+		return nil, diags
+	}
+	if !wait {
+		tflog.Info(ctx, fmt.Sprintf("Not waiting for deployment jobs %v to complete", jobIds))
+		return jobIds, diags
 	}
+
+	// Without job IDs to track, fall back to polling the store inventories until the certificate shows up
+	confirmationLoops := MAX_INVENTORY_WAIT_LOOPS
+	if len(jobIds) > 0 {
+		jobs, jobErr := p.waitForOrchestratorJobs(ctx, jobIds, MAX_ORCHESTRATOR_JOB_WAIT_SECONDS*time.Second)
+		if jobErr != nil {
+			diags.AddError(
+				"Certificate deployment error",
+				fmt.Sprintf("Unknown error while waiting for deployment of certificate '%v' to complete: %s", certificateId, jobErr.Error()),
+			)
+			return jobIds, diags
+		}
+		failed, jobDiags := orchestratorJobFailures(conn, pending, jobs, certificateId)
+		diags.Append(jobDiags...)
+		var succeeded []api.CertificateStore
+		for _, store := range pending {
+			if !failed[store.CertificateStoreId] {
+				succeeded = append(succeeded, store)
+			}
+		}
+		pending = succeeded
+		confirmationLoops = MAX_DEPLOYMENT_CONFIRMATION_LOOPS
+	}
+
+	failures := validateDeployments(ctx, conn, pending, certObj, confirmationLoops)
+	for _, store := range pending {
+		if vErr, failed := failures[deploymentStoreKey(store)]; failed {
+			diags.AddError(
+				"Deployment validation error.",
+				fmt.Sprintf("Unknown error during validation of deploy of certificate '%v' to store '%s (%s)': %s", certificateId, store.CertificateStoreId, store.Alias, vErr.Error()),
+			)
+		}
+	}
+	return jobIds, diags
+}
+
+// orchestratorJobFailures reports the orchestrator jobs that did not succeed and returns the IDs of the stores whose
+// job failed. Jobs are matched to stores by client machine and store path, as job history doesn't include store IDs.
+func orchestratorJobFailures(
+	conn *api.Client,
+	stores []api.CertificateStore,
+	jobs map[string]orchestratorJobHistory,
+	certificateId int,
+) (map[string]bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	failed := make(map[string]bool)
+	storeLocations := make(map[string]string)
+	for _, job := range jobs {
+		switch job.Result {
+		case ORCHESTRATOR_JOB_RESULT_SUCCESS:
+			continue
+		case ORCHESTRATOR_JOB_RESULT_WARNING:
+			diags.AddWarning(
+				"Certificate deployment warning.",
+				fmt.Sprintf("Orchestrator job '%s' deploying certificate '%v' to '%s' (%s) completed with a warning: %s", job.JobId, certificateId, job.ClientMachine, job.StorePath, job.Message),
+			)
+			continue
+		}
+
+		diags.AddError(
+			"Certificate deployment error",
+			fmt.Sprintf("Orchestrator job '%s' deploying certificate '%v' to '%s' (%s) failed: %s", job.JobId, certificateId, job.ClientMachine, job.StorePath, job.Message),
+		)
+		if len(storeLocations) == 0 {
+			for _, store := range stores {
+				storeInfo, err := conn.GetCertificateStoreByID(store.CertificateStoreId)
+				if err != nil || storeInfo == nil {
+					continue
+				}
+				storeLocations[store.CertificateStoreId] = strings.ToLower(storeInfo.ClientMachine + "|" + storeInfo.StorePath)
+			}
+		}
+		for storeId, location := range storeLocations {
+			if location == strings.ToLower(job.ClientMachine+"|"+job.StorePath) {
+				failed[storeId] = true
+			}
+		}
+	}
+	return failed, diags
+}
+
+// addCertificateToStore adds certificate certId to each of the stores configured by stores in a single
+// api.AddCertificateToStores() job run on schedule, and returns the IDs of the orchestrator jobs that were created.
+func addCertificateToStore(ctx context.Context, conn *api.Client, certificateId int, stores []api.CertificateStore, schedule *api.InventorySchedule) ([]string, error) {
+	storesStruct := stores
+
+	tflog.Debug(ctx, "Creating request to add certificate to store")
+
 	config := &api.AddCertificateToStore{
 		CertificateId:     certificateId,
 		CertificateStores: &storesStruct,
 		InventorySchedule: schedule,
 	}
-	tflog.Debug(ctx, fmt.Sprintf("Adding certificate %v to Keyfactor store %v", certificateId, storeId))
-	_, err := conn.AddCertificateToStores(config)
+	tflog.Debug(ctx, fmt.Sprintf("Adding certificate %v to %v Keyfactor stores", certificateId, len(stores)))
+	jobIds, err := conn.AddCertificateToStores(config)
 	if err != nil {
-		tflog.Error(ctx, fmt.Sprintf("Error adding certificate %v to Keyfactor store %v: %v", certificateId, storeId, err))
-		return err
+		tflog.Error(ctx, fmt.Sprintf("Error adding certificate %v to Keyfactor stores: %v", certificateId, err))
+		return nil, err
 	}
-	tflog.Debug(ctx, fmt.Sprintf("Successfully added certificate %v to Keyfactor store %v", certificateId, storeId))
-	return nil
+	tflog.Debug(ctx, fmt.Sprintf("Successfully scheduled orchestrator jobs %v adding certificate %v to %v Keyfactor stores", jobIds, certificateId, len(stores)))
+	return jobIds, nil
+}
+
+// validateDeployments runs validateDeployment for each of the stores in parallel and returns the error of every store
+// that failed validation, keyed by deploymentStoreKey.
+func validateDeployments(ctx context.Context, conn *api.Client, stores []api.CertificateStore, certObj *api.GetCertificateResponse, maxIterations int) map[string]error {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	failures := make(map[string]error)
+	for _, store := range stores {
+		wg.Add(1)
+		go func(store api.CertificateStore) {
+			defer wg.Done()
+			err := validateDeployment(ctx, conn, store, certObj, maxIterations)
+			if err != nil {
+				mu.Lock()
+				failures[deploymentStoreKey(store)] = err
+				mu.Unlock()
+			}
+		}(store)
+	}
+	wg.Wait()
+	return failures
 }
 
 func validateUndeployment(ctx context.Context, conn *api.Client, storeId string, certificateId int, certAlias string, certObj *api.GetCertificateResponse, maxIterations int) error {
@@ -406,7 +1269,9 @@ func validateUndeployment(ctx context.Context, conn *api.Client, storeId string,
 		}
 		if deployed {
 			tflog.Debug(ctx, fmt.Sprintf("Certificate '%s'(%v) found in Keyfactor Command store '%s'(%v). Retrying in %v seconds", certObj.Thumbprint, certObj.Id, certAlias, storeId, retryDelay))
-			time.Sleep(time.Duration(retryDelay) * time.Second)
+			if err := sleepContext(ctx, time.Duration(retryDelay)*time.Second); err != nil {
+				return err
+			}
 			retryDelay = retryDelay * 2
 			if retryDelay > 60 {
 				retryDelay = 60
@@ -422,7 +1287,10 @@ func validateUndeployment(ctx context.Context, conn *api.Client, storeId string,
 	return nil
 }
 
-func validateDeployment(ctx context.Context, conn *api.Client, storeId string, certAlias string, certObj *api.GetCertificateResponse, maxIterations int) error {
+// validateDeployment polls the inventory of the store until it holds certObj.
+func validateDeployment(ctx context.Context, conn *api.Client, store api.CertificateStore, certObj *api.GetCertificateResponse, maxIterations int) error {
+	storeId := store.CertificateStoreId
+	certAlias := store.Alias
 	valid := false
 	tflog.Debug(ctx, fmt.Sprintf("Validating Keyfactor Command store %v inventory has been updated with %s", storeId, certAlias))
 	retryDelay := 2
@@ -431,29 +1299,12 @@ func validateDeployment(ctx context.Context, conn *api.Client, storeId string, c
 		if invErr != nil {
 			return invErr
 		}
-		for _, cert := range *inv {
-			if cert.Name == certAlias {
-				// Iterate through Certificates in the store and check if the certificate we're looking for is there
-				for _, iCert := range cert.Certificates {
-					if iCert.Id == certObj.Id {
-						valid = true
-						break
-					}
-				}
-			} else if certAlias == "" {
-				// if not alias is provided then just compare cert ID of the leaf node
-				if len(cert.Ids) > 0 && cert.Ids[0] == certObj.Id { //TODO: This may not be the best way to do this as a cert ID can show up multiple times in a store
-					valid = true
-					break
-				}
-			}
-			if valid {
-				break
-			}
-		}
+		valid = inventoryHasDeployment(inv, store, certObj)
 		if !valid {
 			tflog.Debug(ctx, fmt.Sprintf("Certificate %s not found in Keyfactor store %v. Retrying in %v seconds", certAlias, storeId, retryDelay))
-			time.Sleep(time.Duration(retryDelay) * time.Second)
+			if err := sleepContext(ctx, time.Duration(retryDelay)*time.Second); err != nil {
+				return err
+			}
 			retryDelay = retryDelay * 2
 			if retryDelay > 60 {
 				retryDelay = 60
@@ -468,6 +1319,85 @@ func validateDeployment(ctx context.Context, conn *api.Client, storeId string, c
 	return nil
 }
 
+// inventoryHasDeployment reports whether the store inventory inv holds certObj for store. Certificates deployed with
+// their private key are expected under the store's alias, while public only deployments are accepted under any
+// inventory entry because trust stores don't always keep the requested alias.
+func inventoryHasDeployment(inv *[]api.CertStoreInventory, store api.CertificateStore, certObj *api.GetCertificateResponse) bool {
+	if inv == nil {
+		return false
+	}
+	for _, cert := range *inv {
+		if cert.Name == store.Alias || !store.IncludePrivateKey {
+			// Iterate through Certificates in the store and check if the certificate we're looking for is there
+			for _, iCert := range cert.Certificates {
+				if iCert.Id == certObj.Id {
+					return true
+				}
+			}
+		} else if store.Alias == "" {
+			// if not alias is provided then just compare cert ID of the leaf node
+			if len(cert.Ids) > 0 && cert.Ids[0] == certObj.Id { //TODO: This may not be the best way to do this as a cert ID can show up multiple times in a store
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// findDeploymentDrift reads the latest inventory of each of the stores in parallel and returns the keys, as returned by
+// deploymentStoreKey, of the stores that no longer hold certObj.
+func findDeploymentDrift(ctx context.Context, conn *api.Client, stores []api.CertificateStore, certObj *api.GetCertificateResponse) (map[string]bool, error) {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var invErr error
+	drifted := make(map[string]bool)
+	for _, store := range stores {
+		wg.Add(1)
+		go func(store api.CertificateStore) {
+			defer wg.Done()
+			inv, err := conn.GetCertStoreInventory(store.CertificateStoreId)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				invErr = err
+				return
+			}
+			if !inventoryHasDeployment(inv, store, certObj) {
+				tflog.Debug(ctx, fmt.Sprintf("Certificate %v not found in Keyfactor store %v inventory", certObj.Id, store.CertificateStoreId))
+				drifted[deploymentStoreKey(store)] = true
+			}
+		}(store)
+	}
+	wg.Wait()
+	return drifted, invErr
+}
+
+// validateAliasAvailable returns an error when store doesn't allow overwrites and its alias already holds a different
+// certificate than certObj, since the deployment job would never succeed.
+func validateAliasAvailable(conn *api.Client, store api.CertificateStore, certObj *api.GetCertificateResponse) error {
+	if store.Overwrite || store.Alias == "" {
+		return nil
+	}
+	inv, invErr := conn.GetCertStoreInventory(store.CertificateStoreId)
+	if invErr != nil {
+		return invErr
+	}
+	if inv == nil {
+		return nil
+	}
+	for _, cert := range *inv {
+		if cert.Name != store.Alias {
+			continue
+		}
+		for _, iCert := range cert.Certificates {
+			if iCert.Id != certObj.Id {
+				return fmt.Errorf("alias '%s' in Keyfactor store %v already holds certificate '%s'(%v) and overwrite is disabled", store.Alias, store.CertificateStoreId, iCert.Thumbprint, iCert.Id)
+			}
+		}
+	}
+	return nil
+}
+
 func validateCertificatesInStore(ctx context.Context, conn *api.Client, certificateId int, storeId string, maxIterations int) error {
 	valid := false
 	tflog.Debug(ctx, fmt.Sprintf("Validating certificate %v is in Keyfactor store %v", certificateId, storeId))
@@ -501,7 +1431,9 @@ func validateCertificatesInStore(ctx context.Context, conn *api.Client, certific
 				retryDelay = 30
 			}
 			tflog.Debug(ctx, fmt.Sprintf("Certificate %v not found in Keyfactor store %v. Retrying in %v seconds", certificateId, storeId, retryDelay))
-			time.Sleep(time.Duration(retryDelay) * time.Second)
+			if err := sleepContext(ctx, time.Duration(retryDelay)*time.Second); err != nil {
+				return err
+			}
 		}
 	}
 	if !valid {
@@ -510,7 +1442,7 @@ func validateCertificatesInStore(ctx context.Context, conn *api.Client, certific
 	return nil
 }
 
-func removeCertificateAliasFromStore(ctx context.Context, conn *api.Client, certificateStores *[]api.CertificateStore, certId int, certAlias string) error {
+func removeCertificateAliasFromStore(ctx context.Context, conn *api.Client, certificateStores *[]api.CertificateStore, certId int) error {
 	// We want Keyfactor to immediately apply these changes.
 	schedule := &api.InventorySchedule{
 		Immediate: boolToPointer(true),
@@ -535,9 +1467,18 @@ func removeCertificateAliasFromStore(ctx context.Context, conn *api.Client, cert
 		return err
 	}
 
-	//iterate through stores and validate that the certificate is no longer in the store
-	for _, store := range *certificateStores {
-		validateErr := validateUndeployment(ctx, conn, store.CertificateStoreId, certId, certAlias, certificateData, 100000)
+	//validate in parallel that the certificate is no longer in any of the stores
+	var wg sync.WaitGroup
+	validateErrs := make([]error, len(*certificateStores))
+	for i, store := range *certificateStores {
+		wg.Add(1)
+		go func(i int, store api.CertificateStore) {
+			defer wg.Done()
+			validateErrs[i] = validateUndeployment(ctx, conn, store.CertificateStoreId, certId, store.Alias, certificateData, MAX_INVENTORY_WAIT_LOOPS)
+		}(i, store)
+	}
+	wg.Wait()
+	for _, validateErr := range validateErrs {
 		if validateErr != nil {
 			return validateErr
 		}
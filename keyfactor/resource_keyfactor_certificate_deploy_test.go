@@ -1,139 +1,454 @@
 package keyfactor
 
-//import (
-//	"fmt"
-//	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
-//	"os"
-//	"testing"
-//)
-//
-//type certificateDeploymentTestCase struct {
-//	certificateTestCase
-//	storeID string
-//}
-//
-//func TestAccKeyfactorCertificateDeploymentResource(t *testing.T) {
-//
-//	r := certificateDeploymentTestCase{
-//		certificateTestCase: certificateTestCase{
-//			template:     os.Getenv("KEYFACTOR_CERTIFICATE_TEMPLATE_NAME"),
-//			cn:           "terraform_test_certificate",
-//			o:            "Keyfactor Inc.",
-//			l:            "Independence",
-//			c:            "US",
-//			ou:           "Integrations Engineering",
-//			st:           "OH",
-//			ca:           fmt.Sprintf(`%s\\%s`, os.Getenv("KEYFACTOR_CERTIFICATE_CA_DOMAIN"), os.Getenv("KEYFACTOR_CERTIFICATE_CA_NAME")),
-//			ipSans:       `["192.168.0.2", "10.10.0.9"]`,
-//			dnsSans:      `["tfprovider.keyfactor.com", "terraform_test_certificate"]`,
-//			metadata:     nil,
-//			email:        "",
-//			keyPassword:  os.Getenv("KEYFACTOR_CERTIFICATE_PASSWORD"),
-//			resourceName: "keyfactor_certificate_deployment.PFXCertificate",
-//		},
-//		storeID: os.Getenv("KEYFACTOR_CERTIFICATE_STORE_ID"),
-//	}
-//
-//	// Testing PFX certificate
-//	resource.Test(t, resource.TestCase{
-//		PreCheck:                 func() { testAccPreCheck(t) },
-//		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
-//		Steps: []resource.TestStep{
-//			// Create and Read testing
-//			{
-//				//ResourceName: "",
-//				//PreConfig:    nil,
-//				//Taint:        nil,
-//				Config: testAccKeyfactorCertificateDeploymentResourcePFXConfig(r),
-//				Check: resource.ComposeAggregateTestCheckFunc(
-//					resource.TestCheckResourceAttrSet(r.resourceName, "id"),
-//					resource.TestCheckResourceAttrSet(r.resourceName, "id"),
-//					resource.TestCheckResourceAttrSet(r.resourceName, "id"),
-//					resource.TestCheckResourceAttrSet(r.resourceName, "id"),
-//				),
-//				//Destroy:                   false,
-//				//ExpectNonEmptyPlan:        false,
-//				//ExpectError:               nil,
-//				//PlanOnly:                  false,
-//				//PreventDiskCleanup:        false,
-//				//PreventPostDestroyRefresh: false,
-//				//SkipFunc:                  nil,
-//				//ImportState:               false,
-//				//ImportStateId:             "",
-//				//ImportStateIdPrefix:       "",
-//				//ImportStateIdFunc:         nil,
-//				//ImportStateCheck:          nil,
-//				//ImportStateVerify:         false,
-//				//ImportStateVerifyIgnore:   nil,
-//				//ProviderFactories:         nil,
-//				//ProtoV5ProviderFactories:  nil,
-//				//ProtoV6ProviderFactories:  nil,
-//				//ExternalProviders:         nil,
-//			},
-//			// ImportState testing
-//			//{
-//			//	ResourceName:      "scaffolding_example.test",
-//			//	ImportState:       false,
-//			//	ImportStateVerify: false,
-//			//	// This is not normally necessary, but is here because this
-//			//	// example code does not have an actual upstream service.
-//			//	// Once the Read method is able to refresh information from
-//			//	// the upstream service, this can be removed.
-//			//	ImportStateVerifyIgnore: []string{"configurable_attribute"},
-//			//},
-//			// Update and Read testing
-//			{
-//				Config: testAccKeyfactorCertificateDeploymentResourcePFXConfig(r),
-//				Check: resource.ComposeAggregateTestCheckFunc(
-//					resource.TestCheckResourceAttrSet(r.resourceName, "id"),
-//					resource.TestCheckResourceAttrSet(r.resourceName, "serial_number"),
-//					resource.TestCheckResourceAttrSet(r.resourceName, "issuer_dn"),
-//					resource.TestCheckResourceAttrSet(r.resourceName, "thumbprint"),
-//					resource.TestCheckResourceAttrSet(r.resourceName, "keyfactor_request_id"),
-//					resource.TestCheckResourceAttrSet(r.resourceName, "certificate_pem"),
-//					resource.TestCheckResourceAttrSet(r.resourceName, "certificate_chain"),
-//					resource.TestCheckResourceAttrSet(r.resourceName, "certificate_authority"),
-//					resource.TestCheckResourceAttrSet(r.resourceName, "certificate_template"),
-//					resource.TestCheckResourceAttrSet(r.resourceName, "dns_sans.#"),
-//					resource.TestCheckResourceAttrSet(r.resourceName, "certificate_authority"),
-//					resource.TestCheckResourceAttrSet(r.resourceName, "certificate_template"),
-//					resource.TestCheckResourceAttrSet(r.resourceName, "dns_sans.#"),
-//					resource.TestCheckResourceAttrSet(r.resourceName, "ip_sans.#"),
-//					resource.TestCheckResourceAttrSet(r.resourceName, "metadata.%"),
-//				),
-//			},
-//			// Delete testing automatically occurs in TestCase
-//		},
-//	})
-//}
-//
-//func testAccKeyfactorCertificateDeploymentResourcePFXConfig(t certificateDeploymentTestCase) string {
-//	output := fmt.Sprintf(`
-//resource "keyfactor_certificate" "PFXCertificate" {
-//  subject = {
-//    subject_common_name         = "%s"
-//    subject_organization        = "%s"
-//    subject_locality            = "%s"
-//    subject_country             = "%s"
-//    subject_organizational_unit = "%s"
-//    subject_state               = "%s"
-//  }
-//
-//  ip_sans  = %s
-//  dns_sans = %s
-//
-//  key_password          = "%s" # Please don't use this password in production pass in an environmental variable or something
-//  certificate_authority = "%s"
-//  certificate_template  = "%s"
-//  metadata = {
-//    "Email-Contact" = "%s" # Note metadata keys must be defined in Keyfactor
-//  }
-//}
-//resource "keyfactor_certificate_deployment" "PFXCertificateDeployment" {
-//	certificate_id = keyfactor_certificate.PFXCertificate.id
-//	certificate_store_id = "%s"
-//	certificate_alias = keyfactor_certificate.PFXCertificate.subject.subject_common_name
-//}
-//`, t.cn, t.o, t.l, t.c, t.ou, t.st, t.ipSans, t.dnsSans, t.keyPassword, t.ca, t.template, t.email, t.storeID)
-//	return output
-//}
+import (
+	"fmt"
+	"github.com/Keyfactor/keyfactor-go-client/v2/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"os"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type certificateDeploymentTestCase struct {
+	certificateTestCase
+	storeId       string
+	storeId2      string
+	containerName string
+	clientMachine string
+	alias         string
+	resourceName  string
+}
+
+func newCertificateDeploymentTestCase() certificateDeploymentTestCase {
+	return certificateDeploymentTestCase{
+		certificateTestCase: certificateTestCase{
+			template:     os.Getenv("KEYFACTOR_CERTIFICATE_TEMPLATE_NAME"),
+			cn:           "terraform_test_deployment",
+			o:            "Keyfactor Inc.",
+			l:            "Independence",
+			c:            "US",
+			ou:           "Integrations Engineering",
+			st:           "OH",
+			ca:           fmt.Sprintf(`%s\\%s`, os.Getenv("KEYFACTOR_CERTIFICATE_CA_DOMAIN"), os.Getenv("KEYFACTOR_CERTIFICATE_CA_NAME")),
+			ipSans:       `["192.168.0.2"]`,
+			dnsSans:      `["terraform_test_deployment"]`,
+			keyPassword:  os.Getenv("KEYFACTOR_CERTIFICATE_PASSWORD"),
+			resourceName: "keyfactor_certificate.PFXCertificate",
+		},
+		storeId:       os.Getenv("KEYFACTOR_DEPLOY_CERT_STOREID1"),
+		storeId2:      os.Getenv("KEYFACTOR_DEPLOY_CERT_STOREID2"),
+		containerName: os.Getenv("KEYFACTOR_CERTIFICATE_STORE_CONTAINER_NAME1"),
+		clientMachine: os.Getenv("KEYFACTOR_CERTIFICATE_STORE_CLIENT_MACHINE"),
+		alias:         "terraform_test_deployment",
+		resourceName:  "keyfactor_certificate_deployment.PFXCertificateDeployment",
+	}
+}
+
+func TestAccKeyfactorCertificateDeploymentResource(t *testing.T) {
+
+	r := newCertificateDeploymentTestCase()
+
+	// Set by the first step and used to remove the certificate out of band for the drift step
+	var certificateId string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccKeyfactorCertificateDeploymentResourceConfig(r, `
+  certificate_store_id = "`+r.storeId+`"
+  certificate_alias    = "`+r.alias+`"
+  include_private_key  = true
+  overwrite            = true
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(r.resourceName, "id"),
+					resource.TestCheckResourceAttrSet(r.resourceName, "certificate_thumbprint"),
+					resource.TestCheckResourceAttr(r.resourceName, "certificate_store_id", r.storeId),
+					resource.TestCheckResourceAttr(r.resourceName, "certificate_alias", r.alias),
+					resource.TestCheckResourceAttr(r.resourceName, "include_private_key", "true"),
+					resource.TestCheckResourceAttr(r.resourceName, "overwrite", "true"),
+					resource.TestCheckResourceAttr(r.resourceName, "job_status", DEPLOYMENT_JOB_STATUS_COMPLETED),
+					resource.TestCheckResourceAttrSet(r.resourceName, "job_ids.0"),
+					resource.TestCheckResourceAttrPair(r.resourceName, "certificate_id", r.certificateTestCase.resourceName, "certificate_id"),
+					testAccKeyfactorCertificateDeploymentAttr(r.resourceName, "certificate_id", &certificateId),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      r.resourceName,
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s/%s", r.storeId, r.alias),
+				ImportStateVerify: true,
+				// Deployment options and job IDs aren't stored by Command and the key password can't be read back
+				ImportStateVerifyIgnore: []string{"key_password", "include_private_key", "overwrite", "job_ids"},
+			},
+			// Drift testing: a certificate removed from the store outside of Terraform is deployed again
+			{
+				PreConfig: func() {
+					testAccKeyfactorCertificateDeploymentRemove(t, certificateId, r.storeId, r.alias)
+				},
+				Config: testAccKeyfactorCertificateDeploymentResourceConfig(r, `
+  certificate_store_id = "`+r.storeId+`"
+  certificate_alias    = "`+r.alias+`"
+  include_private_key  = true
+  overwrite            = true
+`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccKeyfactorCertificateDeploymentResourceConfig(r, `
+  certificate_store_id = "`+r.storeId+`"
+  certificate_alias    = "`+r.alias+`"
+  include_private_key  = true
+  overwrite            = true
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(r.resourceName, "certificate_thumbprint"),
+					resource.TestCheckResourceAttr(r.resourceName, "job_status", DEPLOYMENT_JOB_STATUS_COMPLETED),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccKeyfactorCertificateDeploymentResource_ImportByCertificateId(t *testing.T) {
+
+	r := newCertificateDeploymentTestCase()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKeyfactorCertificateDeploymentResourceConfig(r, `
+  certificate_store_id = "`+r.storeId+`"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(r.resourceName, "id"),
+					resource.TestCheckNoResourceAttr(r.resourceName, "certificate_alias"),
+				),
+			},
+			// Importing by certificate ID leaves the alias unset, so a configuration without one has no diff
+			{
+				ResourceName: r.resourceName,
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources[r.resourceName]
+					if !ok {
+						return "", fmt.Errorf("resource %s not found in state", r.resourceName)
+					}
+					return fmt.Sprintf("%s/%s", r.storeId, rs.Primary.Attributes["certificate_id"]), nil
+				},
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"key_password", "job_ids"},
+			},
+		},
+	})
+}
+
+func TestAccKeyfactorCertificateDeploymentResource_MultipleStores(t *testing.T) {
+
+	r := newCertificateDeploymentTestCase()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKeyfactorCertificateDeploymentResourceConfig(r, `
+  store {
+    certificate_store_id = "`+r.storeId+`"
+    certificate_alias    = "`+r.alias+`"
+  }
+  store {
+    certificate_store_id = "`+r.storeId2+`"
+    certificate_alias    = "`+r.alias+`"
+    include_private_key  = false
+    overwrite            = false
+  }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(r.resourceName, "id"),
+					resource.TestCheckResourceAttr(r.resourceName, "store.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(r.resourceName, "store.*", map[string]string{
+						"certificate_store_id": r.storeId,
+						"certificate_alias":    r.alias,
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(r.resourceName, "store.*", map[string]string{
+						"certificate_store_id": r.storeId2,
+						"certificate_alias":    r.alias,
+						"include_private_key":  "false",
+						"overwrite":            "false",
+					}),
+					resource.TestCheckResourceAttr(r.resourceName, "job_status", DEPLOYMENT_JOB_STATUS_COMPLETED),
+				),
+			},
+			// Removing a store block only removes the certificate from that store
+			{
+				Config: testAccKeyfactorCertificateDeploymentResourceConfig(r, `
+  store {
+    certificate_store_id = "`+r.storeId+`"
+    certificate_alias    = "`+r.alias+`"
+  }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(r.resourceName, "store.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(r.resourceName, "store.*", map[string]string{
+						"certificate_store_id": r.storeId,
+					}),
+				),
+			},
+			// 'store' blocks and 'certificate_store_id' are mutually exclusive
+			{
+				Config: testAccKeyfactorCertificateDeploymentResourceConfig(r, `
+  certificate_store_id = "`+r.storeId+`"
+  store {
+    certificate_store_id = "`+r.storeId2+`"
+  }
+`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Exactly one of 'certificate_store_id', 'store' blocks"),
+			},
+		},
+	})
+}
+
+func TestAccKeyfactorCertificateDeploymentResource_Selectors(t *testing.T) {
+
+	r := newCertificateDeploymentTestCase()
+	storeQuery := fmt.Sprintf(`ClientMachine -eq \"%s\"`, r.clientMachine)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKeyfactorCertificateDeploymentResourceConfig(r, `
+  container_name = "`+r.containerName+`"
+  store_query    = "`+storeQuery+`"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(r.resourceName, "id"),
+					resource.TestCheckResourceAttr(r.resourceName, "container_name", r.containerName),
+					resource.TestCheckResourceAttr(r.resourceName, "store_query", fmt.Sprintf(`ClientMachine -eq "%s"`, r.clientMachine)),
+					resource.TestCheckResourceAttrSet(r.resourceName, "selected_store_ids.0"),
+					resource.TestCheckResourceAttr(r.resourceName, "job_status", DEPLOYMENT_JOB_STATUS_COMPLETED),
+				),
+			},
+			// The selection is resolved on every plan, so an unchanged container doesn't produce a diff
+			{
+				Config: testAccKeyfactorCertificateDeploymentResourceConfig(r, `
+  container_name = "`+r.containerName+`"
+  store_query    = "`+storeQuery+`"
+`),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccKeyfactorCertificateDeploymentResource_Schedule(t *testing.T) {
+
+	r := newCertificateDeploymentTestCase()
+	exactlyOnce := time.Now().UTC().Add(24 * time.Hour).Truncate(time.Minute).Format(time.RFC3339)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// A schedule requires not waiting for the deployment
+			{
+				Config: testAccKeyfactorCertificateDeploymentResourceConfig(r, `
+  certificate_store_id = "`+r.storeId+`"
+  certificate_alias    = "`+r.alias+`"
+  schedule {
+    exactly_once = "`+exactlyOnce+`"
+  }
+`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("'wait_for_completion' must be false when a 'schedule' is provided"),
+			},
+			{
+				Config: testAccKeyfactorCertificateDeploymentResourceConfig(r, `
+  certificate_store_id = "`+r.storeId+`"
+  certificate_alias    = "`+r.alias+`"
+  wait_for_completion  = false
+  schedule {
+    exactly_once = "`+exactlyOnce+`"
+  }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(r.resourceName, "id"),
+					resource.TestCheckResourceAttr(r.resourceName, "schedule.0.exactly_once", exactlyOnce),
+					resource.TestCheckResourceAttr(r.resourceName, "wait_for_completion", "false"),
+					resource.TestCheckResourceAttrSet(r.resourceName, "job_ids.0"),
+					resource.TestCheckResourceAttr(r.resourceName, "job_status", DEPLOYMENT_JOB_STATUS_PENDING),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKeyfactorCertificateDeploymentResource_RotateInPlace(t *testing.T) {
+
+	r := newCertificateDeploymentTestCase()
+	r2 := r
+	r2.cn = "terraform_test_deployment_rotated"
+
+	var deploymentId string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKeyfactorCertificateDeploymentResourceConfig(r, `
+  certificate_store_id = "`+r.storeId+`"
+  certificate_alias    = "`+r.alias+`"
+  rotate_in_place      = true
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(r.resourceName, "certificate_id", r.certificateTestCase.resourceName, "certificate_id"),
+					testAccKeyfactorCertificateDeploymentAttr(r.resourceName, "id", &deploymentId),
+				),
+			},
+			// A re-enrolled certificate is deployed over the same alias without replacing the deployment, so the
+			// deployment keeps its ID
+			{
+				Config: testAccKeyfactorCertificateDeploymentResourceConfig(r2, `
+  certificate_store_id = "`+r.storeId+`"
+  certificate_alias    = "`+r.alias+`"
+  rotate_in_place      = true
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(r.resourceName, "certificate_id", r.certificateTestCase.resourceName, "certificate_id"),
+					resource.TestCheckResourceAttr(r.resourceName, "certificate_alias", r.alias),
+					resource.TestCheckResourceAttrWith(r.resourceName, "id", func(value string) error {
+						if value != deploymentId {
+							return fmt.Errorf("expected the deployment ID to stay %s, got %s", deploymentId, value)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKeyfactorCertificateDeploymentResource_AliasInUse(t *testing.T) {
+
+	r := newCertificateDeploymentTestCase()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Deploying a second certificate over an existing alias without overwrite is rejected before a job is
+			// submitted
+			{
+				Config: testAccKeyfactorCertificateDeploymentResourceConfig(r, `
+  certificate_store_id = "`+r.storeId+`"
+  certificate_alias    = "`+r.alias+`"
+`) + testAccKeyfactorCertificateDeploymentResourceOverwriteConfig(r),
+				ExpectError: regexp.MustCompile(`alias '.+' in Keyfactor store .+ already holds certificate .+ and overwrite is disabled`),
+			},
+		},
+	})
+}
+
+// testAccKeyfactorCertificateDeploymentAttr stores the value of a resource attribute for use by later test steps.
+func testAccKeyfactorCertificateDeploymentAttr(resourceName string, key string, value *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", resourceName)
+		}
+		*value = rs.Primary.Attributes[key]
+		return nil
+	}
+}
+
+// testAccKeyfactorCertificateDeploymentRemove removes a deployed certificate from a store outside of Terraform.
+func testAccKeyfactorCertificateDeploymentRemove(t *testing.T, certificateId string, storeId string, alias string) {
+	certId, err := strconv.Atoi(certificateId)
+	if err != nil {
+		t.Fatalf("invalid certificate ID '%s': %s", certificateId, err)
+	}
+	client, err := api.NewKeyfactorClient(&api.AuthConfig{
+		Hostname: os.Getenv("KEYFACTOR_HOSTNAME"),
+		Username: os.Getenv("KEYFACTOR_USERNAME"),
+		Password: os.Getenv("KEYFACTOR_PASSWORD"),
+		Domain:   os.Getenv("KEYFACTOR_DOMAIN"),
+	})
+	if err != nil {
+		t.Fatalf("unable to create Keyfactor Command client: %s", err)
+	}
+	stores := []api.CertificateStore{{CertificateStoreId: storeId, Alias: alias}}
+	_, err = client.RemoveCertificateFromStores(&api.RemoveCertificateFromStore{
+		CertificateId:     certId,
+		CertificateStores: &stores,
+		InventorySchedule: &api.InventorySchedule{Immediate: boolToPointer(true)},
+	})
+	if err != nil {
+		t.Fatalf("unable to remove certificate %v from store %s: %s", certId, storeId, err)
+	}
+	// Give the orchestrator time to run the job and update the store inventory
+	time.Sleep(2 * time.Minute)
+}
+
+func testAccKeyfactorCertificateDeploymentResourceConfig(t certificateDeploymentTestCase, deployment string) string {
+	output := fmt.Sprintf(`
+resource "keyfactor_certificate" "PFXCertificate" {
+  common_name         = "%s"
+  organization        = "%s"
+  locality            = "%s"
+  country             = "%s"
+  organizational_unit = "%s"
+  state               = "%s"
+
+  ip_sans  = %s
+  dns_sans = %s
+
+  key_password          = "%s" # Please don't use this password in production pass in an environmental variable or something
+  certificate_authority = "%s"
+  certificate_template  = "%s"
+}
+
+resource "keyfactor_certificate_deployment" "PFXCertificateDeployment" {
+  certificate_id = keyfactor_certificate.PFXCertificate.certificate_id
+  key_password   = keyfactor_certificate.PFXCertificate.key_password
+%s}
+`, t.cn, t.o, t.l, t.c, t.ou, t.st, t.ipSans, t.dnsSans, t.keyPassword, t.ca, t.template, deployment)
+	return output
+}
+
+func testAccKeyfactorCertificateDeploymentResourceOverwriteConfig(t certificateDeploymentTestCase) string {
+	output := fmt.Sprintf(`
+resource "keyfactor_certificate" "PFXCertificateOverwrite" {
+  common_name = "%s_overwrite"
+
+  key_password          = "%s"
+  certificate_authority = "%s"
+  certificate_template  = "%s"
+}
+
+resource "keyfactor_certificate_deployment" "PFXCertificateDeploymentOverwrite" {
+  certificate_id       = keyfactor_certificate.PFXCertificateOverwrite.certificate_id
+  key_password         = keyfactor_certificate.PFXCertificateOverwrite.key_password
+  certificate_store_id = "%s"
+  certificate_alias    = "%s"
+  overwrite            = false
+
+  depends_on = [keyfactor_certificate_deployment.PFXCertificateDeployment]
+}
+`, t.cn, t.keyPassword, t.ca, t.template, t.storeId, t.alias)
+	return output
+}
@@ -5,11 +5,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/Keyfactor/keyfactor-go-client/v2/api"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"log"
+	"net/http"
+	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -61,10 +67,17 @@ func (r resourceCertificateStoreType) GetSchema(_ context.Context) (tfsdk.Schema
 				//	// gives us a definitive answer.
 				//	return !d.HasChange(k)
 				//},
-				Description: "Bool that indicates the approval status of store. Unapproved stores come from store Discovery and cannot be used for certificate operations.",
+				Description: "Bool that indicates the approval status of store. Unapproved stores come from store Discovery and cannot be used for certificate operations. Set `approve_discovered` to approve a discovered store instead of creating a new one.",
 				Computed:    true,
 				//PlanModifiers:       []tfsdk.AttributePlanModifier{tfsdk.RequiresReplace()},
 			},
+			"approve_discovered": {
+				Type:     types.BoolType,
+				Optional: true,
+				Description: "When creating the store, approve the unapproved store found by a discovery job with the same `client_machine`, " +
+					"`store_path` and `store_type` and update it with this configuration, instead of creating a new store. A new store is " +
+					"created when no discovered store matches. See the `keyfactor_discovered_stores` data source. Defaults to false.",
+			},
 			"create_if_missing": {
 				Type:        types.BoolType,
 				Optional:    true,
@@ -74,7 +87,8 @@ func (r resourceCertificateStoreType) GetSchema(_ context.Context) (tfsdk.Schema
 			"properties": {
 				Type:        types.MapType{ElemType: types.StringType},
 				Optional:    true,
-				Description: "Certificate properties specific to certificate store type configured as key-value pairs. NOTE: Special properties 'ServerUsername' and 'ServerPassword' are required for some store types and should not be declared in this attribute and have their own dedicated values. See store type documentation for more information.",
+				Computed:    true,
+				Description: "Certificate properties specific to certificate store type configured as key-value pairs. Property names, required properties and the values of `Bool` and `MultipleChoice` properties are validated against the store type during plan, and when `properties` is not set it is filled with the store type defaults. Properties that are not configured are ignored when `properties` is set. NOTE: Special properties 'ServerUsername', 'ServerPassword' and 'ServerUseSsl' are required for some store types and must not be declared in this attribute, they have their own dedicated values. See store type documentation for more information.",
 				//PlanModifiers: []tfsdk.AttributePlanModifier{tfsdk.RequiresReplace()},
 			},
 			"agent_identifier": {
@@ -104,18 +118,7 @@ func (r resourceCertificateStoreType) GetSchema(_ context.Context) (tfsdk.Schema
 				Type:     types.StringType,
 				Optional: true,
 				//Computed:    true,
-				Description: "Name of the container you want to associate the certificate store with. NOTE: The container must already exist and be of the same certificate store type.",
-				//PlanModifiers: []tfsdk.AttributePlanModifier{tfsdk.RequiresReplace()},
-			},
-			"inventory_schedule": {
-				Type:     types.StringType,
-				Optional: true,
-				Description: `String indicating the schedule for inventory updates. Valid formats are:
-					"immediate" - schedules and immediate job
-					"1d" - schedules a daily job
-					"12h" - schedules a job every 12 hours
-					"30m" - schedules a job every 30 minutes
-				`,
+				Description: "Name of the container you want to associate the certificate store with. NOTE: The container must already exist, or be managed with `keyfactor_certificate_store_container`, and be of the same certificate store type.",
 				//PlanModifiers: []tfsdk.AttributePlanModifier{tfsdk.RequiresReplace()},
 			},
 			"set_new_password_allowed": {
@@ -149,6 +152,18 @@ func (r resourceCertificateStoreType) GetSchema(_ context.Context) (tfsdk.Schema
 				Description: "Indicates whether the certificate store host requires SSL. In Keyfactor Command this is the 'ServerUseSsl' field found in the store type 'Properties'. Whether this is required and what format will vary based on store type definitions, please review the store type documentation for more information.",
 			},
 		},
+		Blocks: map[string]tfsdk.Block{
+			"inventory_schedule": {
+				NestingMode: tfsdk.BlockNestingModeList,
+				MaxItems:    1,
+				Description: "Schedule of the certificate store's inventory jobs. Exactly one of `immediate`, `interval_minutes`, `daily_time`, `weekly_days` with `weekly_time` or `exactly_once` must be set. Inventory isn't scheduled if not provided.",
+				Attributes:  inventoryScheduleAttributes(false),
+			},
+			"server_username_pam": pamReferenceBlock("server_username"),
+			"server_password_pam": pamReferenceBlock("server_password"),
+			"store_password_pam":  pamReferenceBlock("store_password"),
+		},
+		Version: 1,
 	}, nil
 }
 
@@ -162,6 +177,82 @@ type resourceCertificateStore struct {
 	p provider
 }
 
+func (r resourceCertificateStore) ValidateConfig(ctx context.Context, request tfsdk.ValidateResourceConfigRequest, response *tfsdk.ValidateResourceConfigResponse) {
+	var config CertificateStore
+	diags := request.Config.Get(ctx, &config)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	response.Diagnostics.Append(validateInventorySchedule(ctx, config.InventorySchedule, path.Root("inventory_schedule"))...)
+	response.Diagnostics.Append(validateStorePamReferences(config)...)
+}
+
+func (r resourceCertificateStore) UpgradeState(_ context.Context) map[int64]tfsdk.ResourceStateUpgrader {
+	return map[int64]tfsdk.ResourceStateUpgrader{
+		0: {StateUpgrader: upgradeCertificateStoreStateV0},
+	}
+}
+
+// upgradeCertificateStoreStateV0 converts the inventory_schedule string of version 0 states to the inventory_schedule
+// block. Daily and exactly once schedules had no time, so they are left out with a warning and the next refresh reads
+// them from Keyfactor Command.
+func upgradeCertificateStoreStateV0(ctx context.Context, request tfsdk.UpgradeResourceStateRequest, response *tfsdk.UpgradeResourceStateResponse) {
+	var rawState map[string]interface{}
+	if err := json.Unmarshal(request.RawState.JSON, &rawState); err != nil {
+		response.Diagnostics.AddError(ERR_SUMMARY_CERT_STORE_READ, "Unable to read prior certificate store state: "+err.Error())
+		return
+	}
+
+	schedules := []map[string]interface{}{}
+	interval, _ := rawState["inventory_schedule"].(string)
+	switch {
+	case interval == "immediate":
+		schedules = append(schedules, map[string]interface{}{"immediate": true})
+	case strings.HasSuffix(interval, "m"):
+		if minutes, err := strconv.Atoi(strings.TrimSuffix(interval, "m")); err == nil {
+			schedules = append(schedules, map[string]interface{}{"interval_minutes": minutes})
+		}
+	case strings.HasSuffix(interval, "h"):
+		if hours, err := strconv.Atoi(strings.TrimSuffix(interval, "h")); err == nil {
+			schedules = append(schedules, map[string]interface{}{"interval_minutes": hours * 60})
+		}
+	}
+	if interval != "" && len(schedules) == 0 {
+		response.Diagnostics.AddWarning(
+			ERR_SUMMARY_INVENTORY_SCHEDULE,
+			fmt.Sprintf("The inventory schedule '%s' of certificate store '%v' has no time to convert it to an 'inventory_schedule' block. "+
+				"The schedule is read from Keyfactor Command on the next refresh, set 'daily_time' or 'exactly_once' in the 'inventory_schedule' block to keep managing it.",
+				interval, rawState["id"]),
+		)
+	}
+	rawState["inventory_schedule"] = schedules
+
+	upgradedJSON, err := json.Marshal(rawState)
+	if err != nil {
+		response.Diagnostics.AddError(ERR_SUMMARY_CERT_STORE_READ, "Unable to upgrade certificate store state: "+err.Error())
+		return
+	}
+	schema, diags := resourceCertificateStoreType{}.GetSchema(ctx)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	schemaType := schema.TerraformType(ctx)
+	upgradedState, err := (&tfprotov6.RawState{JSON: upgradedJSON}).Unmarshal(schemaType)
+	if err != nil {
+		response.Diagnostics.AddError(ERR_SUMMARY_CERT_STORE_READ, "Unable to upgrade certificate store state: "+err.Error())
+		return
+	}
+	dynamicValue, err := tfprotov6.NewDynamicValue(schemaType, upgradedState)
+	if err != nil {
+		response.Diagnostics.AddError(ERR_SUMMARY_CERT_STORE_READ, "Unable to upgrade certificate store state: "+err.Error())
+		return
+	}
+	response.DynamicValue = &dynamicValue
+}
+
 func (r resourceCertificateStore) Create(ctx context.Context, request tfsdk.CreateResourceRequest, response *tfsdk.CreateResourceResponse) {
 	if !r.p.configured {
 		response.Diagnostics.AddError(
@@ -233,12 +324,14 @@ func (r resourceCertificateStore) Create(ctx context.Context, request tfsdk.Crea
 		properties["ServerUseSsl"] = strconv.FormatBool(plan.ServerUseSsl.Value)
 	}
 
-	schedule, err := createInventorySchedule(plan.InventorySchedule.Value) // TODO: Implement inventory schedule
-	if err != nil {
-		response.Diagnostics.AddError(
-			"Invalid inventory schedule.",
-			fmt.Sprintf("Could not create inventory schedule: %s", err.Error()),
-		)
+	schedule, diags := expandInventorySchedule(ctx, plan.InventorySchedule)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	pamReferences, diags := expandStorePamReferences(ctx, r.p, plan)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
 		return
 	}
 
@@ -313,12 +406,39 @@ func (r resourceCertificateStore) Create(ctx context.Context, request tfsdk.Crea
 		AgentId:               agentId,
 		AgentAssigned:         &plan.AgentAssigned.Value,
 		ContainerName:         &plan.ContainerName.Value,
-		InventorySchedule:     schedule,
+		InventorySchedule:     schedule.clientSchedule(),
 		SetNewPasswordAllowed: &plan.SetNewPasswordAllowed.Value,
 		Password:              storePassFormatted,
 	}
 
-	createStoreResponse, err := kfClient.CreateStore(newStoreArgs)
+	// A store found by a discovery job is approved and updated instead of being created again
+	discoveredStore, err := findDiscoveredStore(ctx, r.p, plan.ClientMachine.Value, plan.StorePath.Value, csType.StoreType)
+	if err != nil {
+		response.Diagnostics.AddError(
+			ERR_SUMMARY_DISCOVERED_STORES_READ,
+			fmt.Sprintf("Could not check for a discovered certificate store at '%s/%s': %s", plan.ClientMachine.Value, plan.StorePath.Value, err.Error()),
+		)
+		return
+	}
+	if discoveredStore != nil && !plan.ApproveDiscovered.Value {
+		response.Diagnostics.AddAttributeError(
+			path.Root("approve_discovered"),
+			"Error creating certificate store",
+			fmt.Sprintf("A discovery job already found an unapproved certificate store '%s' at '%s/%s'. Set 'approve_discovered' to true to approve and manage it.",
+				discoveredStore.Id, plan.ClientMachine.Value, plan.StorePath.Value),
+		)
+		return
+	}
+	var createStoreResponse *api.CreateStoreResponse
+	if discoveredStore != nil {
+		tflog.Info(ctx, fmt.Sprintf("Approving discovered certificate store '%s'", discoveredStore.Id))
+		createStoreResponse, err = promoteDiscoveredStore(ctx, r.p, discoveredStore.Id, newStoreArgs, buildPropertiesInterface(&properties, pamReferences.properties()))
+	} else if serverPamReferences := pamReferences.properties(); len(serverPamReferences) > 0 {
+		// The client can only send literal credentials, so stores with PAM references are created directly
+		createStoreResponse, err = createStore(ctx, r.p, newStoreArgs, buildPropertiesInterface(&properties, serverPamReferences))
+	} else {
+		createStoreResponse, err = kfClient.CreateStore(newStoreArgs)
+	}
 	if err != nil {
 		response.Diagnostics.AddError(
 			"Error creating certificate store",
@@ -326,6 +446,25 @@ func (r resourceCertificateStore) Create(ctx context.Context, request tfsdk.Crea
 		)
 		return
 	}
+	if schedule.Weekly != nil {
+		err = setStoreInventorySchedule(ctx, r.p, createStoreResponse.Id, schedule)
+		if err != nil {
+			// The store exists, so it is saved to state and tainted
+			response.Diagnostics.AddError(
+				ERR_SUMMARY_INVENTORY_SCHEDULE,
+				fmt.Sprintf("Certificate store '%s' was created, but its weekly inventory schedule could not be set: %s", createStoreResponse.Id, err.Error()),
+			)
+		}
+	}
+	if pamReferences.StorePassword != nil {
+		err = setStorePassword(ctx, r.p, createStoreResponse.Id, pamReferences.StorePassword)
+		if err != nil {
+			response.Diagnostics.AddError(
+				ERR_SUMMARY_PAM_REFERENCE,
+				fmt.Sprintf("Certificate store '%s' was created, but its store password could not be set from the PAM provider: %s", createStoreResponse.Id, err.Error()),
+			)
+		}
+	}
 
 	// Set state
 	var result = CertificateStore{
@@ -338,6 +477,7 @@ func (r resourceCertificateStore) Create(ctx context.Context, request tfsdk.Crea
 		StorePath:             types.String{Value: createStoreResponse.Storepath},
 		StoreType:             plan.StoreType,
 		Approved:              types.Bool{Value: createStoreResponse.Approved},
+		ApproveDiscovered:     plan.ApproveDiscovered,
 		CreateIfMissing:       plan.CreateIfMissing,
 		Properties:            plan.Properties,
 		AgentId:               types.String{Value: createStoreResponse.AgentId},
@@ -350,6 +490,9 @@ func (r resourceCertificateStore) Create(ctx context.Context, request tfsdk.Crea
 		ServerUsername:        plan.ServerUsername,
 		ServerPassword:        plan.ServerPassword,
 		ServerUseSsl:          plan.ServerUseSsl,
+		ServerUsernamePam:     plan.ServerUsernamePam,
+		ServerPasswordPam:     plan.ServerPasswordPam,
+		StorePasswordPam:      plan.StorePasswordPam,
 		//Certificates:          types.List{ElemType: types.Int64Type, Elems: []attr.Value{}},
 	}
 
@@ -383,6 +526,7 @@ func (r resourceCertificateStore) Read(ctx context.Context, request tfsdk.ReadRe
 		return
 	}
 
+	remoteProperties := storePropertiesMap(sResp.Properties, sResp.PropertiesString)
 	var result = CertificateStore{
 		ID: types.String{Value: sResp.Id},
 		ContainerID: types.Int64{
@@ -393,18 +537,22 @@ func (r resourceCertificateStore) Read(ctx context.Context, request tfsdk.ReadRe
 		StorePath:             types.String{Value: sResp.StorePath},
 		StoreType:             state.StoreType,
 		Approved:              types.Bool{Value: sResp.Approved},
+		ApproveDiscovered:     state.ApproveDiscovered,
 		CreateIfMissing:       state.CreateIfMissing,
-		Properties:            state.Properties, //TODO: Parse this w/o special properties included
+		Properties:            flattenStoreProperties(remoteProperties, state.Properties),
 		AgentId:               types.String{Value: sResp.AgentId},
 		AgentIdentifier:       state.AgentIdentifier,
 		AgentAssigned:         types.Bool{Value: sResp.AgentAssigned},
 		ContainerName:         types.String{Value: sResp.ContainerName, Null: isNullString(sResp.ContainerName)},
-		InventorySchedule:     state.InventorySchedule, // TODO: Parse this from sResp.InventorySchedule
+		InventorySchedule:     flattenInventorySchedule(ctx, readStoreInventorySchedule(ctx, r.p, sResp.Id, sResp.InventorySchedule), state.InventorySchedule),
 		SetNewPasswordAllowed: types.Bool{Value: sResp.SetNewPasswordAllowed},
 		StorePassword:         state.StorePassword,  //TODO: Currently command doesn't return this as of 10.x
 		ServerUsername:        state.ServerUsername, //TODO: Parse this from sResp.Properties
 		ServerPassword:        state.ServerPassword, //TODO: Parse this from sResp.Properties
 		ServerUseSsl:          state.ServerUseSsl,   //TODO: Parse this from sResp.Properties
+		ServerUsernamePam:     flattenPamReference(ctx, r.p, remoteProperties, "ServerUsername", state.ServerUsernamePam),
+		ServerPasswordPam:     flattenPamReference(ctx, r.p, remoteProperties, "ServerPassword", state.ServerPasswordPam),
+		StorePasswordPam:      state.StorePasswordPam, // Command doesn't return the store password
 		//Certificates:          types.List{ElemType: types.Int64Type, Elems: []attr.Value{}},
 	}
 
@@ -442,12 +590,14 @@ func (r resourceCertificateStore) Update(ctx context.Context, request tfsdk.Upda
 		)
 		return
 	}
-	schedule, err := createInventorySchedule(plan.InventorySchedule.Value) // TODO: Implement inventory schedule
-	if err != nil {
-		response.Diagnostics.AddError(
-			"Invalid inventory schedule.",
-			fmt.Sprintf("Could not create inventory schedule: %s", err.Error()),
-		)
+	schedule, diags := expandInventorySchedule(ctx, plan.InventorySchedule)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	pamReferences, diags := expandStorePamReferences(ctx, r.p, plan)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
 		return
 	}
 
@@ -509,8 +659,7 @@ func (r resourceCertificateStore) Update(ctx context.Context, request tfsdk.Upda
 		tflog.Debug(ctx, fmt.Sprintf("Agent: %s", agentId))
 	}
 
-	properties := make(map[string]interface{})
-	var existingProperties map[string]string
+	existingProperties := make(map[string]string)
 	if plan.Properties.Elems != nil {
 		propConvErr := plan.Properties.ElementsAs(ctx, &existingProperties, false)
 		if propConvErr != nil {
@@ -521,28 +670,17 @@ func (r resourceCertificateStore) Update(ctx context.Context, request tfsdk.Upda
 			return
 		}
 	}
-	//add existing properties to properties map
-	for k, v := range existingProperties {
-		if k == "ServerUsername" || k == "ServerPassword" {
-			properties[k] = api.SpecialPropertiesSecretValue{Value: api.SecretParamValue{SecretValue: v}}
-		} else if k == "ServerUseSsl" {
-			properties[k] = api.SpecialPropertiesValue{Value: strings.ToLower(v) == "true"}
-		} else {
-			properties[k] = v
-		}
-	}
 	//Add Special Properties to properties map
 	if !plan.ServerUsername.IsNull() {
-		formattedUsername := api.SecretParamValue{SecretValue: plan.ServerUsername.Value}
-		properties["ServerUsername"] = api.SpecialPropertiesSecretValue{Value: formattedUsername}
+		existingProperties["ServerUsername"] = plan.ServerUsername.Value
 	}
 	if !plan.ServerPassword.IsNull() {
-		formattedPassword := api.SecretParamValue{SecretValue: plan.ServerPassword.Value}
-		properties["ServerPassword"] = api.SpecialPropertiesSecretValue{Value: formattedPassword}
+		existingProperties["ServerPassword"] = plan.ServerPassword.Value
 	}
 	if !plan.ServerUseSsl.IsNull() {
-		properties["ServerUseSsl"] = api.SpecialPropertiesValue{Value: plan.ServerUseSsl.Value}
+		existingProperties["ServerUseSsl"] = strconv.FormatBool(plan.ServerUseSsl.Value)
 	}
+	properties := buildPropertiesInterface(&existingProperties, pamReferences.properties())
 
 	propertiesStr, psErr := mapToEscapedJSONString(properties)
 	if psErr != nil {
@@ -566,7 +704,7 @@ func (r resourceCertificateStore) Update(ctx context.Context, request tfsdk.Upda
 		AgentId:               agentId,
 		AgentAssigned:         &plan.AgentAssigned.Value,
 		ContainerName:         &plan.ContainerName.Value,
-		InventorySchedule:     schedule,
+		InventorySchedule:     schedule.clientSchedule(),
 		SetNewPasswordAllowed: &plan.SetNewPasswordAllowed.Value,
 		Password:              storePassFormatted,
 	}
@@ -592,6 +730,26 @@ func (r resourceCertificateStore) Update(ctx context.Context, request tfsdk.Upda
 		)
 		return
 	}
+	if schedule.Weekly != nil {
+		err = setStoreInventorySchedule(ctx, r.p, updateResponse.Id, schedule)
+		if err != nil {
+			response.Diagnostics.AddError(
+				ERR_SUMMARY_INVENTORY_SCHEDULE,
+				fmt.Sprintf("Could not set the weekly inventory schedule of certificate store '%s': %s", updateResponse.Id, err.Error()),
+			)
+			return
+		}
+	}
+	if pamReferences.StorePassword != nil {
+		err = setStorePassword(ctx, r.p, updateResponse.Id, pamReferences.StorePassword)
+		if err != nil {
+			response.Diagnostics.AddError(
+				ERR_SUMMARY_PAM_REFERENCE,
+				fmt.Sprintf("Could not set the store password of certificate store '%s' from the PAM provider: %s", updateResponse.Id, err.Error()),
+			)
+			return
+		}
+	}
 
 	// Log response
 	tflog.Trace(ctx, fmt.Sprintf("UpdateStoreResponse: %v", *updateResponse))
@@ -606,6 +764,7 @@ func (r resourceCertificateStore) Update(ctx context.Context, request tfsdk.Upda
 		StorePath:             types.String{Value: updateResponse.Storepath},
 		StoreType:             plan.StoreType,
 		Approved:              types.Bool{Value: updateResponse.Approved},
+		ApproveDiscovered:     plan.ApproveDiscovered,
 		CreateIfMissing:       plan.CreateIfMissing,
 		Properties:            plan.Properties,
 		AgentId:               types.String{Value: updateResponse.AgentId},
@@ -618,6 +777,9 @@ func (r resourceCertificateStore) Update(ctx context.Context, request tfsdk.Upda
 		ServerUsername:        plan.ServerUsername,
 		ServerPassword:        plan.ServerPassword,
 		ServerUseSsl:          plan.ServerUseSsl,
+		ServerUsernamePam:     plan.ServerUsernamePam,
+		ServerPasswordPam:     plan.ServerPasswordPam,
+		StorePasswordPam:      plan.StorePasswordPam,
 	}
 
 	// Set state
@@ -628,6 +790,48 @@ func (r resourceCertificateStore) Update(ctx context.Context, request tfsdk.Upda
 	}
 }
 
+func (r resourceCertificateStore) ModifyPlan(
+	ctx context.Context,
+	request tfsdk.ModifyResourcePlanRequest,
+	response *tfsdk.ModifyResourcePlanResponse,
+) {
+	if request.Plan.Raw.IsNull() {
+		// Nothing to plan on destroy
+		return
+	}
+
+	var config CertificateStore
+	diags := request.Config.Get(ctx, &config)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Properties.Null {
+		// Keep properties known when the store type can't be looked up during plan
+		diags = response.Plan.SetAttribute(ctx, path.Root("properties"), types.Map{ElemType: types.StringType, Null: true})
+		response.Diagnostics.Append(diags...)
+	}
+	if !r.p.configured || config.StoreType.Unknown || config.Properties.Unknown {
+		return
+	}
+
+	storeType, err := r.p.client.GetCertificateStoreTypeByName(config.StoreType.Value)
+	if err != nil || storeType == nil {
+		// The store type may be created in the same apply, the API validates the properties in that case
+		tflog.Warn(ctx, fmt.Sprintf("Unable to validate properties against certificate store type '%s': %v", config.StoreType.Value, err))
+		return
+	}
+
+	properties, diags := planStoreProperties(ctx, storeType, config)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	diags = response.Plan.SetAttribute(ctx, path.Root("properties"), properties)
+	response.Diagnostics.Append(diags...)
+}
+
 func (r resourceCertificateStore) Delete(ctx context.Context, request tfsdk.DeleteResourceRequest, response *tfsdk.DeleteResourceResponse) {
 	var state CertificateStore
 	diags := request.State.Get(ctx, &state)
@@ -661,29 +865,43 @@ func (r resourceCertificateStore) Delete(ctx context.Context, request tfsdk.Dele
 }
 
 func (r resourceCertificateStore) ImportState(ctx context.Context, request tfsdk.ImportResourceStateRequest, response *tfsdk.ImportResourceStateResponse) {
-	var state CertificateStore
+	ctx = tflog.SetField(ctx, "import_id", request.ID)
+	tflog.Info(ctx, "Import called on certificate store resource")
 
-	tflog.Info(ctx, "Read called on certificate store resource")
-	certificateStoreId := state.ID.Value
-
-	tflog.SetField(ctx, "id", certificateStoreId)
+	certificateStoreId := request.ID
+	if strings.Contains(request.ID, "::") {
+		importParts := strings.Split(request.ID, "::")
+		if len(importParts) > 3 || importParts[0] == "" || importParts[1] == "" {
+			response.Diagnostics.AddError(
+				ERR_SUMMARY_CERT_STORE_READ,
+				fmt.Sprintf("Invalid import ID '%s', use the certificate store GUID or 'client_machine::store_path[::store_type]'.", request.ID),
+			)
+			return
+		}
+		storeType := ""
+		if len(importParts) == 3 {
+			storeType = importParts[2]
+		}
+		var err error
+		certificateStoreId, err = lookupCertificateStore(ctx, r.p, importParts[0], importParts[1], storeType)
+		if err != nil {
+			response.Diagnostics.AddError(
+				ERR_SUMMARY_CERT_STORE_READ,
+				fmt.Sprintf("Could not import certificate store '%s': %s", request.ID, err.Error()),
+			)
+			return
+		}
+	}
+	ctx = tflog.SetField(ctx, "id", certificateStoreId)
 
 	readResponse, err := r.p.client.GetCertificateStoreByID(certificateStoreId)
-	if err != nil {
-		response.Diagnostics.AddError(
-			ERR_SUMMARY_CERT_STORE_READ,
-			"Error reading certificate store: %s"+err.Error(),
-		)
-		return
+	if err == nil && readResponse == nil {
+		err = fmt.Errorf("certificate store not found")
 	}
-
-	password := state.StorePassword.Value
-	tflog.Trace(ctx, fmt.Sprintf("Password for store %s: %s", certificateStoreId, password))
-
 	if err != nil {
 		response.Diagnostics.AddError(
-			ERR_SUMMARY_CERTIFICATE_RESOURCE_READ,
-			fmt.Sprintf("Could not retrieve certificate '%s' from Keyfactor Command: "+err.Error(), certificateStoreId),
+			ERR_SUMMARY_CERT_STORE_READ,
+			fmt.Sprintf("Could not retrieve certificate store '%s' from Keyfactor Command: %s", certificateStoreId, err.Error()),
 		)
 		return
 	}
@@ -691,31 +909,41 @@ func (r resourceCertificateStore) ImportState(ctx context.Context, request tfsdk
 	csType, csTypeErr := r.p.client.GetCertificateStoreType(readResponse.CertStoreType)
 	if csTypeErr != nil {
 		response.Diagnostics.AddError(
-			ERR_SUMMARY_CERTIFICATE_RESOURCE_READ,
-			fmt.Sprintf("Could not retrieve certificate store type '%s' from Keyfactor Command: "+err.Error(), readResponse.CertStoreType),
+			ERR_SUMMARY_CERT_STORE_READ,
+			fmt.Sprintf("Could not retrieve certificate store type '%v' from Keyfactor Command: %s", readResponse.CertStoreType, csTypeErr.Error()),
 		)
 		return
 	}
+	remoteProperties := storePropertiesMap(readResponse.Properties, readResponse.PropertiesString)
+	emptyPamReference := types.List{ElemType: types.ObjectType{AttrTypes: pamReferenceAttrTypes}, Elems: []attr.Value{}}
 	// Set state
 	result := CertificateStore{
-		ID:              types.String{Value: state.ID.Value},
-		ContainerID:     types.Int64{Value: int64(readResponse.ContainerId)},
-		ClientMachine:   types.String{Value: readResponse.ClientMachine},
-		StorePath:       types.String{Value: readResponse.StorePath},
-		StoreType:       types.String{Value: csType.Name},
-		Approved:        types.Bool{Value: readResponse.Approved},
-		CreateIfMissing: types.Bool{Value: readResponse.CreateIfMissing},
-		//Properties:            plan.Properties,
-		AgentId:       types.String{Value: readResponse.AgentId},
-		AgentAssigned: types.Bool{Value: readResponse.AgentAssigned},
-		ContainerName: types.String{Value: readResponse.ContainerName, Null: isNullString(readResponse.ContainerName)},
-		InventorySchedule: types.String{
-			Unknown: false,
-			Null:    true,
-			Value:   fmt.Sprintf("%v", readResponse.InventorySchedule),
-		},
+		ID:                types.String{Value: readResponse.Id},
+		ContainerID:       types.Int64{Value: int64(readResponse.ContainerId)},
+		ClientMachine:     types.String{Value: readResponse.ClientMachine},
+		StorePath:         types.String{Value: readResponse.StorePath},
+		StoreType:         types.String{Value: csType.ShortName},
+		Approved:          types.Bool{Value: readResponse.Approved},
+		ApproveDiscovered: types.Bool{Null: true},
+		CreateIfMissing:   types.Bool{Value: readResponse.CreateIfMissing},
+		Properties:        flattenStoreProperties(remoteProperties, types.Map{ElemType: types.StringType, Null: true}),
+		AgentId:           types.String{Value: readResponse.AgentId},
+		AgentIdentifier:   types.String{Value: readResponse.AgentId},
+		AgentAssigned:     types.Bool{Value: readResponse.AgentAssigned},
+		ContainerName:     types.String{Value: readResponse.ContainerName, Null: isNullString(readResponse.ContainerName)},
+		InventorySchedule: flattenInventorySchedule(
+			ctx,
+			readStoreInventorySchedule(ctx, r.p, readResponse.Id, readResponse.InventorySchedule),
+			types.List{Null: true},
+		),
 		SetNewPasswordAllowed: types.Bool{Value: readResponse.SetNewPasswordAllowed},
-		//Password:              plan.StorePassword,
+		StorePassword:         types.String{Null: true},
+		ServerUsername:        types.String{Null: true},
+		ServerPassword:        types.String{Null: true},
+		ServerUseSsl:          types.Bool{Null: true},
+		ServerUsernamePam:     flattenPamReference(ctx, r.p, remoteProperties, "ServerUsername", emptyPamReference),
+		ServerPasswordPam:     flattenPamReference(ctx, r.p, remoteProperties, "ServerPassword", emptyPamReference),
+		StorePasswordPam:      emptyPamReference,
 	}
 	diags := response.State.Set(ctx, &result)
 	response.Diagnostics.Append(diags...)
@@ -724,117 +952,167 @@ func (r resourceCertificateStore) ImportState(ctx context.Context, request tfsdk
 	}
 }
 
-func createPasswordConfig(p string) *api.StorePasswordConfig {
-	password := stringToPointer(p)
-	res := &api.StorePasswordConfig{
-		Value: password,
+// lookupCertificateStore returns the GUID of the certificate store with the given client machine and store path, and
+// store type short name when it isn't empty. Empty values match every store. The error lists the candidates when more
+// than one store matches.
+func lookupCertificateStore(ctx context.Context, p provider, clientMachine string, storePath string, storeType string) (string, error) {
+	storeTypeId := 0
+	if storeType != "" {
+		csType, err := p.client.GetCertificateStoreTypeByName(storeType)
+		if err != nil || csType == nil {
+			return "", fmt.Errorf("could not retrieve certificate store type '%s': %v", storeType, err)
+		}
+		storeTypeId = csType.StoreType
 	}
 
-	return res
-}
-
-func createInventorySchedule(interval string) (*api.InventorySchedule, error) {
-	inventorySchedule := &api.InventorySchedule{}
-
-	if interval == "immediate" {
-		immediate := true
-		inventorySchedule.Immediate = &immediate
-	} else {
-		if strings.HasSuffix(interval, "m") {
-			minutes, err := strconv.Atoi(interval[:len(interval)-1])
-			if err != nil {
-				return nil, err
+	var clauses []string
+	if clientMachine != "" {
+		clauses = append(clauses, fmt.Sprintf("ClientMachine -eq \"%s\"", clientMachine))
+	}
+	if storePath != "" {
+		clauses = append(clauses, fmt.Sprintf("StorePath -eq \"%s\"", storePath))
+	}
+	if storeTypeId != 0 {
+		clauses = append(clauses, fmt.Sprintf("CertStoreType -eq %d", storeTypeId))
+	}
+	params := url.Values{}
+	if len(clauses) > 0 {
+		params.Set("pq.queryString", strings.Join(clauses, " AND "))
+	}
+	params.Set("pq.returnLimit", strconv.Itoa(DEFAULT_QUERY_RETURN_SIZE))
+
+	var candidates []certificateStoreSummary
+	for page := 1; ; page++ {
+		params.Set("pq.pageReturned", strconv.Itoa(page))
+		var pageResults []certificateStoreSummary
+		err := p.commandRequest(ctx, http.MethodGet, "CertificateStores", params, nil, &pageResults)
+		if err != nil {
+			return "", err
+		}
+		for _, store := range pageResults {
+			if clientMachine != "" && !strings.EqualFold(store.ClientMachine, clientMachine) {
+				continue
 			}
-			iv := &api.InventoryInterval{Minutes: minutes}
-			inventorySchedule.Interval = iv
-			return inventorySchedule, nil
-		}
-		if strings.HasSuffix(interval, "h") {
-			hours, err := strconv.Atoi(interval[:len(interval)-1])
-			if err != nil {
-				return nil, err
+			if storePath != "" && store.StorePath != storePath {
+				continue
 			}
-			if hours >= 24 {
-				return nil, fmt.Errorf("hours cannot be greater than or equal to 24. If specifying 24 use 'daily' instead")
+			if storeTypeId != 0 && store.CertStoreType != storeTypeId {
+				continue
 			}
-			iv := &api.InventoryInterval{Minutes: hours * 60}
-			inventorySchedule.Interval = iv
-			return inventorySchedule, nil
+			candidates = append(candidates, store)
 		}
-		if strings.HasSuffix(interval, "d") {
-			return nil, fmt.Errorf("days not supported please use 'm', 'daily' or 'exactly_once'")
-
-		}
-		if interval == "daily" {
-			daily := &api.InventoryDaily{Time: interval}
-			inventorySchedule.Daily = daily
-			return inventorySchedule, nil
-		}
-		if interval == "exactly_once" {
-			once := &api.InventoryOnce{Time: interval}
-			inventorySchedule.ExactlyOnce = once
-			return inventorySchedule, nil
+		if len(pageResults) < DEFAULT_QUERY_RETURN_SIZE {
+			break
 		}
 	}
 
-	return inventorySchedule, nil
+	switch len(candidates) {
+	case 0:
+		return "", fmt.Errorf("no certificate store matches client machine '%s', store path '%s' and store type '%s'", clientMachine, storePath, storeType)
+	case 1:
+		return candidates[0].Id, nil
+	}
+	var candidateIds []string
+	for _, candidate := range candidates {
+		candidateIds = append(candidateIds, fmt.Sprintf("%s (%s::%s)", candidate.Id, candidate.ClientMachine, candidate.StorePath))
+	}
+	sort.Strings(candidateIds)
+	return "", fmt.Errorf("%v certificate stores match client machine '%s', store path '%s' and store type '%s', narrow the lookup down or use one of their GUIDs: %s", len(candidates), clientMachine, storePath, storeType, strings.Join(candidateIds, ", "))
 }
 
-func parseInventorySchedule(schedule *api.InventorySchedule) string {
-	if schedule.Immediate != nil {
-		return "immediate"
+// promoteDiscoveredStore approves a discovered certificate store and updates it with the configuration of a new store.
+// properties are in the shape the update call expects.
+func promoteDiscoveredStore(ctx context.Context, p provider, storeId string, args *api.CreateStoreFctArgs, properties map[string]interface{}) (*api.CreateStoreResponse, error) {
+	err := approveDiscoveredStore(ctx, p, storeId, *args.ContainerId)
+	if err != nil {
+		return nil, fmt.Errorf("could not approve discovered certificate store '%s': %s", storeId, err.Error())
 	}
-	if schedule.Interval != nil {
-		return fmt.Sprintf("%vm", schedule.Interval.Minutes)
+
+	propertiesStr, err := mapToEscapedJSONString(properties)
+	if err != nil {
+		return nil, err
 	}
-	if schedule.Daily != nil {
-		return fmt.Sprintf("Daily at %s", schedule.Daily.Time)
+	updateResponse, err := p.client.UpdateStore(&api.UpdateStoreFctArgs{
+		Id:                    storeId,
+		ContainerId:           args.ContainerId,
+		ClientMachine:         args.ClientMachine,
+		StorePath:             args.StorePath,
+		CertStoreType:         args.CertStoreType,
+		Approved:              boolToPointer(true),
+		CreateIfMissing:       args.CreateIfMissing,
+		Properties:            properties,
+		PropertiesString:      propertiesStr,
+		AgentId:               args.AgentId,
+		AgentAssigned:         args.AgentAssigned,
+		ContainerName:         args.ContainerName,
+		InventorySchedule:     args.InventorySchedule,
+		SetNewPasswordAllowed: args.SetNewPasswordAllowed,
+		Password:              args.Password,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("discovered certificate store '%s' was approved, but could not be updated: %s", storeId, err.Error())
 	}
-	if schedule.ExactlyOnce != nil {
-		return fmt.Sprintf("Exactly once at %s", schedule.ExactlyOnce.Time)
+	return &updateResponse.CreateStoreResponse, nil
+}
+
+// createStore creates a certificate store with properties built by buildPropertiesInterface, for properties the client
+// can't send, such as PAM references.
+func createStore(ctx context.Context, p provider, args *api.CreateStoreFctArgs, properties map[string]interface{}) (*api.CreateStoreResponse, error) {
+	propertiesStr, err := mapToEscapedJSONString(properties)
+	if err != nil {
+		return nil, err
+	}
+	body := map[string]interface{}{
+		"ContainerId":           args.ContainerId,
+		"ClientMachine":         args.ClientMachine,
+		"StorePath":             args.StorePath,
+		"CertStoreType":         args.CertStoreType,
+		"Approved":              args.Approved,
+		"CreateIfMissing":       args.CreateIfMissing,
+		"Properties":            propertiesStr,
+		"AgentId":               args.AgentId,
+		"AgentAssigned":         args.AgentAssigned,
+		"InventorySchedule":     args.InventorySchedule,
+		"SetNewPasswordAllowed": args.SetNewPasswordAllowed,
+		"Password":              args.Password,
 	}
+	var createResponse api.CreateStoreResponse
+	err = p.commandRequest(ctx, http.MethodPost, "CertificateStores", nil, body, &createResponse)
+	if err != nil {
+		return nil, err
+	}
+	return &createResponse, nil
+}
 
-	return ""
+func createPasswordConfig(p string) *api.StorePasswordConfig {
+	password := stringToPointer(p)
+	res := &api.StorePasswordConfig{
+		Value: password,
+	}
+
+	return res
 }
 
-func buildPropertiesInterface(properties *map[string]string) map[string]interface{} {
+func buildPropertiesInterface(properties *map[string]string, pamReferences map[string]*storePamReference) map[string]interface{} {
 	// Create temporary array of interfaces
-	// When updating a property in Keyfactor, API expects {"key": {"value": "key-value"}} - Build this interface
+	// When updating a property in Keyfactor, API expects {"key": {"value": "key-value"}} for the special properties -
+	// Build this interface
 	propertiesInterface := make(map[string]interface{})
 
-	creds := CertificateStoreCredential{
-		ServerUsername: struct {
-			Value struct {
-				SecretValue string `json:"SecretValue"`
-			} `json:"value"`
-		}{},
-		ServerPassword: struct {
-			Value struct {
-				SecretValue string `json:"SecretValue"`
-			} `json:"value"`
-		}{},
-		ServerUseSsl: struct {
-			Value string `json:"value"`
-		}{},
-	}
-
 	for key, value := range *properties {
-		if key == "ServerUsername" || key == "ServerPassword" || key == "Password" {
-			if key == "ServerUsername" {
-				creds.ServerUsername.Value.SecretValue = value
-				// add to propertiesInterface as JSON string
-				//jsonBytes, _ := json.Marshal(creds.ServerUsername)
-				propertiesInterface[key] = creds.ServerUsername
-			}
-			if key == "ServerPassword" || key == "Password" {
-				creds.ServerPassword.Value.SecretValue = value
-				//jsonBytes, _ := json.Marshal(creds.ServerPassword)
-				propertiesInterface[key] = creds.ServerPassword
-			}
-		} else {
-			propertiesInterface[key] = value // Create {"<key>": {"value": "key-value"}} interface
+		switch key {
+		case "ServerUsername", "ServerPassword", "Password":
+			propertiesInterface[key] = api.SpecialPropertiesSecretValue{Value: api.SecretParamValue{SecretValue: value}}
+		case "ServerUseSsl":
+			propertiesInterface[key] = api.SpecialPropertiesValue{Value: strings.ToLower(value) == "true"}
+		default:
+			propertiesInterface[key] = value
 		}
 	}
+	// Credentials read from a PAM provider are sent as {"key": {"value": {"Provider": <id>, "Parameters": {...}}}}
+	for key, reference := range pamReferences {
+		propertiesInterface[key] = storePamPropertyValue{Value: reference}
+	}
 	return propertiesInterface
 }
 
@@ -850,3 +1128,205 @@ func mapToEscapedJSONString(m map[string]interface{}) (string, error) {
 
 	return escapedString, nil
 }
+
+// isSpecialStoreProperty reports whether a store property is set through its dedicated attribute rather than through
+// properties.
+func isSpecialStoreProperty(name string) bool {
+	return name == "ServerUsername" || name == "ServerPassword" || name == "ServerUseSsl"
+}
+
+// planStoreProperties validates the configured properties of a certificate store against the definitions of its store
+// type and returns them with the defaults of the properties that are not configured.
+func planStoreProperties(ctx context.Context, storeType *api.CertificateStoreType, config CertificateStore) (types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	configured := map[string]types.String{}
+	if !config.Properties.Null {
+		diags.Append(config.Properties.ElementsAs(ctx, &configured, false)...)
+		if diags.HasError() {
+			return config.Properties, diags
+		}
+	}
+
+	definitions := map[string]api.StoreTypePropertyDefinition{}
+	var names []string
+	if storeType.Properties != nil {
+		for _, definition := range *storeType.Properties {
+			definitions[definition.Name] = definition
+			if !isSpecialStoreProperty(definition.Name) {
+				names = append(names, definition.Name)
+			}
+		}
+	}
+	sort.Strings(names)
+
+	for name, value := range configured {
+		attributePath := path.Root("properties").AtMapKey(name)
+		if isSpecialStoreProperty(name) {
+			diags.AddAttributeError(
+				attributePath,
+				"Invalid certificate store property.",
+				fmt.Sprintf("'%s' must be set with the 'server_username', 'server_password' or 'server_use_ssl' attribute instead of 'properties'.", name),
+			)
+			continue
+		}
+		definition, ok := definitions[name]
+		if !ok {
+			diags.AddAttributeError(
+				attributePath,
+				"Invalid certificate store property.",
+				fmt.Sprintf("Certificate store type '%s' has no property '%s'. Valid properties are: %s.", storeType.ShortName, name, strings.Join(names, ", ")),
+			)
+			continue
+		}
+		if value.Unknown || value.Null {
+			continue
+		}
+		switch definition.Type {
+		case STORE_TYPE_PROPERTY_BOOL:
+			if _, err := terraformBoolToGoBool(value.Value); err != nil {
+				diags.AddAttributeError(
+					attributePath,
+					"Invalid certificate store property.",
+					fmt.Sprintf("Property '%s' is a Bool and must be 'true' or 'false', got '%s'.", name, value.Value),
+				)
+			}
+		case STORE_TYPE_PROPERTY_MULTIPLE_CHOICE:
+			choices := storeTypePropertyChoices(definition)
+			valid := len(choices) == 0
+			for _, choice := range choices {
+				if choice == value.Value {
+					valid = true
+					break
+				}
+			}
+			if !valid {
+				diags.AddAttributeError(
+					attributePath,
+					"Invalid certificate store property.",
+					fmt.Sprintf("'%s' is not an allowed value of property '%s'. Allowed values are: %s.", value.Value, name, strings.Join(choices, ", ")),
+				)
+			}
+		}
+	}
+
+	planned := types.Map{ElemType: types.StringType, Elems: map[string]attr.Value{}}
+	for name, value := range configured {
+		planned.Elems[name] = value
+	}
+	for _, definition := range definitions {
+		if isSpecialStoreProperty(definition.Name) {
+			continue
+		}
+		if _, ok := configured[definition.Name]; ok {
+			continue
+		}
+		if propertyDefault := storeTypePropertyDefaultValue(definition); propertyDefault != "" && definition.Type != STORE_TYPE_PROPERTY_SECRET {
+			// Terraform only accepts a computed value when the map isn't configured. Otherwise Command applies the
+			// default and Read leaves out properties that aren't configured.
+			if config.Properties.Null {
+				planned.Elems[definition.Name] = types.String{Value: propertyDefault}
+			}
+			continue
+		}
+		if !definition.Required {
+			continue
+		}
+		if definition.DependsOn != "" {
+			// Properties that depend on a Bool property are only required when it is enabled
+			dependency, ok := planned.Elems[definition.DependsOn].(types.String)
+			if !ok || dependency.Unknown || strings.ToLower(dependency.Value) != "true" {
+				continue
+			}
+		}
+		diags.AddAttributeError(
+			path.Root("properties"),
+			"Missing required certificate store property.",
+			fmt.Sprintf("Certificate store type '%s' requires property '%s' (%s).", storeType.ShortName, definition.Name, definition.DisplayName),
+		)
+	}
+
+	if definition, ok := definitions["ServerUsername"]; ok && definition.Required && config.ServerUsername.Null && len(config.ServerUsernamePam.Elems) == 0 {
+		diags.AddAttributeError(
+			path.Root("server_username"),
+			"Missing required certificate store property.",
+			fmt.Sprintf("Certificate store type '%s' requires 'server_username' or 'server_username_pam'.", storeType.ShortName),
+		)
+	}
+	if definition, ok := definitions["ServerPassword"]; ok && definition.Required && config.ServerPassword.Null && len(config.ServerPasswordPam.Elems) == 0 {
+		diags.AddAttributeError(
+			path.Root("server_password"),
+			"Missing required certificate store property.",
+			fmt.Sprintf("Certificate store type '%s' requires 'server_password' or 'server_password_pam'.", storeType.ShortName),
+		)
+	}
+
+	if config.Properties.Null && len(planned.Elems) == 0 {
+		planned.Null = true
+	}
+	return planned, diags
+}
+
+// storePropertiesMap returns the properties of a certificate store read from Keyfactor Command, parsing the properties
+// string when the client didn't return them as a map.
+func storePropertiesMap(remote map[string]interface{}, propertiesString string) map[string]interface{} {
+	if remote == nil && propertiesString != "" {
+		unescapedJSON, err := unescapeJSON(propertiesString)
+		if err == nil {
+			_ = json.Unmarshal(unescapedJSON, &remote)
+		}
+	}
+	return remote
+}
+
+// flattenStoreProperties returns the properties of a certificate store read from Keyfactor Command, without the
+// properties that have dedicated attributes. When prior isn't null only its properties are returned. Values that can't
+// be compared, such as secrets, and values that only differ in case are kept from prior.
+func flattenStoreProperties(remote map[string]interface{}, prior types.Map) types.Map {
+	if remote == nil {
+		return prior
+	}
+
+	result := types.Map{ElemType: types.StringType, Elems: map[string]attr.Value{}}
+	for name, value := range remote {
+		if isSpecialStoreProperty(name) {
+			continue
+		}
+		priorValue, inPrior := prior.Elems[name].(types.String)
+		if !prior.Null && !inPrior {
+			// Properties that aren't in the configuration, like store type defaults, would be a perpetual diff
+			continue
+		}
+		if wrapped, ok := value.(map[string]interface{}); ok {
+			value = wrapped["value"]
+		}
+		var flattened string
+		switch v := value.(type) {
+		case string:
+			flattened = v
+		case bool:
+			flattened = strconv.FormatBool(v)
+		case float64:
+			flattened = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			if inPrior {
+				result.Elems[name] = priorValue
+			}
+			continue
+		}
+		if inPrior && strings.EqualFold(priorValue.Value, flattened) {
+			result.Elems[name] = priorValue
+			continue
+		}
+		if !inPrior && flattened == "" {
+			// Command keeps empty values for optional properties that were never set
+			continue
+		}
+		result.Elems[name] = types.String{Value: flattened}
+	}
+
+	if prior.Null && len(result.Elems) == 0 {
+		result.Null = true
+	}
+	return result
+}
@@ -0,0 +1,342 @@
+package keyfactor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Keyfactor/keyfactor-go-client/v2/api"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// certificateStoreContainerRequest is the body of the Keyfactor Command certificate store container create and update
+// requests, which the client doesn't support.
+type certificateStoreContainerRequest struct {
+	Id                 int                     `json:"Id,omitempty"`
+	Name               string                  `json:"Name"`
+	OverwriteSchedules bool                    `json:"OverwriteSchedules"`
+	Schedule           *storeInventorySchedule `json:"Schedule"`
+	CertStoreType      int                     `json:"CertStoreType"`
+}
+
+type resourceCertificateStoreContainerType struct{}
+
+func (r resourceCertificateStoreContainerType) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:          types.Int64Type,
+				Computed:      true,
+				Description:   "Keyfactor Command integer ID of the certificate store container.",
+				PlanModifiers: []tfsdk.AttributePlanModifier{tfsdk.UseStateForUnknown()},
+			},
+			"name": {
+				Type:        types.StringType,
+				Required:    true,
+				Description: "Name of the certificate store container, as used by `keyfactor_certificate_store.container_name`.",
+			},
+			"store_type": {
+				Type:          types.StringType,
+				Required:      true,
+				Description:   "Short name of the certificate store type of the stores in the container.",
+				PlanModifiers: []tfsdk.AttributePlanModifier{tfsdk.RequiresReplace()},
+			},
+			"overwrite_schedules": {
+				Type:          types.BoolType,
+				Optional:      true,
+				Computed:      true,
+				Description:   "Whether the inventory schedule of the container replaces the inventory schedules of the stores in it. Defaults to `false`.",
+				PlanModifiers: []tfsdk.AttributePlanModifier{defaultValue(types.Bool{Value: false})},
+			},
+		},
+		Blocks: map[string]tfsdk.Block{
+			"inventory_schedule": {
+				NestingMode: tfsdk.BlockNestingModeList,
+				MaxItems:    1,
+				Description: "Inventory schedule of the stores in the container. Exactly one of `immediate`, `interval_minutes`, `daily_time`, `weekly_days` with `weekly_time` or `exactly_once` must be set.",
+				Attributes:  inventoryScheduleAttributes(false),
+			},
+		},
+	}, nil
+}
+
+func (r resourceCertificateStoreContainerType) NewResource(_ context.Context, p tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	return resourceCertificateStoreContainer{
+		p: *(p.(*provider)),
+	}, nil
+}
+
+type resourceCertificateStoreContainer struct {
+	p provider
+}
+
+func (r resourceCertificateStoreContainer) ValidateConfig(ctx context.Context, request tfsdk.ValidateResourceConfigRequest, response *tfsdk.ValidateResourceConfigResponse) {
+	var config CertificateStoreContainer
+	diags := request.Config.Get(ctx, &config)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	response.Diagnostics.Append(validateInventorySchedule(ctx, config.InventorySchedule, path.Root("inventory_schedule"))...)
+}
+
+func (r resourceCertificateStoreContainer) Create(ctx context.Context, request tfsdk.CreateResourceRequest, response *tfsdk.CreateResourceResponse) {
+	if !r.p.configured {
+		response.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. This leads to weird stuff happening, so we'd prefer if you didn't do that. Thanks!",
+		)
+		return
+	}
+
+	var plan CertificateStoreContainer
+	diags := request.Plan.Get(ctx, &plan)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "name", plan.Name.Value)
+	tflog.Info(ctx, "Create called on certificate store container resource")
+
+	body, diags := expandCertificateStoreContainer(ctx, r.p, plan)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	var created struct {
+		Id int `json:"Id"`
+	}
+	err := r.p.commandRequest(ctx, http.MethodPost, "CertificateStoreContainers", nil, body, &created)
+	if err != nil {
+		response.Diagnostics.AddError(
+			ERR_SUMMARY_STORE_CONTAINER_CREATE,
+			fmt.Sprintf("Could not create certificate store container '%s' on Keyfactor Command: %s", plan.Name.Value, err.Error()),
+		)
+		return
+	}
+
+	result := plan
+	result.ID = types.Int64{Value: int64(created.Id)}
+	diags = response.State.Set(ctx, result)
+	response.Diagnostics.Append(diags...)
+}
+
+func (r resourceCertificateStoreContainer) Read(ctx context.Context, request tfsdk.ReadResourceRequest, response *tfsdk.ReadResourceResponse) {
+	var state CertificateStoreContainer
+	diags := request.State.Get(ctx, &state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "id", state.ID.Value)
+	tflog.Info(ctx, "Read called on certificate store container resource")
+
+	container, err := r.p.client.GetStoreContainer(int(state.ID.Value))
+	if (err != nil && strings.Contains(strings.ToLower(err.Error()), "not found")) || (err == nil && container == nil) {
+		response.Diagnostics.AddWarning(
+			ERR_SUMMARY_STORE_CONTAINER_READ,
+			fmt.Sprintf("Certificate store container '%v' no longer exists on Keyfactor Command and will be recreated.", state.ID.Value),
+		)
+		response.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		response.Diagnostics.AddError(
+			ERR_SUMMARY_STORE_CONTAINER_READ,
+			fmt.Sprintf("Could not read certificate store container '%v' from Keyfactor Command: %s", state.ID.Value, err.Error()),
+		)
+		return
+	}
+
+	result, diags := flattenCertificateStoreContainer(ctx, r.p, container, state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	diags = response.State.Set(ctx, result)
+	response.Diagnostics.Append(diags...)
+}
+
+func (r resourceCertificateStoreContainer) Update(ctx context.Context, request tfsdk.UpdateResourceRequest, response *tfsdk.UpdateResourceResponse) {
+	var plan CertificateStoreContainer
+	diags := request.Plan.Get(ctx, &plan)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	var state CertificateStoreContainer
+	diags = request.State.Get(ctx, &state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "id", state.ID.Value)
+	tflog.Info(ctx, "Update called on certificate store container resource")
+
+	body, diags := expandCertificateStoreContainer(ctx, r.p, plan)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	body.Id = int(state.ID.Value)
+
+	err := r.p.commandRequest(ctx, http.MethodPut, "CertificateStoreContainers", nil, body, nil)
+	if err != nil {
+		response.Diagnostics.AddError(
+			ERR_SUMMARY_STORE_CONTAINER_UPDATE,
+			fmt.Sprintf("Could not update certificate store container '%s' on Keyfactor Command: %s", plan.Name.Value, err.Error()),
+		)
+		return
+	}
+
+	result := plan
+	result.ID = state.ID
+	diags = response.State.Set(ctx, result)
+	response.Diagnostics.Append(diags...)
+}
+
+func (r resourceCertificateStoreContainer) Delete(ctx context.Context, request tfsdk.DeleteResourceRequest, response *tfsdk.DeleteResourceResponse) {
+	var state CertificateStoreContainer
+	diags := request.State.Get(ctx, &state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "id", state.ID.Value)
+	tflog.Info(ctx, "Delete called on certificate store container resource")
+
+	err := r.p.commandRequest(ctx, http.MethodDelete, "CertificateStoreContainers/"+strconv.FormatInt(state.ID.Value, 10), nil, nil, nil)
+	if err != nil {
+		response.Diagnostics.AddError(
+			ERR_SUMMARY_STORE_CONTAINER_DELETE,
+			fmt.Sprintf("Could not delete certificate store container '%s' from Keyfactor Command: %s", state.Name.Value, err.Error()),
+		)
+		return
+	}
+
+	response.State.RemoveResource(ctx)
+}
+
+func (r resourceCertificateStoreContainer) ImportState(ctx context.Context, request tfsdk.ImportResourceStateRequest, response *tfsdk.ImportResourceStateResponse) {
+	ctx = tflog.SetField(ctx, "import_id", request.ID)
+	tflog.Info(ctx, "Import called on certificate store container resource")
+
+	container, err := lookupStoreContainer(r.p.client, request.ID)
+	if err != nil {
+		response.Diagnostics.AddError(
+			ERR_SUMMARY_STORE_CONTAINER_READ,
+			fmt.Sprintf("Could not import certificate store container '%s' from Keyfactor Command: %s", request.ID, err.Error()),
+		)
+		return
+	}
+
+	result, diags := flattenCertificateStoreContainer(ctx, r.p, container, CertificateStoreContainer{
+		InventorySchedule: types.List{ElemType: types.ObjectType{AttrTypes: inventoryScheduleAttrTypes}, Null: true},
+	})
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	diags = response.State.Set(ctx, result)
+	response.Diagnostics.Append(diags...)
+}
+
+// lookupStoreContainer looks a certificate store container up by its integer ID, or by its name otherwise.
+func lookupStoreContainer(kfClient *api.Client, identifier string) (*api.CertStoreContainer, error) {
+	var container *api.CertStoreContainer
+	var err error
+	if id, convErr := strconv.Atoi(identifier); convErr == nil {
+		container, err = kfClient.GetStoreContainer(id)
+	} else {
+		container, err = kfClient.GetStoreContainer(identifier)
+	}
+	if err == nil && (container == nil || container.Id == nil) {
+		err = fmt.Errorf("certificate store container '%s' not found", identifier)
+	}
+	return container, err
+}
+
+// expandCertificateStoreContainer converts a certificate store container resource to the Keyfactor Command model.
+func expandCertificateStoreContainer(ctx context.Context, p provider, plan CertificateStoreContainer) (*certificateStoreContainerRequest, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	storeType, err := p.client.GetCertificateStoreTypeByName(plan.StoreType.Value)
+	if err != nil || storeType == nil {
+		diags.AddAttributeError(
+			path.Root("store_type"),
+			ERR_SUMMARY_STORE_CONTAINER_CONFIG,
+			fmt.Sprintf("Could not retrieve certificate store type '%s' from Keyfactor Command: %v", plan.StoreType.Value, err),
+		)
+		return nil, diags
+	}
+
+	schedule, diags := expandInventorySchedule(ctx, plan.InventorySchedule)
+	if diags.HasError() {
+		return nil, diags
+	}
+	if len(plan.InventorySchedule.Elems) == 0 {
+		schedule = nil
+	}
+
+	return &certificateStoreContainerRequest{
+		Name:               plan.Name.Value,
+		OverwriteSchedules: plan.OverwriteSchedules.Value,
+		Schedule:           schedule,
+		CertStoreType:      storeType.StoreType,
+	}, diags
+}
+
+// flattenCertificateStoreContainer converts a certificate store container read from Keyfactor Command to the resource
+// model. The store type is only looked up when it isn't known from prior.
+func flattenCertificateStoreContainer(ctx context.Context, p provider, container *api.CertStoreContainer, prior CertificateStoreContainer) (CertificateStoreContainer, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	storeType := prior.StoreType
+	if storeType.Value == "" {
+		remoteType, err := p.client.GetCertificateStoreTypeById(container.CertStoreType)
+		if err != nil {
+			diags.AddError(
+				ERR_SUMMARY_STORE_CONTAINER_READ,
+				fmt.Sprintf("Could not retrieve certificate store type '%v' of certificate store container '%s' from Keyfactor Command: %s", container.CertStoreType, container.Name, err.Error()),
+			)
+			return prior, diags
+		}
+		storeType = types.String{Value: remoteType.ShortName}
+	}
+
+	return CertificateStoreContainer{
+		ID:                 types.Int64{Value: int64(*container.Id)},
+		Name:               types.String{Value: container.Name},
+		StoreType:          storeType,
+		OverwriteSchedules: types.Bool{Value: container.OverwriteSchedules},
+		InventorySchedule:  flattenInventorySchedule(ctx, parseContainerSchedule(ctx, container.Schedule), prior.InventorySchedule),
+	}, diags
+}
+
+// parseContainerSchedule parses the inventory schedule of a certificate store container, which Keyfactor Command returns
+// as a JSON string.
+func parseContainerSchedule(ctx context.Context, schedule string) *storeInventorySchedule {
+	if schedule == "" {
+		return nil
+	}
+	var result storeInventorySchedule
+	if err := json.Unmarshal([]byte(schedule), &result); err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Unable to parse certificate store container schedule '%s': %s", schedule, err.Error()))
+		return nil
+	}
+	return &result
+}
@@ -0,0 +1,83 @@
+package keyfactor
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"os"
+	"testing"
+)
+
+type certificateStoreContainerTestCase struct {
+	name               string
+	storeType          string
+	overwriteSchedules bool
+	scheduleMinutes    int
+	resourceName       string
+}
+
+func TestAccKeyfactorCertificateStoreContainerResource(t *testing.T) {
+
+	r := certificateStoreContainerTestCase{
+		name:               "Terraform Acceptance Test",
+		storeType:          os.Getenv("KEYFACTOR_CERTIFICATE_STORE_TYPE"),
+		overwriteSchedules: false,
+		scheduleMinutes:    720,
+		resourceName:       "keyfactor_certificate_store_container.tf_acc_test",
+	}
+	if r.storeType == "" {
+		r.storeType = "PEM"
+	}
+	r2 := r
+	r2.name = "Terraform Acceptance Test Updated"
+	r2.overwriteSchedules = true
+	r2.scheduleMinutes = 60
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccKeyfactorCertificateStoreContainerResourceConfig(r),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(r.resourceName, "id"),
+					resource.TestCheckResourceAttr(r.resourceName, "name", r.name),
+					resource.TestCheckResourceAttr(r.resourceName, "store_type", r.storeType),
+					resource.TestCheckResourceAttr(r.resourceName, "overwrite_schedules", "false"),
+					resource.TestCheckResourceAttr(r.resourceName, "inventory_schedule.0.interval_minutes", fmt.Sprintf("%d", r.scheduleMinutes)),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      r.resourceName,
+				ImportState:       true,
+				ImportStateId:     r.name,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccKeyfactorCertificateStoreContainerResourceConfig(r2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(r2.resourceName, "name", r2.name),
+					resource.TestCheckResourceAttr(r2.resourceName, "overwrite_schedules", "true"),
+					resource.TestCheckResourceAttr(r2.resourceName, "inventory_schedule.0.interval_minutes", fmt.Sprintf("%d", r2.scheduleMinutes)),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccKeyfactorCertificateStoreContainerResourceConfig(t certificateStoreContainerTestCase) string {
+	output := fmt.Sprintf(`
+resource "keyfactor_certificate_store_container" "tf_acc_test" {
+  name                = "%s"
+  store_type          = "%s"
+  overwrite_schedules = %t
+  inventory_schedule {
+    interval_minutes = %d
+  }
+}
+`, t.name, t.storeType, t.overwriteSchedules, t.scheduleMinutes)
+	return output
+}
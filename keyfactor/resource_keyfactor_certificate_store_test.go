@@ -1,9 +1,17 @@
 package keyfactor
 
 import (
+	"context"
 	"fmt"
+	"github.com/Keyfactor/keyfactor-go-client/v2/api"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"os"
+	"regexp"
 	"testing"
 )
 
@@ -24,7 +32,7 @@ type certificateStoreTestCase struct {
 	agentIdentifier string
 	storeType       string
 	properties      map[string]interface{}
-	schedule        string
+	scheduleMinutes int
 	containerName   string
 	serverUserName  string
 	serverPassword  string
@@ -44,7 +52,7 @@ func TestAccKeyfactorCertificateStoreResource(t *testing.T) {
 		serverUserName:  os.Getenv("TEST_SERVER_USERNAME"),
 		serverPassword:  os.Getenv("TEST_SERVER_PASSWORD"),
 		storePassword:   "",
-		schedule:        "",
+		scheduleMinutes: 720,
 		serverUseSSL:    true,
 		resourceName:    "keyfactor_certificate_store.tf_acc_test",
 	}
@@ -65,7 +73,8 @@ func TestAccKeyfactorCertificateStoreResource(t *testing.T) {
 					resource.TestCheckResourceAttrSet(r.resourceName, "store_type"),     // TODO: Check specific value
 					resource.TestCheckResourceAttrSet(r.resourceName, "client_machine"), // TODO: Check specific value
 					resource.TestCheckResourceAttrSet(r.resourceName, "agent_id"),       // TODO: Check specific value
-					resource.TestCheckResourceAttrSet(r.resourceName, "password"),       // TODO: Check specific value
+					resource.TestCheckResourceAttr(r.resourceName, "store_password", r.storePassword),
+					resource.TestCheckResourceAttr(r.resourceName, "inventory_schedule.0.interval_minutes", fmt.Sprintf("%d", r.scheduleMinutes)),
 				),
 				//Destroy:                   false,
 				//ExpectNonEmptyPlan:        false,
@@ -87,16 +96,14 @@ func TestAccKeyfactorCertificateStoreResource(t *testing.T) {
 				//ExternalProviders:         nil,
 			},
 			// ImportState testing
-			//{
-			//	ResourceName:      "scaffolding_example.test",
-			//	ImportState:       false,
-			//	ImportStateVerify: false,
-			//	// This is not normally necessary, but is here because this
-			//	// example code does not have an actual upstream service.
-			//	// Once the Read method is able to refresh information from
-			//	// the upstream service, this can be removed.
-			//	ImportStateVerifyIgnore: []string{"configurable_attribute"},
-			//},
+			{
+				ResourceName:      r.resourceName,
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s::%s::%s", r.clientMachine, r.storePath, r.storeType),
+				ImportStateVerify: true,
+				// Command doesn't return credentials, and the agent is imported by its GUID
+				ImportStateVerifyIgnore: []string{"agent_identifier", "server_username", "server_password", "server_use_ssl", "store_password"},
+			},
 			// Update and Read testing
 			//{
 			//	Config: testAccKeyfactorCertificateStoreResourceConfig(r2),
@@ -108,7 +115,7 @@ func TestAccKeyfactorCertificateStoreResource(t *testing.T) {
 			//		resource.TestCheckResourceAttrSet(r2.resourceName, "agent_id"),           // TODO: Check specific value
 			//		resource.TestCheckResourceAttrSet(r2.resourceName, "inventory_schedule"), // TODO: Check specific value
 			//		resource.TestCheckResourceAttrSet(r2.resourceName, "container_id"),       // TODO: Check specific value
-			//		resource.TestCheckResourceAttrSet(r2.resourceName, "password"),           // TODO: Check specific value
+			//		resource.TestCheckResourceAttr(r2.resourceName, "store_password", r2.storePassword),
 			//	),
 			//},
 			// Delete testing automatically occurs in TestCase
@@ -116,9 +123,226 @@ func TestAccKeyfactorCertificateStoreResource(t *testing.T) {
 	})
 }
 
+func TestAccKeyfactorCertificateStoreResource_InvalidProperties(t *testing.T) {
+
+	r := certificateStoreTestCase{
+		clientMachine:   os.Getenv("KEYFACTOR_CERTIFICATE_STORE_CLIENT_MACHINE"),
+		storePath:       os.Getenv("KEYFACTOR_CERTIFICATE_STORE_PATH"),
+		agentIdentifier: os.Getenv("KEYFACTOR_CERTIFICATE_STORE_ORCHESTRATOR_AGENT_ID"),
+		storeType:       os.Getenv("KEYFACTOR_CERTIFICATE_STORE_TYPE"),
+		resourceName:    "keyfactor_certificate_store.tf_acc_test",
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Properties that aren't defined by the store type are rejected during plan
+			{
+				Config:      testAccKeyfactorCertificateStoreResourceInvalidPropertiesConfig(r),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("has no property 'NotAStoreTypeProperty'"),
+			},
+		},
+	})
+}
+
+func TestAccKeyfactorCertificateStoreResource_ConflictingPamReference(t *testing.T) {
+
+	r := certificateStoreTestCase{
+		clientMachine:   os.Getenv("KEYFACTOR_CERTIFICATE_STORE_CLIENT_MACHINE"),
+		storePath:       os.Getenv("KEYFACTOR_CERTIFICATE_STORE_PATH"),
+		agentIdentifier: os.Getenv("KEYFACTOR_CERTIFICATE_STORE_ORCHESTRATOR_AGENT_ID"),
+		storeType:       os.Getenv("KEYFACTOR_CERTIFICATE_STORE_TYPE"),
+		serverPassword:  os.Getenv("TEST_SERVER_PASSWORD"),
+		resourceName:    "keyfactor_certificate_store.tf_acc_test",
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// A credential can't be set both directly and from a PAM provider
+			{
+				Config:      testAccKeyfactorCertificateStoreResourceConflictingPamReferenceConfig(r),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Only one of 'server_password' or 'server_password_pam' can be set"),
+			},
+		},
+	})
+}
+
+func TestAccKeyfactorCertificateStoreResource_PamReferences(t *testing.T) {
+
+	r := certificateStoreTestCase{
+		clientMachine:   os.Getenv("KEYFACTOR_CERTIFICATE_STORE_CLIENT_MACHINE"),
+		storePath:       os.Getenv("KEYFACTOR_CERTIFICATE_STORE_PATH"),
+		agentIdentifier: os.Getenv("KEYFACTOR_CERTIFICATE_STORE_ORCHESTRATOR_AGENT_ID"),
+		storeType:       os.Getenv("KEYFACTOR_CERTIFICATE_STORE_TYPE"),
+		serverUserName:  os.Getenv("TEST_SERVER_USERNAME"),
+		resourceName:    "keyfactor_certificate_store.tf_acc_test",
+	}
+	p := pamProviderTestCase{
+		name:         "Terraform Acceptance Test Store",
+		providerType: os.Getenv("KEYFACTOR_PAM_PROVIDER_TYPE"),
+		host:         os.Getenv("KEYFACTOR_PAM_PROVIDER_HOST"),
+		username:     os.Getenv("KEYFACTOR_PAM_PROVIDER_USERNAME"),
+		password:     os.Getenv("KEYFACTOR_PAM_PROVIDER_PASSWORD"),
+	}
+	if p.providerType == "" {
+		p.providerType = "Delinea-SecretServer"
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccKeyfactorCertificateStoreResourcePamReferenceConfig(r, p),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(r.resourceName, "id"),
+					resource.TestCheckNoResourceAttr(r.resourceName, "server_password"),
+					resource.TestCheckResourceAttr(r.resourceName, "server_password_pam.#", "1"),
+					resource.TestCheckResourceAttr(r.resourceName, "server_password_pam.0.provider_name", p.name),
+					resource.TestCheckResourceAttr(r.resourceName, "server_password_pam.0.parameters.SecretId", "1"),
+					resource.TestCheckResourceAttr(r.resourceName, "store_password_pam.#", "1"),
+					resource.TestCheckResourceAttr(r.resourceName, "store_password_pam.0.parameters.SecretId", "2"),
+				),
+			},
+			// ImportState testing, the server password reference is read back from Command
+			{
+				ResourceName:      r.resourceName,
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s::%s::%s", r.clientMachine, r.storePath, r.storeType),
+				ImportStateVerify: true,
+				// Command doesn't return the store password, and the agent is imported by its GUID
+				ImportStateVerifyIgnore: []string{"agent_identifier", "server_username", "server_use_ssl", "store_password_pam"},
+			},
+		},
+	})
+}
+
+func testAccKeyfactorCertificateStoreResourcePamReferenceConfig(t certificateStoreTestCase, p pamProviderTestCase) string {
+	output := fmt.Sprintf(`
+resource "keyfactor_pam_provider" "tf_acc_test" {
+  name          = "%s"
+  provider_type = "%s"
+  parameters = {
+    Host     = "%s"
+    Username = "%s"
+  }
+  secret_parameters = {
+    Password = "%s"
+  }
+}
+
+resource "keyfactor_certificate_store" "tf_acc_test" {
+  client_machine   = "%s"
+  store_path       = "%s"
+  agent_identifier = "%s"
+  store_type       = "%s"
+  server_username  = "%s"
+  server_password_pam {
+    provider_name = keyfactor_pam_provider.tf_acc_test.name
+    parameters = {
+      SecretId = "1"
+    }
+  }
+  store_password_pam {
+    provider_name = keyfactor_pam_provider.tf_acc_test.name
+    parameters = {
+      SecretId = "2"
+    }
+  }
+}
+`, p.name, p.providerType, p.host, p.username, p.password, t.clientMachine, t.storePath, t.agentIdentifier, t.storeType, t.serverUserName)
+	return output
+}
+
+func TestAccKeyfactorCertificateStoreResource_ApproveDiscovered(t *testing.T) {
+
+	r := certificateStoreTestCase{
+		clientMachine:   os.Getenv("KEYFACTOR_DISCOVERED_STORE_CLIENT_MACHINE"),
+		storePath:       os.Getenv("KEYFACTOR_DISCOVERED_STORE_PATH"),
+		agentIdentifier: os.Getenv("KEYFACTOR_CERTIFICATE_STORE_ORCHESTRATOR_AGENT_ID"),
+		storeType:       os.Getenv("KEYFACTOR_DISCOVERED_STORE_TYPE"),
+		resourceName:    "keyfactor_certificate_store.tf_acc_test",
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// A discovered store is only adopted when asked to
+			{
+				Config:      testAccKeyfactorCertificateStoreResourceApproveDiscoveredConfig(r, false),
+				ExpectError: regexp.MustCompile("Set 'approve_discovered' to true"),
+			},
+			{
+				Config: testAccKeyfactorCertificateStoreResourceApproveDiscoveredConfig(r, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(r.resourceName, "id"),
+					resource.TestCheckResourceAttr(r.resourceName, "approve_discovered", "true"),
+					resource.TestCheckResourceAttr(r.resourceName, "approved", "true"),
+					resource.TestCheckResourceAttr(r.resourceName, "client_machine", r.clientMachine),
+					resource.TestCheckResourceAttr(r.resourceName, "store_path", r.storePath),
+				),
+			},
+		},
+	})
+}
+
+func testAccKeyfactorCertificateStoreResourceApproveDiscoveredConfig(t certificateStoreTestCase, approveDiscovered bool) string {
+	output := fmt.Sprintf(`
+resource "keyfactor_certificate_store" "tf_acc_test" {
+  client_machine     = "%s"
+  store_path         = "%s"
+  agent_identifier   = "%s"
+  store_type         = "%s"
+  approve_discovered = %t
+}
+`, t.clientMachine, t.storePath, t.agentIdentifier, t.storeType, approveDiscovered)
+	return output
+}
+
+func testAccKeyfactorCertificateStoreResourceConflictingPamReferenceConfig(t certificateStoreTestCase) string {
+	output := fmt.Sprintf(`
+resource "keyfactor_certificate_store" "tf_acc_test" {
+  client_machine   = "%s"
+  store_path       = "%s"
+  agent_identifier = "%s"
+  store_type       = "%s"
+  server_password  = "%s"
+  server_password_pam {
+    provider_name = "tf_acc_test"
+    parameters = {
+      SecretId = "1"
+    }
+  }
+}
+`, t.clientMachine, t.storePath, t.agentIdentifier, t.storeType, t.serverPassword)
+	return output
+}
+
+func testAccKeyfactorCertificateStoreResourceInvalidPropertiesConfig(t certificateStoreTestCase) string {
+	output := fmt.Sprintf(`
+resource "keyfactor_certificate_store" "tf_acc_test" {
+  client_machine   = "%s"
+  store_path       = "%s"
+  agent_identifier = "%s"
+  store_type       = "%s"
+  properties = {
+    NotAStoreTypeProperty = "value"
+  }
+}
+`, t.clientMachine, t.storePath, t.agentIdentifier, t.storeType)
+	return output
+}
+
 func testAccKeyfactorCertificateStoreResourceConfig(t certificateStoreTestCase) string {
 	output := fmt.Sprintf(`
-resource "keyfactor_certificate_store" "tf_k8s_acc_test" {
+resource "keyfactor_certificate_store" "tf_acc_test" {
   client_machine = "%s" # Orchestrator client name
   store_path     = "%s" # Varies based on store type
   agent_identifier = "%s" # Orchestrator GUID
@@ -126,7 +350,9 @@ resource "keyfactor_certificate_store" "tf_k8s_acc_test" {
   properties = {
     # Optional properties based on the store type
   }
-  inventory_schedule = "%s" # How often to update the inventory
+  inventory_schedule {
+    interval_minutes = %d # How often to update the inventory
+  }
   container_name       = "%s"   # ID of the KeyFactor container
   store_password           = "%s"
   server_username          = "%s" # The username for the certificate store.
@@ -134,6 +360,169 @@ resource "keyfactor_certificate_store" "tf_k8s_acc_test" {
   server_use_ssl           = true
   # The password for the certificate store. Note: This is bad practice, use TF_VAR_<variable_name> instead.
 }
-`, t.clientMachine, t.storePath, t.agentIdentifier, t.storeType, t.schedule, t.containerName, t.storePassword, t.serverUserName, t.serverPassword)
+`, t.clientMachine, t.storePath, t.agentIdentifier, t.storeType, t.scheduleMinutes, t.containerName, t.storePassword, t.serverUserName, t.serverPassword)
 	return output
 }
+
+func TestPlanStoreProperties(t *testing.T) {
+	storeType := &api.CertificateStoreType{
+		ShortName: "tf_acc_test",
+		Properties: &[]api.StoreTypePropertyDefinition{
+			{Name: "Mode", Type: STORE_TYPE_PROPERTY_MULTIPLE_CHOICE, DefaultValue: "fast,slow"},
+			{Name: "Verify", Type: STORE_TYPE_PROPERTY_BOOL, DefaultValue: "true"},
+			{Name: "Region", Type: STORE_TYPE_PROPERTY_STRING},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		properties types.Map
+		want       types.Map
+	}{
+		{
+			name:       "defaults when not configured",
+			properties: types.Map{ElemType: types.StringType, Null: true},
+			want: types.Map{ElemType: types.StringType, Elems: map[string]attr.Value{
+				"Mode":   types.String{Value: "fast"},
+				"Verify": types.String{Value: "true"},
+			}},
+		},
+		{
+			name:       "empty configuration is kept",
+			properties: types.Map{ElemType: types.StringType, Elems: map[string]attr.Value{}},
+			want:       types.Map{ElemType: types.StringType, Elems: map[string]attr.Value{}},
+		},
+		{
+			name: "configuration is kept",
+			properties: types.Map{ElemType: types.StringType, Elems: map[string]attr.Value{
+				"Region": types.String{Value: "east"},
+			}},
+			want: types.Map{ElemType: types.StringType, Elems: map[string]attr.Value{
+				"Region": types.String{Value: "east"},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := planStoreProperties(context.Background(), storeType, CertificateStore{Properties: tt.properties})
+			if diags.HasError() {
+				t.Fatalf("planStoreProperties() diagnostics = %v", diags)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("planStoreProperties() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlattenStoreProperties(t *testing.T) {
+	remote := map[string]interface{}{
+		"Mode":           "fast",
+		"Verify":         true,
+		"Region":         "",
+		"ServerUsername": "admin",
+	}
+
+	tests := []struct {
+		name  string
+		prior types.Map
+		want  types.Map
+	}{
+		{
+			name:  "imported",
+			prior: types.Map{ElemType: types.StringType, Null: true},
+			want: types.Map{ElemType: types.StringType, Elems: map[string]attr.Value{
+				"Mode":   types.String{Value: "fast"},
+				"Verify": types.String{Value: "true"},
+			}},
+		},
+		{
+			name:  "store type defaults aren't added to the configuration",
+			prior: types.Map{ElemType: types.StringType, Elems: map[string]attr.Value{}},
+			want:  types.Map{ElemType: types.StringType, Elems: map[string]attr.Value{}},
+		},
+		{
+			name: "configured values keep their case",
+			prior: types.Map{ElemType: types.StringType, Elems: map[string]attr.Value{
+				"Verify": types.String{Value: "True"},
+			}},
+			want: types.Map{ElemType: types.StringType, Elems: map[string]attr.Value{
+				"Verify": types.String{Value: "True"},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := flattenStoreProperties(remote, tt.prior)
+			if !got.Equal(tt.want) {
+				t.Errorf("flattenStoreProperties() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpgradeCertificateStoreStateV0(t *testing.T) {
+	tests := []struct {
+		name            string
+		schedule        string
+		wantSchedules   int
+		immediate       bool
+		intervalMinutes int64
+		wantWarning     bool
+	}{
+		{name: "immediate", schedule: "immediate", wantSchedules: 1, immediate: true},
+		{name: "minutes", schedule: "30m", wantSchedules: 1, intervalMinutes: 30},
+		{name: "hours", schedule: "12h", wantSchedules: 1, intervalMinutes: 720},
+		{name: "daily", schedule: "daily", wantWarning: true},
+		{name: "exactly once", schedule: "exactly_once", wantWarning: true},
+		{name: "not set"},
+	}
+
+	ctx := context.Background()
+	schema, diags := resourceCertificateStoreType{}.GetSchema(ctx)
+	if diags.HasError() {
+		t.Fatalf("GetSchema() diagnostics = %v", diags)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rawState := fmt.Sprintf(`{"id":"1","client_machine":"host","store_path":"/path","inventory_schedule":%q}`, tt.schedule)
+			request := tfsdk.UpgradeResourceStateRequest{RawState: &tfprotov6.RawState{JSON: []byte(rawState)}}
+			response := &tfsdk.UpgradeResourceStateResponse{}
+			upgradeCertificateStoreStateV0(ctx, request, response)
+			if response.Diagnostics.HasError() {
+				t.Fatalf("upgradeCertificateStoreStateV0() diagnostics = %v", response.Diagnostics)
+			}
+			if gotWarning := response.Diagnostics.WarningsCount() > 0; gotWarning != tt.wantWarning {
+				t.Errorf("upgradeCertificateStoreStateV0() warning = %v, want %v", gotWarning, tt.wantWarning)
+			}
+
+			upgraded, err := response.DynamicValue.Unmarshal(schema.TerraformType(ctx))
+			if err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			state := tfsdk.State{Schema: schema, Raw: upgraded}
+			var clientMachine types.String
+			diags := state.GetAttribute(ctx, path.Root("client_machine"), &clientMachine)
+			var schedules []CertificateStoreInventorySchedule
+			diags.Append(state.GetAttribute(ctx, path.Root("inventory_schedule"), &schedules)...)
+			if diags.HasError() {
+				t.Fatalf("GetAttribute() diagnostics = %v", diags)
+			}
+			if clientMachine.Value != "host" {
+				t.Errorf("client_machine = %v, want host", clientMachine)
+			}
+			if len(schedules) != tt.wantSchedules {
+				t.Fatalf("inventory_schedule = %+v, want %d schedules", schedules, tt.wantSchedules)
+			}
+			if tt.wantSchedules == 0 {
+				return
+			}
+			if schedules[0].Immediate.Value != tt.immediate || schedules[0].IntervalMinutes.Value != tt.intervalMinutes {
+				t.Errorf("inventory_schedule = %+v, want immediate %v and interval_minutes %v", schedules[0], tt.immediate, tt.intervalMinutes)
+			}
+		})
+	}
+}
@@ -1,461 +1,688 @@
 package keyfactor
 
-//
-//import (
-//	"context"
-//	"fmt"
-//	"github.com/Keyfactor/keyfactor-go-client/v2/api"
-//	"github.com/hashicorp/terraform-plugin-framework/attr"
-//	"github.com/hashicorp/terraform-plugin-framework/diag"
-//	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
-//	"github.com/hashicorp/terraform-plugin-framework/types"
-//	"github.com/hashicorp/terraform-plugin-log/tflog"
-//	"log"
-//)
-//
-//type resourceCertificateStoreType struct{}
-//
-//func (r resourceCertificateStoreType) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
-//	return tfsdk.Schema{
-//		Attributes: map[string]tfsdk.Attribute{
-//			"container_id": {
-//				Type:        types.Int64Type,
-//				Optional:    true,
-//				Description: "Container identifier of the store's associated certificate store container.",
-//			},
-//			"client_machine": {
-//				Type:        types.StringType,
-//				Required:    true,
-//				Description: "Client machine name; value depends on certificate store type. See API reference guide",
-//			},
-//			"store_path": {
-//				Type:        types.StringType,
-//				Required:    true,
-//				Description: "Path to the new certificate store on a target. Format varies depending on type.",
-//			},
-//			"store_type": {
-//				Type:        types.StringType,
-//				Required:    true,
-//				Description: "Short name of certificate store type. See API reference guide",
-//			},
-//			"approved": {
-//				Type:     types.BoolType,
-//				Optional: true,
-//				//DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
-//				//	// For some reason Terraform detects this particular function as having drift; this function
-//				//	// gives us a definitive answer.
-//				//	return !d.HasChange(k)
-//				//},
-//				Description: "Bool that indicates the approval status of store created. Default is true, omit if unsure.",
-//			},
-//			"create_if_missing": {
-//				Type:        types.BoolType,
-//				Optional:    true,
-//				Description: "Bool that indicates if the store should be created with information provided. Valid only for JKS type, omit if unsure.",
-//			},
-//			"properties": {
-//				Type:        types.MapType{ElemType: types.StringType},
-//				Optional:    true,
-//				Description: "Certificate properties specific to certificate store type configured as key-value pairs.",
-//			},
-//			"agent_id": {
-//				Type:        types.StringType,
-//				Required:    true,
-//				Description: "String indicating the Keyfactor Command GUID of the orchestrator for the created store.",
-//			},
-//			"agent_assigned": {
-//				Type:     types.BoolType,
-//				Optional: true,
-//				//DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
-//				//	// For some reason Terraform detects this particular function as having drift; this function
-//				//	// gives us a definitive answer.
-//				//	return !d.HasChange(k)
-//				//},
-//				Description: "Bool indicating if there is an orchestrator assigned to the new certificate store.",
-//			},
-//			"container_name": {
-//				Type:        types.StringType,
-//				Optional:    true,
-//				Description: "Name of certificate store's associated container, if applicable.",
-//			},
-//			"inventory_schedule": {
-//				Type:        types.StringType,
-//				Optional:    true,
-//				Description: "Inventory schedule for new certificate store.",
-//			},
-//			"set_new_password_allowed": {
-//				Type:        types.BoolType,
-//				Optional:    true,
-//				Description: "Indicates whether the store password can be changed.",
-//			},
-//			"password": {
-//				Type:        types.StringType,
-//				Optional:    true,
-//				Description: "Sets password for certificate store.",
-//			},
-//			"id": {
-//				Type:        types.StringType,
-//				Computed:    true,
-//				Description: "Keyfactor certificate store GUID.",
-//			},
-//			"certificates": {
-//				Type:        types.ListType{ElemType: types.Int64Type},
-//				Computed:    true,
-//				Description: "A list of certificate IDs associated with the certificate store.",
-//			},
-//		},
-//	}, nil
-//}
-//
-//func (r resourceCertificateStoreType) NewResource(_ context.Context, p tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
-//	return resourceCertificateStore{
-//		p: *(p.(*provider)),
-//	}, nil
-//}
-//
-//type resourceCertificateStore struct {
-//	p provider
-//}
-//
-//func (r resourceCertificateStore) Create(ctx context.Context, request tfsdk.CreateResourceRequest, response *tfsdk.CreateResourceResponse) {
-//	if !r.p.configured {
-//		response.Diagnostics.AddError(
-//			"Provider not configured",
-//			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. This leads to weird stuff happening, so we'd prefer if you didn't do that. Thanks!",
-//		)
-//		return
-//	}
-//
-//	// Retrieve values from plan
-//	var plan CertificateStore
-//	diags := request.Plan.Get(ctx, &plan)
-//	response.Diagnostics.Append(diags...)
-//	if response.Diagnostics.HasError() {
-//		return
-//	}
-//
-//	// Generate API request body from plan
-//
-//	kfClient := r.p.client
-//
-//	//certificateStoreId := plan.ID.Value
-//	//ctx = tflog.SetField(ctx, "id", certificateStoreId)
-//	tflog.Info(ctx, "Create called on certificate store resource")
-//
-//	csType, csTypeErr := r.p.client.GetCertStoreTypeByName(plan.StoreType.Value)
-//	if csTypeErr != nil {
-//		response.Diagnostics.AddError(
-//			"Invalid certificate store type.",
-//			fmt.Sprintf("Could not retrieve certificate store type '%s' from Keyfactor"+csTypeErr.Error(), plan.StoreType.Value),
-//		)
-//		return
-//	}
-//
-//	containerId := int(plan.ContainerID.Value)
-//	var properties map[string]string
-//	if plan.Properties.Elems != nil {
-//		diags = plan.Properties.ElementsAs(ctx, &properties, false)
-//
-//	}
-//	newStoreArgs := &api.CreateStoreFctArgs{
-//		ContainerId:     &containerId,
-//		ClientMachine:   plan.ClientMachine.Value,
-//		StorePath:       plan.StorePath.Value,
-//		CertStoreType:   csType.StoreType,
-//		Approved:        &plan.Approved.Value,
-//		CreateIfMissing: &plan.CreateIfMissing.Value,
-//		Properties:      properties,
-//		AgentId:         plan.AgentId.Value,
-//		AgentAssigned:   &plan.AgentAssigned.Value,
-//		ContainerName:   &plan.ContainerName.Value,
-//		//InventorySchedule:     createInventorySchedule(plan.InventorySchedule.Value), // TODO: Implement inventory schedule
-//		SetNewPasswordAllowed: &plan.SetNewPasswordAllowed.Value,
-//		Password:              createPasswordConfig(plan.Password.Value),
-//	}
-//
-//	createStoreResponse, err := kfClient.CreateStore(newStoreArgs)
-//	if err != nil {
-//		response.Diagnostics.AddError(
-//			"Error creating certificate store",
-//			"Error creating certificate store: %s"+err.Error(),
-//		)
-//		return
-//	}
-//
-//	// Set state
-//	var result = CertificateStore{
-//		ID:                    types.String{Value: createStoreResponse.Id},
-//		ContainerID:           types.Int64{Value: int64(createStoreResponse.ContainerId)},
-//		ClientMachine:         types.String{Value: createStoreResponse.ClientMachine},
-//		StorePath:             types.String{Value: createStoreResponse.Storepath},
-//		StoreType:             types.String{Value: plan.StoreType.Value},
-//		Approved:              plan.Approved,
-//		CreateIfMissing:       plan.CreateIfMissing,
-//		Properties:            plan.Properties,
-//		AgentId:               types.String{Value: createStoreResponse.AgentId},
-//		AgentAssigned:         plan.AgentAssigned,
-//		ContainerName:         plan.ContainerName,
-//		InventorySchedule:     plan.InventorySchedule,
-//		SetNewPasswordAllowed: plan.SetNewPasswordAllowed,
-//		Password:              plan.Password,
-//		Certificates:          types.List{ElemType: types.Int64Type, Elems: []attr.Value{}},
-//	}
-//
-//	diags = response.State.Set(ctx, result)
-//	response.Diagnostics.Append(diags...)
-//	if response.Diagnostics.HasError() {
-//		return
-//	}
-//
-//}
-//
-//func (r resourceCertificateStore) Read(ctx context.Context, request tfsdk.ReadResourceRequest, response *tfsdk.ReadResourceResponse) {
-//	var state CertificateStore
-//	diags := request.State.Get(ctx, &state)
-//	response.Diagnostics.Append(diags...)
-//	if response.Diagnostics.HasError() {
-//		return
-//	}
-//
-//	tflog.Info(ctx, "Read called on certificate store resource")
-//	certificateStoreId := state.ID.Value
-//
-//	tflog.SetField(ctx, "id", certificateStoreId)
-//
-//	_, err := r.p.client.GetCertificateStoreByID(certificateStoreId)
-//	if err != nil {
-//		response.Diagnostics.AddError(
-//			ERR_SUMMARY_CERT_STORE_READ,
-//			"Error reading certificate store: %s"+err.Error(),
-//		)
-//		return
-//	}
-//
-//	password := state.Password.Value
-//	tflog.Trace(ctx, fmt.Sprintf("Password for store %s: %s", certificateStoreId, password))
-//
-//	if err != nil {
-//		response.Diagnostics.AddError(
-//			ERR_SUMMARY_CERTIFICATE_RESOURCE_READ,
-//			fmt.Sprintf("Could not retrieve certificate '%s' from Keyfactor Command: "+err.Error(), certificateStoreId),
-//		)
-//		return
-//	}
-//
-//	// Set state
-//	diags = response.State.Set(ctx, &state)
-//	response.Diagnostics.Append(diags...)
-//	if response.Diagnostics.HasError() {
-//		return
-//	}
-//}
-//
-//func (r resourceCertificateStore) Update(ctx context.Context, request tfsdk.UpdateResourceRequest, response *tfsdk.UpdateResourceResponse) {
-//	// Get plan values
-//	var plan CertificateStore
-//	diags := request.Plan.Get(ctx, &plan)
-//	response.Diagnostics.Append(diags...)
-//	if response.Diagnostics.HasError() {
-//		return
-//	}
-//
-//	// Get current state
-//	var state CertificateStore
-//	diags = request.State.Get(ctx, &state)
-//	response.Diagnostics.Append(diags...)
-//	if response.Diagnostics.HasError() {
-//		return
-//	}
-//
-//	// Generate API request body from plan
-//	containerId := int(plan.ContainerID.Value)
-//	csType, csTypeErr := r.p.client.GetCertStoreTypeByName(plan.StoreType.Value)
-//	if csTypeErr != nil {
-//		response.Diagnostics.AddError(
-//			"Invalid certificate store type.",
-//			fmt.Sprintf("Could not retrieve certificate store type '%s' from Keyfactor"+csTypeErr.Error(), plan.StoreType.Value),
-//		)
-//		return
-//	}
-//	updateStoreArgs := &api.UpdateStoreFctArgs{
-//		Id: state.ID.Value,
-//		CreateStoreFctArgs: api.CreateStoreFctArgs{
-//			ContainerId:     &containerId,
-//			ClientMachine:   plan.ClientMachine.Value,
-//			StorePath:       plan.StorePath.Value,
-//			CertStoreType:   csType.StoreType,
-//			Approved:        &plan.Approved.Value,
-//			CreateIfMissing: &plan.CreateIfMissing.Value,
-//			//Properties:            map[string]interface{}(plan.Properties.Elems),
-//			AgentId:       plan.AgentId.Value,
-//			AgentAssigned: &plan.AgentAssigned.Value,
-//			ContainerName: &plan.ContainerName.Value,
-//			//InventorySchedule:     createInventorySchedule(d.Get("inventory_schedule").([]interface{})),
-//			SetNewPasswordAllowed: &plan.SetNewPasswordAllowed.Value,
-//			//Password:              createPasswordConfig(d.Get("password").([]interface{})),
-//		}}
-//
-//	updateResponse, err := r.p.client.UpdateStore(updateStoreArgs)
-//	if err != nil {
-//		response.Diagnostics.AddError(
-//			"Error updating certificate store",
-//			"Error updating certificate store: %s"+err.Error(),
-//		)
-//	}
-//
-//	result := CertificateStore{
-//		ID:                    types.String{Value: state.ID.Value},
-//		ContainerID:           types.Int64{Value: int64(updateResponse.ContainerId)},
-//		ClientMachine:         types.String{Value: updateResponse.ClientMachine},
-//		StorePath:             types.String{Value: updateResponse.Storepath},
-//		StoreType:             plan.StoreType,
-//		Approved:              types.Bool{Value: updateResponse.Approved},
-//		CreateIfMissing:       types.Bool{Value: updateResponse.CreateIfMissing},
-//		Properties:            plan.Properties,
-//		AgentId:               types.String{Value: updateResponse.AgentId},
-//		AgentAssigned:         types.Bool{Value: updateResponse.AgentAssigned},
-//		ContainerName:         types.String{Value: updateResponse.ContainerName},
-//		InventorySchedule:     plan.InventorySchedule,
-//		SetNewPasswordAllowed: types.Bool{Value: updateResponse.SetNewPasswordAllowed},
-//		Password:              plan.Password,
-//	}
-//
-//	// Set state
-//	diags = response.State.Set(ctx, &result)
-//	response.Diagnostics.Append(diags...)
-//	if response.Diagnostics.HasError() {
-//		return
-//	}
-//}
-//
-//func (r resourceCertificateStore) Delete(ctx context.Context, request tfsdk.DeleteResourceRequest, response *tfsdk.DeleteResourceResponse) {
-//	var state CertificateStore
-//	diags := request.State.Get(ctx, &state)
-//	kfClient := r.p.client
-//
-//	response.Diagnostics.Append(diags...)
-//	if response.Diagnostics.HasError() {
-//		return
-//	}
-//
-//	// Get order ID from state
-//	certificateStoreId := state.ID.Value
-//	tflog.SetField(ctx, "id", certificateStoreId)
-//
-//	// Delete order by calling API
-//	log.Println("[INFO] Deleting certificate resource")
-//
-//	// When Terraform Destroy is called, we want Keyfactor to revoke the certificate.
-//
-//	tflog.Info(ctx, fmt.Sprintf("Revoking certificate %s in Keyfactor", certificateStoreId))
-//
-//	err := kfClient.DeleteCertificateStore(certificateStoreId)
-//	if err != nil {
-//		response.Diagnostics.AddError("Certificate store delete error.", fmt.Sprintf("Could not delete certificate store '%s' on Keyfactor: "+err.Error(), certificateStoreId))
-//		return
-//	}
-//
-//	// Remove resource from state
-//	response.State.RemoveResource(ctx)
-//
-//}
-//
-//func (r resourceCertificateStore) ImportState(ctx context.Context, request tfsdk.ImportResourceStateRequest, response *tfsdk.ImportResourceStateResponse) {
-//	var state CertificateStore
-//
-//	tflog.Info(ctx, "Read called on certificate store resource")
-//	certificateStoreId := state.ID.Value
-//
-//	tflog.SetField(ctx, "id", certificateStoreId)
-//
-//	readResponse, err := r.p.client.GetCertificateStoreByID(certificateStoreId)
-//	if err != nil {
-//		response.Diagnostics.AddError(
-//			ERR_SUMMARY_CERT_STORE_READ,
-//			"Error reading certificate store: %s"+err.Error(),
-//		)
-//		return
-//	}
-//
-//	password := state.Password.Value
-//	tflog.Trace(ctx, fmt.Sprintf("Password for store %s: %s", certificateStoreId, password))
-//
-//	if err != nil {
-//		response.Diagnostics.AddError(
-//			ERR_SUMMARY_CERTIFICATE_RESOURCE_READ,
-//			fmt.Sprintf("Could not retrieve certificate '%s' from Keyfactor Command: "+err.Error(), certificateStoreId),
-//		)
-//		return
-//	}
-//
-//	csType, csTypeErr := r.p.client.GetCertStoreType(readResponse.CertStoreType)
-//	if csTypeErr != nil {
-//		response.Diagnostics.AddError(
-//			ERR_SUMMARY_CERTIFICATE_RESOURCE_READ,
-//			fmt.Sprintf("Could not retrieve certificate store type '%s' from Keyfactor Command: "+err.Error(), readResponse.CertStoreType),
-//		)
-//		return
-//	}
-//	// Set state
-//	result := CertificateStore{
-//		ID:              types.String{Value: state.ID.Value},
-//		ContainerID:     types.Int64{Value: int64(readResponse.ContainerId)},
-//		ClientMachine:   types.String{Value: readResponse.ClientMachine},
-//		StorePath:       types.String{Value: readResponse.StorePath},
-//		StoreType:       types.String{Value: csType.Name},
-//		Approved:        types.Bool{Value: readResponse.Approved},
-//		CreateIfMissing: types.Bool{Value: readResponse.CreateIfMissing},
-//		//Properties:            plan.Properties,
-//		AgentId:       types.String{Value: readResponse.AgentId},
-//		AgentAssigned: types.Bool{Value: readResponse.AgentAssigned},
-//		ContainerName: types.String{Value: readResponse.ContainerName},
-//		//InventorySchedule:     plan.InventorySchedule,
-//		SetNewPasswordAllowed: types.Bool{Value: readResponse.SetNewPasswordAllowed},
-//		//Password:              plan.Password,
-//	}
-//	diags := response.State.Set(ctx, &result)
-//	response.Diagnostics.Append(diags...)
-//	if response.Diagnostics.HasError() {
-//		return
-//	}
-//}
-//
-//func createPasswordConfig(p string) *api.StorePasswordConfig {
-//	password := stringToPointer(p)
-//	res := &api.StorePasswordConfig{
-//		Value: password,
-//	}
-//
-//	return res
-//}
-//
-////func createInventorySchedule(interval string) (*api.InventorySchedule, error) {
-////	inventorySchedule := &api.InventorySchedule{}
-////
-////	if interval == "immediate" {
-////		immediate := true
-////		inventorySchedule.Immediate = &immediate
-////	} else {
-////		if strings.HasSuffix(interval, "m") {
-////			minutes, err := strconv.Atoi(interval[:len(interval)-1])
-////			if err != nil {
-////				return nil, err
-////			}
-////			iv := &api.InventoryInterval{Minutes: minutes}
-////			inventorySchedule.Interval = iv
-////			return inventorySchedule, nil
-////		}
-////		if key == "daily" {
-////			daily := &api.InventoryDaily{Time: innerValue.(string)}
-////			inventorySchedule.Daily = daily
-////			return inventorySchedule
-////		}
-////		if key == "exactly_once" {
-////			once := &api.InventoryOnce{Time: innerValue.(string)}
-////			inventorySchedule.ExactlyOnce = once
-////			return inventorySchedule
-////		}
-////	}
-////
-////	return inventorySchedule
-////}
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Keyfactor/keyfactor-go-client/v2/api"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type resourceKeyfactorCertificateStoreTypeType struct{}
+
+func (r resourceKeyfactorCertificateStoreTypeType) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:          types.Int64Type,
+				Computed:      true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{tfsdk.UseStateForUnknown()},
+				Description:   "Keyfactor Command integer ID of the certificate store type.",
+			},
+			"name": {
+				Type:        types.StringType,
+				Required:    true,
+				Description: "Display name of the certificate store type.",
+			},
+			"short_name": {
+				Type:          types.StringType,
+				Required:      true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{tfsdk.RequiresReplace()},
+				Description:   "Short name of the certificate store type. This is the name used by `keyfactor_certificate_store.store_type`.",
+			},
+			"capability": {
+				Type:     types.StringType,
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.UseStateForUnknown(),
+					tfsdk.RequiresReplace(),
+				},
+				Description: "Capability the orchestrator extension registers for the certificate store type. Defaults to `short_name`.",
+			},
+			"local_store": {
+				Type:          types.BoolType,
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{defaultValue(types.Bool{Value: false})},
+				Description:   "Whether certificate stores of this type are local to the orchestrator. Defaults to `false`.",
+			},
+			"server_required": {
+				Type:          types.BoolType,
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{defaultValue(types.Bool{Value: false})},
+				Description:   "Whether certificate stores of this type need server credentials (`server_username`, `server_password` and `server_use_ssl` on `keyfactor_certificate_store`). Defaults to `false`.",
+			},
+			"blueprint_allowed": {
+				Type:          types.BoolType,
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{defaultValue(types.Bool{Value: false})},
+				Description:   "Whether certificate stores of this type can be included in orchestrator blueprints. Defaults to `false`.",
+			},
+			"supports_add": {
+				Type:          types.BoolType,
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{defaultValue(types.Bool{Value: false})},
+				Description:   "Whether certificates can be added to certificate stores of this type. Defaults to `false`.",
+			},
+			"supports_remove": {
+				Type:          types.BoolType,
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{defaultValue(types.Bool{Value: false})},
+				Description:   "Whether certificates can be removed from certificate stores of this type. Defaults to `false`.",
+			},
+			"supports_create": {
+				Type:          types.BoolType,
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{defaultValue(types.Bool{Value: false})},
+				Description:   "Whether the orchestrator can create certificate stores of this type. Defaults to `false`.",
+			},
+			"supports_discovery": {
+				Type:          types.BoolType,
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{defaultValue(types.Bool{Value: false})},
+				Description:   "Whether the orchestrator can discover certificate stores of this type. Defaults to `false`.",
+			},
+			"supports_reenrollment": {
+				Type:          types.BoolType,
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{defaultValue(types.Bool{Value: false})},
+				Description:   "Whether certificate stores of this type support reenrollment jobs. Defaults to `false`.",
+			},
+			"private_key_allowed": {
+				Type:          types.StringType,
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{defaultValue(types.String{Value: STORE_TYPE_POLICY_FORBIDDEN})},
+				Description:   "Whether certificates in stores of this type are deployed with their private key. Valid values are `Forbidden`, `Optional` and `Required`. Defaults to `Forbidden`.",
+			},
+			"custom_alias_allowed": {
+				Type:          types.StringType,
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{defaultValue(types.String{Value: STORE_TYPE_POLICY_FORBIDDEN})},
+				Description:   "Whether a custom alias can be given to certificates deployed to stores of this type. Valid values are `Forbidden`, `Optional` and `Required`. Defaults to `Forbidden`.",
+			},
+			"supports_entry_password": {
+				Type:          types.BoolType,
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{defaultValue(types.Bool{Value: false})},
+				Description:   "Whether certificates in stores of this type can be protected by their own password. Defaults to `false`.",
+			},
+			"store_password_required": {
+				Type:          types.BoolType,
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{defaultValue(types.Bool{Value: false})},
+				Description:   "Whether certificate stores of this type must have a store password. Defaults to `false`.",
+			},
+			"password_style": {
+				Type:          types.StringType,
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{defaultValue(types.String{Value: "Default"})},
+				Description:   "How store and entry passwords are generated. Valid values are `Default` and `Custom`. Defaults to `Default`.",
+			},
+			"job_properties": {
+				Type:        types.ListType{ElemType: types.StringType},
+				Optional:    true,
+				Description: "Names of the custom fields passed to the orchestrator with management jobs.",
+			},
+			"properties": {
+				Optional:    true,
+				Description: "Custom properties that certificate stores of this type are configured with.",
+				Attributes: tfsdk.ListNestedAttributes(
+					map[string]tfsdk.Attribute{
+						"name": {
+							Type:        types.StringType,
+							Required:    true,
+							Description: "Name of the property as it appears in `keyfactor_certificate_store.properties`.",
+						},
+						"display_name": {
+							Type:        types.StringType,
+							Required:    true,
+							Description: "Display name of the property in the Keyfactor Command portal.",
+						},
+						"type": {
+							Type:        types.StringType,
+							Required:    true,
+							Description: "Type of the property. Valid values are `String`, `Bool`, `MultipleChoice` and `Secret`.",
+						},
+						"depends_on": {
+							Type:          types.StringType,
+							Optional:      true,
+							Computed:      true,
+							PlanModifiers: []tfsdk.AttributePlanModifier{defaultValue(types.String{Value: ""})},
+							Description:   "Name of a `Bool` property that has to be true for this property to be shown.",
+						},
+						"default_value": {
+							Type:          types.StringType,
+							Optional:      true,
+							Computed:      true,
+							PlanModifiers: []tfsdk.AttributePlanModifier{defaultValue(types.String{Value: ""})},
+							Description:   "Default value of the property. For `MultipleChoice` properties this is the comma separated list of allowed values.",
+						},
+						"required": {
+							Type:          types.BoolType,
+							Optional:      true,
+							Computed:      true,
+							PlanModifiers: []tfsdk.AttributePlanModifier{defaultValue(types.Bool{Value: false})},
+							Description:   "Whether the property must be set on every certificate store of this type. Defaults to `false`.",
+						},
+					},
+				),
+			},
+			"entry_parameters": {
+				Optional:    true,
+				Description: "Parameters passed to the orchestrator when certificates are added to, removed from or reenrolled in stores of this type.",
+				Attributes: tfsdk.ListNestedAttributes(
+					map[string]tfsdk.Attribute{
+						"name": {
+							Type:        types.StringType,
+							Required:    true,
+							Description: "Name of the entry parameter.",
+						},
+						"display_name": {
+							Type:        types.StringType,
+							Required:    true,
+							Description: "Display name of the entry parameter in the Keyfactor Command portal.",
+						},
+						"type": {
+							Type:        types.StringType,
+							Required:    true,
+							Description: "Type of the entry parameter. Valid values are `String`, `Bool` and `MultipleChoice`.",
+						},
+						"depends_on": {
+							Type:          types.StringType,
+							Optional:      true,
+							Computed:      true,
+							PlanModifiers: []tfsdk.AttributePlanModifier{defaultValue(types.String{Value: ""})},
+							Description:   "Name of a `Bool` entry parameter that has to be true for this parameter to be shown.",
+						},
+						"default_value": {
+							Type:          types.StringType,
+							Optional:      true,
+							Computed:      true,
+							PlanModifiers: []tfsdk.AttributePlanModifier{defaultValue(types.String{Value: ""})},
+							Description:   "Default value of the entry parameter.",
+						},
+						"options": {
+							Type:          types.StringType,
+							Optional:      true,
+							Computed:      true,
+							PlanModifiers: []tfsdk.AttributePlanModifier{defaultValue(types.String{Value: ""})},
+							Description:   "Comma separated list of allowed values of a `MultipleChoice` entry parameter.",
+						},
+						"required_when_has_private_key": {
+							Type:          types.BoolType,
+							Optional:      true,
+							Computed:      true,
+							PlanModifiers: []tfsdk.AttributePlanModifier{defaultValue(types.Bool{Value: false})},
+							Description:   "Whether the parameter is required when the certificate has a private key. Defaults to `false`.",
+						},
+						"required_when_on_add": {
+							Type:          types.BoolType,
+							Optional:      true,
+							Computed:      true,
+							PlanModifiers: []tfsdk.AttributePlanModifier{defaultValue(types.Bool{Value: false})},
+							Description:   "Whether the parameter is required when adding a certificate. Defaults to `false`.",
+						},
+						"required_when_on_remove": {
+							Type:          types.BoolType,
+							Optional:      true,
+							Computed:      true,
+							PlanModifiers: []tfsdk.AttributePlanModifier{defaultValue(types.Bool{Value: false})},
+							Description:   "Whether the parameter is required when removing a certificate. Defaults to `false`.",
+						},
+						"required_when_on_reenrollment": {
+							Type:          types.BoolType,
+							Optional:      true,
+							Computed:      true,
+							PlanModifiers: []tfsdk.AttributePlanModifier{defaultValue(types.Bool{Value: false})},
+							Description:   "Whether the parameter is required when reenrolling a certificate. Defaults to `false`.",
+						},
+					},
+				),
+			},
+		},
+	}, nil
+}
+
+func (r resourceKeyfactorCertificateStoreTypeType) NewResource(_ context.Context, p tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	return resourceKeyfactorCertificateStoreType{
+		p: *(p.(*provider)),
+	}, nil
+}
+
+type resourceKeyfactorCertificateStoreType struct {
+	p provider
+}
+
+func (r resourceKeyfactorCertificateStoreType) ValidateConfig(ctx context.Context, request tfsdk.ValidateResourceConfigRequest, response *tfsdk.ValidateResourceConfigResponse) {
+	var config CertificateStoreType
+	diags := request.Config.Get(ctx, &config)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	policies := []string{STORE_TYPE_POLICY_FORBIDDEN, STORE_TYPE_POLICY_OPTIONAL, STORE_TYPE_POLICY_REQUIRED}
+	validateStoreTypeEnum(&response.Diagnostics, path.Root("private_key_allowed"), config.PrivateKeyAllowed, policies)
+	validateStoreTypeEnum(&response.Diagnostics, path.Root("custom_alias_allowed"), config.CustomAliasAllowed, policies)
+	validateStoreTypeEnum(&response.Diagnostics, path.Root("password_style"), config.PasswordStyle, []string{"Default", "Custom"})
+
+	propertyTypes := []string{
+		STORE_TYPE_PROPERTY_STRING,
+		STORE_TYPE_PROPERTY_BOOL,
+		STORE_TYPE_PROPERTY_MULTIPLE_CHOICE,
+		STORE_TYPE_PROPERTY_SECRET,
+	}
+	propertyNames := make(map[string]bool)
+	for i, property := range config.Properties {
+		validateStoreTypeEnum(&response.Diagnostics, path.Root("properties").AtListIndex(i).AtName("type"), property.Type, propertyTypes)
+		if property.Name.Unknown {
+			continue
+		}
+		if propertyNames[property.Name.Value] {
+			response.Diagnostics.AddAttributeError(
+				path.Root("properties").AtListIndex(i).AtName("name"),
+				ERR_SUMMARY_STORE_TYPE_CONFIG,
+				fmt.Sprintf("Property '%s' is defined more than once.", property.Name.Value),
+			)
+		}
+		propertyNames[property.Name.Value] = true
+	}
+
+	parameterNames := make(map[string]bool)
+	for i, parameter := range config.EntryParameters {
+		validateStoreTypeEnum(&response.Diagnostics, path.Root("entry_parameters").AtListIndex(i).AtName("type"), parameter.Type, propertyTypes[:3])
+		if parameter.Name.Unknown {
+			continue
+		}
+		if parameterNames[parameter.Name.Value] {
+			response.Diagnostics.AddAttributeError(
+				path.Root("entry_parameters").AtListIndex(i).AtName("name"),
+				ERR_SUMMARY_STORE_TYPE_CONFIG,
+				fmt.Sprintf("Entry parameter '%s' is defined more than once.", parameter.Name.Value),
+			)
+		}
+		parameterNames[parameter.Name.Value] = true
+	}
+}
+
+func (r resourceKeyfactorCertificateStoreType) Create(ctx context.Context, request tfsdk.CreateResourceRequest, response *tfsdk.CreateResourceResponse) {
+	if !r.p.configured {
+		response.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. This leads to weird stuff happening, so we'd prefer if you didn't do that. Thanks!",
+		)
+		return
+	}
+
+	var plan CertificateStoreType
+	diags := request.Plan.Get(ctx, &plan)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "short_name", plan.ShortName.Value)
+	tflog.Info(ctx, "Create called on certificate store type resource")
+
+	storeType, diags := expandCertificateStoreType(ctx, plan)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := r.p.client.CreateStoreType(storeType)
+	if err != nil {
+		response.Diagnostics.AddError(
+			ERR_SUMMARY_STORE_TYPE_CREATE,
+			fmt.Sprintf("Could not create certificate store type '%s' on Keyfactor Command: %s", plan.ShortName.Value, err.Error()),
+		)
+		return
+	}
+
+	result := flattenCertificateStoreType(created, plan)
+	diags = response.State.Set(ctx, result)
+	response.Diagnostics.Append(diags...)
+}
+
+func (r resourceKeyfactorCertificateStoreType) Read(ctx context.Context, request tfsdk.ReadResourceRequest, response *tfsdk.ReadResourceResponse) {
+	var state CertificateStoreType
+	diags := request.State.Get(ctx, &state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "id", state.ID.Value)
+	tflog.Info(ctx, "Read called on certificate store type resource")
+
+	storeType, err := r.p.client.GetCertificateStoreTypeById(int(state.ID.Value))
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "not found") {
+			response.Diagnostics.AddWarning(
+				ERR_SUMMARY_STORE_TYPE_READ,
+				fmt.Sprintf("Certificate store type '%v' no longer exists on Keyfactor Command and will be recreated.", state.ID.Value),
+			)
+			response.State.RemoveResource(ctx)
+			return
+		}
+		response.Diagnostics.AddError(
+			ERR_SUMMARY_STORE_TYPE_READ,
+			fmt.Sprintf("Could not read certificate store type '%v' from Keyfactor Command: %s", state.ID.Value, err.Error()),
+		)
+		return
+	}
+
+	result := flattenCertificateStoreType(storeType, state)
+	diags = response.State.Set(ctx, result)
+	response.Diagnostics.Append(diags...)
+}
+
+func (r resourceKeyfactorCertificateStoreType) Update(ctx context.Context, request tfsdk.UpdateResourceRequest, response *tfsdk.UpdateResourceResponse) {
+	var plan CertificateStoreType
+	diags := request.Plan.Get(ctx, &plan)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	var state CertificateStoreType
+	diags = request.State.Get(ctx, &state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "id", state.ID.Value)
+	tflog.Info(ctx, "Update called on certificate store type resource")
+
+	storeType, diags := expandCertificateStoreType(ctx, plan)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	storeType.StoreType = int(state.ID.Value)
+
+	updated, err := r.p.client.UpdateStoreType(storeType)
+	if err != nil {
+		response.Diagnostics.AddError(
+			ERR_SUMMARY_STORE_TYPE_UPDATE,
+			fmt.Sprintf("Could not update certificate store type '%s' on Keyfactor Command: %s", plan.ShortName.Value, err.Error()),
+		)
+		return
+	}
+
+	result := flattenCertificateStoreType(updated, plan)
+	diags = response.State.Set(ctx, result)
+	response.Diagnostics.Append(diags...)
+}
+
+func (r resourceKeyfactorCertificateStoreType) Delete(ctx context.Context, request tfsdk.DeleteResourceRequest, response *tfsdk.DeleteResourceResponse) {
+	var state CertificateStoreType
+	diags := request.State.Get(ctx, &state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "id", state.ID.Value)
+	tflog.Info(ctx, "Delete called on certificate store type resource")
+
+	_, err := r.p.client.DeleteCertificateStoreType(int(state.ID.Value))
+	if err != nil {
+		response.Diagnostics.AddError(
+			ERR_SUMMARY_STORE_TYPE_DELETE,
+			fmt.Sprintf("Could not delete certificate store type '%s' from Keyfactor Command. Certificate stores of this type have to be deleted first: %s", state.ShortName.Value, err.Error()),
+		)
+		return
+	}
+
+	response.State.RemoveResource(ctx)
+}
+
+func (r resourceKeyfactorCertificateStoreType) ImportState(ctx context.Context, request tfsdk.ImportResourceStateRequest, response *tfsdk.ImportResourceStateResponse) {
+	ctx = tflog.SetField(ctx, "import_id", request.ID)
+	tflog.Info(ctx, "Import called on certificate store type resource")
+
+	storeType, err := lookupCertificateStoreType(r.p.client, request.ID)
+	if err != nil {
+		response.Diagnostics.AddError(
+			ERR_SUMMARY_STORE_TYPE_READ,
+			fmt.Sprintf("Could not import certificate store type '%s' from Keyfactor Command: %s", request.ID, err.Error()),
+		)
+		return
+	}
+
+	result := flattenCertificateStoreType(storeType, CertificateStoreType{
+		JobProperties: types.List{ElemType: types.StringType, Null: true},
+	})
+	diags := response.State.Set(ctx, result)
+	response.Diagnostics.Append(diags...)
+}
+
+// lookupCertificateStoreType looks a certificate store type up by its integer ID, or by its short name otherwise.
+func lookupCertificateStoreType(kfClient *api.Client, identifier string) (*api.CertificateStoreType, error) {
+	if id, err := strconv.Atoi(identifier); err == nil {
+		return kfClient.GetCertificateStoreTypeById(id)
+	}
+	return kfClient.GetCertificateStoreTypeByName(identifier)
+}
+
+func validateStoreTypeEnum(diags *diag.Diagnostics, attributePath path.Path, value types.String, allowed []string) {
+	if value.Null || value.Unknown {
+		return
+	}
+	for _, v := range allowed {
+		if value.Value == v {
+			return
+		}
+	}
+	diags.AddAttributeError(
+		attributePath,
+		ERR_SUMMARY_STORE_TYPE_CONFIG,
+		fmt.Sprintf("'%s' is not valid. Valid values are: %s.", value.Value, strings.Join(allowed, ", ")),
+	)
+}
+
+func expandCertificateStoreType(ctx context.Context, plan CertificateStoreType) (*api.CertificateStoreType, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	capability := plan.ShortName.Value
+	if !plan.Capability.Null && !plan.Capability.Unknown {
+		capability = plan.Capability.Value
+	}
+
+	jobProperties := []string{}
+	if !plan.JobProperties.Null {
+		diags.Append(plan.JobProperties.ElementsAs(ctx, &jobProperties, false)...)
+	}
+
+	properties := []api.StoreTypePropertyDefinition{}
+	for _, property := range plan.Properties {
+		properties = append(properties, api.StoreTypePropertyDefinition{
+			Name:         property.Name.Value,
+			DisplayName:  property.DisplayName.Value,
+			Type:         property.Type.Value,
+			DependsOn:    property.DependsOn.Value,
+			DefaultValue: property.DefaultValue.Value,
+			Required:     property.Required.Value,
+		})
+	}
+
+	entryParameters := []api.EntryParameter{}
+	for _, parameter := range plan.EntryParameters {
+		entryParameters = append(entryParameters, api.EntryParameter{
+			Name:        parameter.Name.Value,
+			DisplayName: parameter.DisplayName.Value,
+			Type:        parameter.Type.Value,
+			RequiredWhen: map[string]bool{
+				"HasPrivateKey":  parameter.RequiredWhenHasPrivateKey.Value,
+				"OnAdd":          parameter.RequiredWhenOnAdd.Value,
+				"OnRemove":       parameter.RequiredWhenOnRemove.Value,
+				"OnReenrollment": parameter.RequiredWhenOnReenrollment.Value,
+			},
+			DependsOn:    parameter.DependsOn.Value,
+			DefaultValue: parameter.DefaultValue.Value,
+			Options:      parameter.Options.Value,
+		})
+	}
+
+	return &api.CertificateStoreType{
+		Name:       plan.Name.Value,
+		ShortName:  plan.ShortName.Value,
+		Capability: capability,
+		LocalStore: plan.LocalStore.Value,
+		SupportedOperations: &api.StoreTypeSupportedOperations{
+			Add:        plan.SupportsAdd.Value,
+			Create:     plan.SupportsCreate.Value,
+			Discovery:  plan.SupportsDiscovery.Value,
+			Enrollment: plan.SupportsReenrollment.Value,
+			Remove:     plan.SupportsRemove.Value,
+		},
+		Properties:      &properties,
+		EntryParameters: &entryParameters,
+		PasswordOptions: &api.StoreTypePasswordOptions{
+			EntrySupported: plan.SupportsEntryPassword.Value,
+			StoreRequired:  plan.StorePasswordRequired.Value,
+			Style:          plan.PasswordStyle.Value,
+		},
+		PrivateKeyAllowed:  plan.PrivateKeyAllowed.Value,
+		JobProperties:      &jobProperties,
+		ServerRequired:     plan.ServerRequired.Value,
+		BlueprintAllowed:   plan.BlueprintAllowed.Value,
+		CustomAliasAllowed: plan.CustomAliasAllowed.Value,
+	}, diags
+}
+
+// flattenCertificateStoreType converts a store type returned by Keyfactor Command to its Terraform model. Lists that
+// are null in prior stay null while Keyfactor Command returns them empty.
+func flattenCertificateStoreType(storeType *api.CertificateStoreType, prior CertificateStoreType) CertificateStoreType {
+	result := CertificateStoreType{
+		ID:                 types.Int64{Value: int64(storeType.StoreType)},
+		Name:               types.String{Value: storeType.Name},
+		ShortName:          types.String{Value: storeType.ShortName},
+		Capability:         types.String{Value: storeType.Capability},
+		LocalStore:         types.Bool{Value: storeType.LocalStore},
+		ServerRequired:     types.Bool{Value: storeType.ServerRequired},
+		BlueprintAllowed:   types.Bool{Value: storeType.BlueprintAllowed},
+		PrivateKeyAllowed:  types.String{Value: storeType.PrivateKeyAllowed},
+		CustomAliasAllowed: types.String{Value: storeType.CustomAliasAllowed},
+		PasswordStyle:      types.String{Value: "Default"},
+		JobProperties:      types.List{ElemType: types.StringType, Null: true},
+	}
+
+	if storeType.SupportedOperations != nil {
+		result.SupportsAdd = types.Bool{Value: storeType.SupportedOperations.Add}
+		result.SupportsRemove = types.Bool{Value: storeType.SupportedOperations.Remove}
+		result.SupportsCreate = types.Bool{Value: storeType.SupportedOperations.Create}
+		result.SupportsDiscovery = types.Bool{Value: storeType.SupportedOperations.Discovery}
+		result.SupportsReenrollment = types.Bool{Value: storeType.SupportedOperations.Enrollment}
+	}
+	if storeType.PasswordOptions != nil {
+		result.SupportsEntryPassword = types.Bool{Value: storeType.PasswordOptions.EntrySupported}
+		result.StorePasswordRequired = types.Bool{Value: storeType.PasswordOptions.StoreRequired}
+		if storeType.PasswordOptions.Style != "" {
+			result.PasswordStyle = types.String{Value: storeType.PasswordOptions.Style}
+		}
+	}
+
+	if storeType.JobProperties != nil {
+		result.JobProperties = flattenStringList(*storeType.JobProperties)
+		if result.JobProperties.Null && !prior.JobProperties.Null {
+			result.JobProperties.Null = false
+		}
+	}
+
+	if storeType.Properties != nil && (len(*storeType.Properties) > 0 || prior.Properties != nil) {
+		result.Properties = []CertificateStoreTypeProperty{}
+		for _, property := range *storeType.Properties {
+			result.Properties = append(result.Properties, CertificateStoreTypeProperty{
+				Name:         types.String{Value: property.Name},
+				DisplayName:  types.String{Value: property.DisplayName},
+				Type:         types.String{Value: property.Type},
+				DependsOn:    types.String{Value: property.DependsOn},
+				DefaultValue: types.String{Value: storeTypePropertyDefault(property.DefaultValue)},
+				Required:     types.Bool{Value: property.Required},
+			})
+		}
+	}
+
+	if storeType.EntryParameters != nil && (len(*storeType.EntryParameters) > 0 || prior.EntryParameters != nil) {
+		result.EntryParameters = []CertificateStoreTypeEntryParameter{}
+		for _, parameter := range *storeType.EntryParameters {
+			result.EntryParameters = append(result.EntryParameters, CertificateStoreTypeEntryParameter{
+				Name:                       types.String{Value: parameter.Name},
+				DisplayName:                types.String{Value: parameter.DisplayName},
+				Type:                       types.String{Value: parameter.Type},
+				DependsOn:                  types.String{Value: parameter.DependsOn},
+				DefaultValue:               types.String{Value: parameter.DefaultValue},
+				Options:                    types.String{Value: parameter.Options},
+				RequiredWhenHasPrivateKey:  types.Bool{Value: parameter.RequiredWhen["HasPrivateKey"]},
+				RequiredWhenOnAdd:          types.Bool{Value: parameter.RequiredWhen["OnAdd"]},
+				RequiredWhenOnRemove:       types.Bool{Value: parameter.RequiredWhen["OnRemove"]},
+				RequiredWhenOnReenrollment: types.Bool{Value: parameter.RequiredWhen["OnReenrollment"]},
+			})
+		}
+	}
+
+	return result
+}
+
+// storeTypePropertyDefault returns the default value of a store type property as a string, Keyfactor Command returns
+// the defaults of Bool properties as JSON booleans.
+func storeTypePropertyDefault(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// storeTypePropertyChoices returns the allowed values of a MultipleChoice store type property, which Keyfactor Command
+// keeps as a comma separated list in the default value of the property.
+func storeTypePropertyChoices(property api.StoreTypePropertyDefinition) []string {
+	if property.Type != STORE_TYPE_PROPERTY_MULTIPLE_CHOICE {
+		return nil
+	}
+	var choices []string
+	for _, choice := range strings.Split(storeTypePropertyDefault(property.DefaultValue), ",") {
+		choice = strings.TrimSpace(choice)
+		if choice != "" {
+			choices = append(choices, choice)
+		}
+	}
+	return choices
+}
+
+// storeTypePropertyDefaultValue returns the value a certificate store gets for a property that is not configured.
+func storeTypePropertyDefaultValue(property api.StoreTypePropertyDefinition) string {
+	if property.Type == STORE_TYPE_PROPERTY_MULTIPLE_CHOICE {
+		choices := storeTypePropertyChoices(property)
+		if len(choices) == 0 {
+			return ""
+		}
+		return choices[0]
+	}
+	return storeTypePropertyDefault(property.DefaultValue)
+}
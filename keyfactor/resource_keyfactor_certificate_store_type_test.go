@@ -0,0 +1,106 @@
+package keyfactor
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+type certificateStoreTypeTestCase struct {
+	name         string
+	shortName    string
+	supportsAdd  bool
+	resourceName string
+}
+
+func TestAccKeyfactorCertificateStoreTypeResource(t *testing.T) {
+
+	r := certificateStoreTypeTestCase{
+		name:         "Terraform Acceptance Test",
+		shortName:    "TFAccTest",
+		supportsAdd:  false,
+		resourceName: "keyfactor_certificate_store_type.tf_acc_test",
+	}
+	r2 := r
+	r2.name = "Terraform Acceptance Test Updated"
+	r2.supportsAdd = true
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccKeyfactorCertificateStoreTypeResourceConfig(r),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(r.resourceName, "id"),
+					resource.TestCheckResourceAttr(r.resourceName, "name", r.name),
+					resource.TestCheckResourceAttr(r.resourceName, "short_name", r.shortName),
+					resource.TestCheckResourceAttr(r.resourceName, "capability", r.shortName),
+					resource.TestCheckResourceAttr(r.resourceName, "supports_add", "false"),
+					resource.TestCheckResourceAttr(r.resourceName, "private_key_allowed", "Optional"),
+					resource.TestCheckResourceAttr(r.resourceName, "custom_alias_allowed", "Required"),
+					resource.TestCheckResourceAttr(r.resourceName, "properties.#", "2"),
+					resource.TestCheckResourceAttr(r.resourceName, "properties.1.default_value", "true"),
+					resource.TestCheckResourceAttr(r.resourceName, "entry_parameters.#", "1"),
+					resource.TestCheckResourceAttr(r.resourceName, "entry_parameters.0.required_when_on_add", "true"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      r.resourceName,
+				ImportState:       true,
+				ImportStateId:     r.shortName,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccKeyfactorCertificateStoreTypeResourceConfig(r2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(r2.resourceName, "id"),
+					resource.TestCheckResourceAttr(r2.resourceName, "name", r2.name),
+					resource.TestCheckResourceAttr(r2.resourceName, "supports_add", "true"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccKeyfactorCertificateStoreTypeResourceConfig(t certificateStoreTypeTestCase) string {
+	output := fmt.Sprintf(`
+resource "keyfactor_certificate_store_type" "tf_acc_test" {
+  name                 = "%s"
+  short_name           = "%s"
+  supports_add         = %t
+  supports_remove      = true
+  private_key_allowed  = "Optional"
+  custom_alias_allowed = "Required"
+  job_properties       = ["Description"]
+  properties = [
+    {
+      name          = "Region"
+      display_name  = "Region"
+      type          = "MultipleChoice"
+      default_value = "us-east-1,us-west-2"
+      required      = true
+    },
+    {
+      name          = "IsTrustStore"
+      display_name  = "Trust Store"
+      type          = "Bool"
+      default_value = "true"
+    },
+  ]
+  entry_parameters = [
+    {
+      name                 = "Tag"
+      display_name         = "Tag"
+      type                 = "String"
+      required_when_on_add = true
+    },
+  ]
+}
+`, t.name, t.shortName, t.supportsAdd)
+	return output
+}
@@ -2,6 +2,7 @@ package keyfactor
 
 import (
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"os"
 	"strconv"
@@ -551,3 +552,45 @@ resource "keyfactor_certificate" "PFXCertificate" {
 `, CsrContent, t.ipSans, t.dnsSans, t.keyPassword, t.ca, t.template, t.email)
 	return output
 }
+
+func TestKeepUnsetADSubject(t *testing.T) {
+	issued := KeyfactorCertificate{
+		CommonName:         types.String{Value: "terraform_test_certificate"},
+		Organization:       types.String{Value: "Keyfactor Inc."},
+		OrganizationalUnit: types.String{Value: "Integrations Engineering"},
+		Locality:           types.String{Value: "Independence"},
+		State:              types.String{Value: "OH"},
+		Country:            types.String{Value: "US"},
+	}
+	state := KeyfactorCertificate{
+		CommonName:         types.String{Value: "terraform_test_certificate"},
+		Organization:       types.String{Value: "Keyfactor"},
+		OrganizationalUnit: types.String{Null: true},
+		Locality:           types.String{Null: true},
+		State:              types.String{Value: "OH"},
+		Country:            types.String{Null: true},
+	}
+
+	result := issued
+	keepUnsetADSubject(&result, state)
+
+	tests := []struct {
+		name string
+		got  types.String
+		want types.String
+	}{
+		{name: "common_name", got: result.CommonName, want: issued.CommonName},
+		{name: "organization", got: result.Organization, want: issued.Organization},
+		{name: "organizational_unit", got: result.OrganizationalUnit, want: types.String{Null: true}},
+		{name: "locality", got: result.Locality, want: types.String{Null: true}},
+		{name: "state", got: result.State, want: issued.State},
+		{name: "country", got: result.Country, want: types.String{Null: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.got.Equal(tt.want) {
+				t.Errorf("keepUnsetADSubject() %s = %v, want %v", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+}
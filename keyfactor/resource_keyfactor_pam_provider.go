@@ -0,0 +1,455 @@
+package keyfactor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// pamProvider is the Keyfactor Command model of a PAM provider instance. The client has no PAM support, so PAM
+// providers are managed with commandRequest.
+type pamProvider struct {
+	Id                      int                     `json:"Id,omitempty"`
+	Name                    string                  `json:"Name"`
+	Area                    int                     `json:"Area"`
+	ProviderType            pamProviderType         `json:"ProviderType"`
+	ProviderTypeParamValues []pamProviderParamValue `json:"ProviderTypeParamValues"`
+}
+
+type pamProviderType struct {
+	Id         string                 `json:"Id"`
+	Name       string                 `json:"Name,omitempty"`
+	Parameters []pamProviderTypeParam `json:"Parameters,omitempty"`
+}
+
+type pamProviderTypeParam struct {
+	Id            int    `json:"Id"`
+	Name          string `json:"Name"`
+	DisplayName   string `json:"DisplayName,omitempty"`
+	DataType      int    `json:"DataType,omitempty"`
+	InstanceLevel bool   `json:"InstanceLevel"`
+}
+
+type pamProviderParamValue struct {
+	Id int `json:"Id,omitempty"`
+	// Value is a string, Command doesn't return the value of secret parameters
+	Value             interface{}          `json:"Value"`
+	ProviderTypeParam pamProviderTypeParam `json:"ProviderTypeParam"`
+}
+
+type resourcePamProviderType struct{}
+
+func (r resourcePamProviderType) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:          types.Int64Type,
+				Computed:      true,
+				Description:   "Keyfactor Command integer ID of the PAM provider.",
+				PlanModifiers: []tfsdk.AttributePlanModifier{tfsdk.UseStateForUnknown()},
+			},
+			"name": {
+				Type:        types.StringType,
+				Required:    true,
+				Description: "Name of the PAM provider. Certificate stores reference the PAM provider by this name in `server_username_pam`, `server_password_pam` and `store_password_pam`.",
+			},
+			"provider_type": {
+				Type:          types.StringType,
+				Required:      true,
+				Description:   "Name of the PAM provider type, such as `Delinea-SecretServer` or `CyberArk-SdkCredentialProvider`. See the `keyfactor_pam_provider_types` data source for the installed types.",
+				PlanModifiers: []tfsdk.AttributePlanModifier{tfsdk.RequiresReplace()},
+			},
+			"area": {
+				Type:          types.Int64Type,
+				Optional:      true,
+				Computed:      true,
+				Description:   "Area of Keyfactor Command the PAM provider is used in. Defaults to `1`, certificate stores.",
+				PlanModifiers: []tfsdk.AttributePlanModifier{defaultValue(types.Int64{Value: 1})},
+			},
+			"parameters": {
+				Type:        types.MapType{ElemType: types.StringType},
+				Optional:    true,
+				Description: "Values of the provider type parameters that aren't secret, such as `Host`, by parameter name.",
+			},
+			"secret_parameters": {
+				Type:      types.MapType{ElemType: types.StringType},
+				Optional:  true,
+				Sensitive: true,
+				Description: "Values of the secret provider type parameters, such as `Password`, by parameter name. The values are kept " +
+					"in Terraform state, so protect the state accordingly. Keyfactor Command doesn't return secret parameters, so changes " +
+					"made outside of Terraform aren't detected.",
+			},
+		},
+	}, nil
+}
+
+func (r resourcePamProviderType) NewResource(_ context.Context, p tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	return resourcePamProvider{
+		p: *(p.(*provider)),
+	}, nil
+}
+
+type resourcePamProvider struct {
+	p provider
+}
+
+func (r resourcePamProvider) Create(ctx context.Context, request tfsdk.CreateResourceRequest, response *tfsdk.CreateResourceResponse) {
+	if !r.p.configured {
+		response.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. This leads to weird stuff happening, so we'd prefer if you didn't do that. Thanks!",
+		)
+		return
+	}
+
+	var plan PamProvider
+	diags := request.Plan.Get(ctx, &plan)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "name", plan.Name.Value)
+	tflog.Info(ctx, "Create called on PAM provider resource")
+
+	body, diags := expandPamProvider(ctx, r.p, plan, nil)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	var created pamProvider
+	err := r.p.commandRequest(ctx, http.MethodPost, "PamProviders", nil, body, &created)
+	if err != nil {
+		response.Diagnostics.AddError(
+			ERR_SUMMARY_PAM_PROVIDER_CREATE,
+			fmt.Sprintf("Could not create PAM provider '%s' on Keyfactor Command: %s", plan.Name.Value, err.Error()),
+		)
+		return
+	}
+
+	result := flattenPamProvider(&created, plan, false)
+	diags = response.State.Set(ctx, result)
+	response.Diagnostics.Append(diags...)
+}
+
+func (r resourcePamProvider) Read(ctx context.Context, request tfsdk.ReadResourceRequest, response *tfsdk.ReadResourceResponse) {
+	var state PamProvider
+	diags := request.State.Get(ctx, &state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "id", state.ID.Value)
+	tflog.Info(ctx, "Read called on PAM provider resource")
+
+	remote, err := getPamProvider(ctx, r.p, int(state.ID.Value))
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "404") || strings.Contains(strings.ToLower(err.Error()), "not found") {
+			response.Diagnostics.AddWarning(
+				ERR_SUMMARY_PAM_PROVIDER_READ,
+				fmt.Sprintf("PAM provider '%v' no longer exists on Keyfactor Command and will be recreated.", state.ID.Value),
+			)
+			response.State.RemoveResource(ctx)
+			return
+		}
+		response.Diagnostics.AddError(
+			ERR_SUMMARY_PAM_PROVIDER_READ,
+			fmt.Sprintf("Could not read PAM provider '%v' from Keyfactor Command: %s", state.ID.Value, err.Error()),
+		)
+		return
+	}
+
+	result := flattenPamProvider(remote, state, false)
+	diags = response.State.Set(ctx, result)
+	response.Diagnostics.Append(diags...)
+}
+
+func (r resourcePamProvider) Update(ctx context.Context, request tfsdk.UpdateResourceRequest, response *tfsdk.UpdateResourceResponse) {
+	var plan PamProvider
+	diags := request.Plan.Get(ctx, &plan)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	var state PamProvider
+	diags = request.State.Get(ctx, &state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "id", state.ID.Value)
+	tflog.Info(ctx, "Update called on PAM provider resource")
+
+	existing, err := getPamProvider(ctx, r.p, int(state.ID.Value))
+	if err != nil {
+		response.Diagnostics.AddError(
+			ERR_SUMMARY_PAM_PROVIDER_UPDATE,
+			fmt.Sprintf("Could not read PAM provider '%v' from Keyfactor Command: %s", state.ID.Value, err.Error()),
+		)
+		return
+	}
+
+	body, diags := expandPamProvider(ctx, r.p, plan, existing)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	body.Id = int(state.ID.Value)
+
+	var updated pamProvider
+	err = r.p.commandRequest(ctx, http.MethodPut, "PamProviders", nil, body, &updated)
+	if err != nil {
+		response.Diagnostics.AddError(
+			ERR_SUMMARY_PAM_PROVIDER_UPDATE,
+			fmt.Sprintf("Could not update PAM provider '%s' on Keyfactor Command: %s", plan.Name.Value, err.Error()),
+		)
+		return
+	}
+
+	result := flattenPamProvider(&updated, plan, false)
+	diags = response.State.Set(ctx, result)
+	response.Diagnostics.Append(diags...)
+}
+
+func (r resourcePamProvider) Delete(ctx context.Context, request tfsdk.DeleteResourceRequest, response *tfsdk.DeleteResourceResponse) {
+	var state PamProvider
+	diags := request.State.Get(ctx, &state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "id", state.ID.Value)
+	tflog.Info(ctx, "Delete called on PAM provider resource")
+
+	err := r.p.commandRequest(ctx, http.MethodDelete, "PamProviders/"+strconv.FormatInt(state.ID.Value, 10), nil, nil, nil)
+	if err != nil {
+		response.Diagnostics.AddError(
+			ERR_SUMMARY_PAM_PROVIDER_DELETE,
+			fmt.Sprintf("Could not delete PAM provider '%s' from Keyfactor Command. Certificate stores that use it have to be updated first: %s", state.Name.Value, err.Error()),
+		)
+		return
+	}
+
+	response.State.RemoveResource(ctx)
+}
+
+func (r resourcePamProvider) ImportState(ctx context.Context, request tfsdk.ImportResourceStateRequest, response *tfsdk.ImportResourceStateResponse) {
+	ctx = tflog.SetField(ctx, "import_id", request.ID)
+	tflog.Info(ctx, "Import called on PAM provider resource")
+
+	var remote *pamProvider
+	var err error
+	if id, convErr := strconv.Atoi(request.ID); convErr == nil {
+		remote, err = getPamProvider(ctx, r.p, id)
+	} else {
+		remote, err = lookupPamProvider(ctx, r.p, request.ID)
+	}
+	if err != nil {
+		response.Diagnostics.AddError(
+			ERR_SUMMARY_PAM_PROVIDER_READ,
+			fmt.Sprintf("Could not import PAM provider '%s' from Keyfactor Command: %s", request.ID, err.Error()),
+		)
+		return
+	}
+
+	result := flattenPamProvider(remote, PamProvider{
+		Parameters:       types.Map{ElemType: types.StringType, Null: true},
+		SecretParameters: types.Map{ElemType: types.StringType, Null: true},
+	}, true)
+	diags := response.State.Set(ctx, result)
+	response.Diagnostics.Append(diags...)
+}
+
+// lookupPamProvider returns the PAM provider with the given name.
+func lookupPamProvider(ctx context.Context, p provider, name string) (*pamProvider, error) {
+	params := url.Values{}
+	params.Set("pq.queryString", fmt.Sprintf("Name -eq \"%s\"", name))
+	var providers []pamProvider
+	err := p.commandRequest(ctx, http.MethodGet, "PamProviders", params, nil, &providers)
+	if err != nil {
+		return nil, err
+	}
+	for i := range providers {
+		if providers[i].Name == name {
+			return &providers[i], nil
+		}
+	}
+	return nil, fmt.Errorf("PAM provider '%s' not found", name)
+}
+
+// getPamProvider returns the PAM provider with the given ID.
+func getPamProvider(ctx context.Context, p provider, id int) (*pamProvider, error) {
+	var result pamProvider
+	err := p.commandRequest(ctx, http.MethodGet, "PamProviders/"+strconv.Itoa(id), nil, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// getPamProviderTypes returns the PAM provider types installed in Keyfactor Command.
+func getPamProviderTypes(ctx context.Context, p provider) ([]pamProviderType, error) {
+	var providerTypes []pamProviderType
+	err := p.commandRequest(ctx, http.MethodGet, "PamProviders/Types", nil, nil, &providerTypes)
+	if err != nil {
+		return nil, err
+	}
+	return providerTypes, nil
+}
+
+// expandPamProvider converts a PAM provider resource to the Keyfactor Command model. Parameters are validated against
+// the provider type, and the IDs of the values of existing are kept so they are updated in place.
+func expandPamProvider(ctx context.Context, p provider, plan PamProvider, existing *pamProvider) (*pamProvider, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	parameters := map[string]string{}
+	if !plan.Parameters.Null {
+		diags.Append(plan.Parameters.ElementsAs(ctx, &parameters, false)...)
+	}
+	secretParameters := map[string]string{}
+	if !plan.SecretParameters.Null {
+		diags.Append(plan.SecretParameters.ElementsAs(ctx, &secretParameters, false)...)
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	providerTypes, err := getPamProviderTypes(ctx, p)
+	if err != nil {
+		diags.AddError(
+			ERR_SUMMARY_PAM_PROVIDER_CONFIG,
+			fmt.Sprintf("Could not retrieve PAM provider types from Keyfactor Command: %s", err.Error()),
+		)
+		return nil, diags
+	}
+	var providerType *pamProviderType
+	var typeNames []string
+	for i := range providerTypes {
+		typeNames = append(typeNames, providerTypes[i].Name)
+		if providerTypes[i].Name == plan.ProviderType.Value {
+			providerType = &providerTypes[i]
+		}
+	}
+	if providerType == nil {
+		sort.Strings(typeNames)
+		diags.AddAttributeError(
+			path.Root("provider_type"),
+			ERR_SUMMARY_PAM_PROVIDER_CONFIG,
+			fmt.Sprintf("PAM provider type '%s' is not installed in Keyfactor Command. Installed types are: %s.", plan.ProviderType.Value, strings.Join(typeNames, ", ")),
+		)
+		return nil, diags
+	}
+
+	existingIds := map[string]int{}
+	if existing != nil {
+		for _, value := range existing.ProviderTypeParamValues {
+			existingIds[value.ProviderTypeParam.Name] = value.Id
+		}
+	}
+
+	definitions := map[string]pamProviderTypeParam{}
+	var names, secretNames []string
+	for _, param := range providerType.Parameters {
+		if param.InstanceLevel {
+			// Instance level parameters identify a secret and are set by the certificate stores
+			continue
+		}
+		definitions[param.Name] = param
+		if param.DataType == PAM_PARAMETER_SECRET {
+			secretNames = append(secretNames, param.Name)
+		} else {
+			names = append(names, param.Name)
+		}
+	}
+	sort.Strings(names)
+	sort.Strings(secretNames)
+
+	result := &pamProvider{
+		Name:                    plan.Name.Value,
+		Area:                    int(plan.Area.Value),
+		ProviderType:            pamProviderType{Id: providerType.Id},
+		ProviderTypeParamValues: []pamProviderParamValue{},
+	}
+	expandValues := func(attribute string, values map[string]string, secret bool, valid []string) {
+		for name, value := range values {
+			definition, ok := definitions[name]
+			if !ok || (definition.DataType == PAM_PARAMETER_SECRET) != secret {
+				diags.AddAttributeError(
+					path.Root(attribute).AtMapKey(name),
+					ERR_SUMMARY_PAM_PROVIDER_CONFIG,
+					fmt.Sprintf("'%s' is not a parameter of '%s' for PAM provider type '%s'. Valid parameters are: %s.", name, attribute, providerType.Name, strings.Join(valid, ", ")),
+				)
+				continue
+			}
+			result.ProviderTypeParamValues = append(result.ProviderTypeParamValues, pamProviderParamValue{
+				Id:                existingIds[name],
+				Value:             value,
+				ProviderTypeParam: definition,
+			})
+		}
+	}
+	expandValues("parameters", parameters, false, names)
+	expandValues("secret_parameters", secretParameters, true, secretNames)
+	return result, diags
+}
+
+// flattenPamProvider converts a PAM provider read from Keyfactor Command to the resource model. Only the parameters
+// in prior are returned, unless the provider is imported. Secret parameters aren't returned by Command and are kept
+// from prior.
+func flattenPamProvider(remote *pamProvider, prior PamProvider, imported bool) PamProvider {
+	parameters := types.Map{ElemType: types.StringType, Elems: map[string]attr.Value{}}
+	for _, value := range remote.ProviderTypeParamValues {
+		param := value.ProviderTypeParam
+		if param.InstanceLevel || param.DataType == PAM_PARAMETER_SECRET {
+			continue
+		}
+		flattened, _ := value.Value.(string)
+		_, inPrior := prior.Parameters.Elems[param.Name]
+		if !imported && !inPrior {
+			// Parameters that aren't configured, such as provider type defaults, would be a perpetual diff
+			continue
+		}
+		if !inPrior && flattened == "" {
+			continue
+		}
+		parameters.Elems[param.Name] = types.String{Value: flattened}
+	}
+	if prior.Parameters.Null && len(parameters.Elems) == 0 {
+		parameters.Null = true
+	}
+
+	secretParameters := prior.SecretParameters
+	if secretParameters.ElemType == nil {
+		secretParameters = types.Map{ElemType: types.StringType, Null: true}
+	}
+	providerType := types.String{Value: remote.ProviderType.Name}
+	if remote.ProviderType.Name == "" {
+		// Command only returns the ID of the provider type after create and update
+		providerType = prior.ProviderType
+	}
+
+	return PamProvider{
+		ID:               types.Int64{Value: int64(remote.Id)},
+		Name:             types.String{Value: remote.Name},
+		ProviderType:     providerType,
+		Area:             types.Int64{Value: int64(remote.Area)},
+		Parameters:       parameters,
+		SecretParameters: secretParameters,
+	}
+}
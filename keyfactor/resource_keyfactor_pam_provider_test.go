@@ -0,0 +1,144 @@
+package keyfactor
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"os"
+	"testing"
+)
+
+type pamProviderTestCase struct {
+	name         string
+	providerType string
+	host         string
+	username     string
+	password     string
+	resourceName string
+}
+
+func TestAccKeyfactorPamProviderResource(t *testing.T) {
+
+	r := pamProviderTestCase{
+		name:         "Terraform Acceptance Test",
+		providerType: os.Getenv("KEYFACTOR_PAM_PROVIDER_TYPE"),
+		host:         os.Getenv("KEYFACTOR_PAM_PROVIDER_HOST"),
+		username:     os.Getenv("KEYFACTOR_PAM_PROVIDER_USERNAME"),
+		password:     os.Getenv("KEYFACTOR_PAM_PROVIDER_PASSWORD"),
+		resourceName: "keyfactor_pam_provider.tf_acc_test",
+	}
+	if r.providerType == "" {
+		r.providerType = "Delinea-SecretServer"
+	}
+	r2 := r
+	r2.name = "Terraform Acceptance Test Updated"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccKeyfactorPamProviderResourceConfig(r),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(r.resourceName, "id"),
+					resource.TestCheckResourceAttr(r.resourceName, "name", r.name),
+					resource.TestCheckResourceAttr(r.resourceName, "provider_type", r.providerType),
+					resource.TestCheckResourceAttr(r.resourceName, "area", "1"),
+					resource.TestCheckResourceAttr(r.resourceName, "parameters.Host", r.host),
+					resource.TestCheckResourceAttr(r.resourceName, "secret_parameters.%", "1"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            r.resourceName,
+				ImportState:             true,
+				ImportStateId:           r.name,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"secret_parameters"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccKeyfactorPamProviderResourceConfig(r2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(r2.resourceName, "id"),
+					resource.TestCheckResourceAttr(r2.resourceName, "name", r2.name),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccKeyfactorPamProviderResourceConfig(t pamProviderTestCase) string {
+	output := fmt.Sprintf(`
+resource "keyfactor_pam_provider" "tf_acc_test" {
+  name          = "%s"
+  provider_type = "%s"
+  parameters = {
+    Host     = "%s"
+    Username = "%s"
+  }
+  secret_parameters = {
+    Password = "%s"
+  }
+}
+`, t.name, t.providerType, t.host, t.username, t.password)
+	return output
+}
+
+func TestFlattenPamProviderParameters(t *testing.T) {
+	remote := &pamProvider{
+		Id:   1,
+		Name: "tf_acc_test",
+		Area: 1,
+		ProviderTypeParamValues: []pamProviderParamValue{
+			{Value: "vault.example.com", ProviderTypeParam: pamProviderTypeParam{Name: "Host"}},
+			{Value: "443", ProviderTypeParam: pamProviderTypeParam{Name: "Port"}},
+			{Value: "", ProviderTypeParam: pamProviderTypeParam{Name: "Domain"}},
+			{ProviderTypeParam: pamProviderTypeParam{Name: "Password", DataType: PAM_PARAMETER_SECRET}},
+			{Value: "1", ProviderTypeParam: pamProviderTypeParam{Name: "SecretId", InstanceLevel: true}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		prior    types.Map
+		imported bool
+		want     types.Map
+	}{
+		{
+			name:  "not configured",
+			prior: types.Map{ElemType: types.StringType, Null: true},
+			want:  types.Map{ElemType: types.StringType, Null: true},
+		},
+		{
+			name: "configured parameters only",
+			prior: types.Map{ElemType: types.StringType, Elems: map[string]attr.Value{
+				"Host": types.String{Value: "old.example.com"},
+			}},
+			want: types.Map{ElemType: types.StringType, Elems: map[string]attr.Value{
+				"Host": types.String{Value: "vault.example.com"},
+			}},
+		},
+		{
+			name:     "imported",
+			prior:    types.Map{ElemType: types.StringType, Null: true},
+			imported: true,
+			want: types.Map{ElemType: types.StringType, Elems: map[string]attr.Value{
+				"Host": types.String{Value: "vault.example.com"},
+				"Port": types.String{Value: "443"},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := flattenPamProvider(remote, PamProvider{Parameters: tt.prior}, tt.imported)
+			if !got.Parameters.Equal(tt.want) {
+				t.Errorf("flattenPamProvider() parameters = %v, want %v", got.Parameters, tt.want)
+			}
+		})
+	}
+}
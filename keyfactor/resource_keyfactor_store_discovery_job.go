@@ -0,0 +1,363 @@
+package keyfactor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Keyfactor/keyfactor-go-client/v2/api"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// storeDiscoveryJobRequest is the body of the Keyfactor Command certificate store discovery job request, which the
+// client doesn't support. The server credentials are either an api.SecretParamValue or a PAM reference.
+type storeDiscoveryJobRequest struct {
+	ClientMachine  string                  `json:"ClientMachine"`
+	AgentId        string                  `json:"AgentId"`
+	Type           int                     `json:"Type"`
+	Schedule       *storeInventorySchedule `json:"Schedule"`
+	Dirs           string                  `json:"Dirs"`
+	IgnoredDirs    string                  `json:"IgnoredDirs,omitempty"`
+	Extensions     string                  `json:"Extensions,omitempty"`
+	NamePatterns   string                  `json:"NamePatterns,omitempty"`
+	SymLinks       bool                    `json:"SymLinks"`
+	ServerUsername interface{}             `json:"ServerUsername,omitempty"`
+	ServerPassword interface{}             `json:"ServerPassword,omitempty"`
+	ServerUseSsl   bool                    `json:"ServerUseSsl"`
+}
+
+type resourceStoreDiscoveryJobType struct{}
+
+func (r resourceStoreDiscoveryJobType) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:          types.StringType,
+				Computed:      true,
+				Description:   "Identifier of the discovery job in Terraform, the concatenation of `agent_id` and `store_type`. Keyfactor Command doesn't return an ID for discovery jobs.",
+				PlanModifiers: []tfsdk.AttributePlanModifier{tfsdk.UseStateForUnknown()},
+			},
+			"agent_identifier": {
+				Type:          types.StringType,
+				Required:      true,
+				Description:   "Agent GUID or client machine name of the orchestrator that runs the discovery job. The first approved agent that matches is used.",
+				PlanModifiers: []tfsdk.AttributePlanModifier{tfsdk.RequiresReplace()},
+			},
+			"agent_id": {
+				Type:          types.StringType,
+				Computed:      true,
+				Description:   "Keyfactor Command GUID of the orchestrator that runs the discovery job.",
+				PlanModifiers: []tfsdk.AttributePlanModifier{tfsdk.UseStateForUnknown()},
+			},
+			"client_machine": {
+				Type:          types.StringType,
+				Optional:      true,
+				Computed:      true,
+				Description:   "Machine to search for certificate stores. Defaults to the client machine of the orchestrator, set it for store types that discover stores on remote machines.",
+				PlanModifiers: []tfsdk.AttributePlanModifier{tfsdk.UseStateForUnknown()},
+			},
+			"store_type": {
+				Type:          types.StringType,
+				Required:      true,
+				Description:   "Short name of the certificate store type to discover. The store type must support discovery.",
+				PlanModifiers: []tfsdk.AttributePlanModifier{tfsdk.RequiresReplace()},
+			},
+			"directories": {
+				Type:        types.ListType{ElemType: types.StringType},
+				Required:    true,
+				Description: "Directories to search for certificate stores. Use `fullscan` to search all directories.",
+			},
+			"ignored_directories": {
+				Type:        types.ListType{ElemType: types.StringType},
+				Optional:    true,
+				Description: "Directories to skip while searching.",
+			},
+			"extensions": {
+				Type:        types.ListType{ElemType: types.StringType},
+				Optional:    true,
+				Description: "File extensions to search for, such as `jks`. Use `noext` to also search files without an extension.",
+			},
+			"name_patterns": {
+				Type:        types.ListType{ElemType: types.StringType},
+				Optional:    true,
+				Description: "Partial file names to search for, such as `keystore`.",
+			},
+			"follow_symlinks": {
+				Type:          types.BoolType,
+				Optional:      true,
+				Computed:      true,
+				Description:   "Whether to follow symbolic links while searching. Defaults to `false`.",
+				PlanModifiers: []tfsdk.AttributePlanModifier{defaultValue(types.Bool{Value: false})},
+			},
+			"server_username": {
+				Type:        types.StringType,
+				Optional:    true,
+				Description: "The username to access the machine that is searched, for store types that discover stores on remote machines.",
+			},
+			"server_password": {
+				Type:        types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The password to access the machine that is searched, for store types that discover stores on remote machines.",
+			},
+			"server_use_ssl": {
+				Type:          types.BoolType,
+				Optional:      true,
+				Computed:      true,
+				Description:   "Whether the connection to the machine that is searched uses SSL. Defaults to `false`.",
+				PlanModifiers: []tfsdk.AttributePlanModifier{defaultValue(types.Bool{Value: false})},
+			},
+		},
+		Blocks: map[string]tfsdk.Block{
+			"schedule": {
+				NestingMode: tfsdk.BlockNestingModeList,
+				MaxItems:    1,
+				Description: "Schedule of the discovery job. Exactly one of `immediate`, `interval_minutes`, `daily_time`, `weekly_days` with `weekly_time` or `exactly_once` must be set. Defaults to a single immediate discovery job. Destroying the resource doesn't remove a recurring schedule from Keyfactor Command.",
+				Attributes:  jobScheduleAttributes("discovery", "the discovery job is created or updated", false),
+			},
+			"server_username_pam": pamReferenceBlock("server_username"),
+			"server_password_pam": pamReferenceBlock("server_password"),
+		},
+	}, nil
+}
+
+func (r resourceStoreDiscoveryJobType) NewResource(_ context.Context, p tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	return resourceStoreDiscoveryJob{
+		p: *(p.(*provider)),
+	}, nil
+}
+
+type resourceStoreDiscoveryJob struct {
+	p provider
+}
+
+func (r resourceStoreDiscoveryJob) ValidateConfig(ctx context.Context, request tfsdk.ValidateResourceConfigRequest, response *tfsdk.ValidateResourceConfigResponse) {
+	var config StoreDiscoveryJob
+	diags := request.Config.Get(ctx, &config)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	response.Diagnostics.Append(validateInventorySchedule(ctx, config.Schedule, path.Root("schedule"))...)
+	response.Diagnostics.Append(validatePamReferenceConflict("server_username", config.ServerUsername, config.ServerUsernamePam)...)
+	response.Diagnostics.Append(validatePamReferenceConflict("server_password", config.ServerPassword, config.ServerPasswordPam)...)
+}
+
+func (r resourceStoreDiscoveryJob) Create(ctx context.Context, request tfsdk.CreateResourceRequest, response *tfsdk.CreateResourceResponse) {
+	if !r.p.configured {
+		response.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. This leads to weird stuff happening, so we'd prefer if you didn't do that. Thanks!",
+		)
+		return
+	}
+
+	var plan StoreDiscoveryJob
+	diags := request.Plan.Get(ctx, &plan)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "agent_identifier", plan.AgentIdentifier.Value)
+	ctx = tflog.SetField(ctx, "store_type", plan.StoreType.Value)
+	tflog.Info(ctx, "Create called on store discovery job resource")
+
+	result, diags := submitStoreDiscoveryJob(ctx, r.p, plan)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	diags = response.State.Set(ctx, result)
+	response.Diagnostics.Append(diags...)
+}
+
+// Read keeps the state as is, because Keyfactor Command doesn't return the definition of discovery jobs once they are
+// submitted.
+func (r resourceStoreDiscoveryJob) Read(ctx context.Context, request tfsdk.ReadResourceRequest, response *tfsdk.ReadResourceResponse) {
+	var state StoreDiscoveryJob
+	diags := request.State.Get(ctx, &state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "id", state.ID.Value)
+	tflog.Info(ctx, "Read called on store discovery job resource")
+
+	diags = response.State.Set(ctx, state)
+	response.Diagnostics.Append(diags...)
+}
+
+func (r resourceStoreDiscoveryJob) Update(ctx context.Context, request tfsdk.UpdateResourceRequest, response *tfsdk.UpdateResourceResponse) {
+	var plan StoreDiscoveryJob
+	diags := request.Plan.Get(ctx, &plan)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "agent_identifier", plan.AgentIdentifier.Value)
+	ctx = tflog.SetField(ctx, "store_type", plan.StoreType.Value)
+	tflog.Info(ctx, "Update called on store discovery job resource")
+
+	// Submitting the discovery job again replaces the schedule of the agent and store type
+	result, diags := submitStoreDiscoveryJob(ctx, r.p, plan)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	diags = response.State.Set(ctx, result)
+	response.Diagnostics.Append(diags...)
+}
+
+// Delete only removes the discovery job from state. Certificate stores it has discovered are kept, and a recurring
+// schedule has to be removed in Keyfactor Command.
+func (r resourceStoreDiscoveryJob) Delete(ctx context.Context, request tfsdk.DeleteResourceRequest, response *tfsdk.DeleteResourceResponse) {
+	var state StoreDiscoveryJob
+	diags := request.State.Get(ctx, &state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "id", state.ID.Value)
+	tflog.Info(ctx, "Delete called on store discovery job resource")
+
+	var schedules []CertificateStoreInventorySchedule
+	diags = state.Schedule.ElementsAs(ctx, &schedules, true)
+	if !diags.HasError() && len(schedules) > 0 && !schedules[0].Immediate.Value && schedules[0].ExactlyOnce.Null {
+		response.Diagnostics.AddWarning(
+			"Recurring discovery job not removed.",
+			fmt.Sprintf("The discovery job '%s' was removed from Terraform state, but Keyfactor Command keeps running it on its schedule. Remove the schedule in the Keyfactor Command Portal.", state.ID.Value),
+		)
+	}
+
+	response.State.RemoveResource(ctx)
+}
+
+// lookupApprovedAgent returns the first approved orchestrator matching an agent GUID or client machine name.
+func lookupApprovedAgent(kfClient *api.Client, identifier string) (*api.Agent, error) {
+	agents, err := kfClient.GetAgent(identifier)
+	if err != nil {
+		return nil, err
+	}
+	for _, agent := range agents {
+		if agent.Status == AGENT_STATUS_APPROVED {
+			return &agent, nil
+		}
+	}
+	if len(agents) == 0 {
+		return nil, fmt.Errorf("no agent with identifier '%s' was found", identifier)
+	}
+	return nil, fmt.Errorf("no approved agents with identifier '%s' were found, review your agents in the Keyfactor Command Portal under Orchestrators > Management", identifier)
+}
+
+// submitStoreDiscoveryJob schedules a discovery job on Keyfactor Command and returns the resource state for it.
+func submitStoreDiscoveryJob(ctx context.Context, p provider, plan StoreDiscoveryJob) (StoreDiscoveryJob, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	agent, err := lookupApprovedAgent(p.client, plan.AgentIdentifier.Value)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("agent_identifier"),
+			ERR_SUMMARY_DISCOVERY_JOB_CONFIG,
+			fmt.Sprintf("Could not retrieve agent '%s' from Keyfactor Command: %s", plan.AgentIdentifier.Value, err.Error()),
+		)
+		return plan, diags
+	}
+
+	storeType, err := p.client.GetCertificateStoreTypeByName(plan.StoreType.Value)
+	if err != nil || storeType == nil {
+		diags.AddAttributeError(
+			path.Root("store_type"),
+			ERR_SUMMARY_DISCOVERY_JOB_CONFIG,
+			fmt.Sprintf("Could not retrieve certificate store type '%s' from Keyfactor Command: %v", plan.StoreType.Value, err),
+		)
+		return plan, diags
+	}
+
+	schedule, scheduleDiags := expandInventorySchedule(ctx, plan.Schedule)
+	diags.Append(scheduleDiags...)
+	if diags.HasError() {
+		return plan, diags
+	}
+	if len(plan.Schedule.Elems) == 0 {
+		schedule.Immediate = boolToPointer(true)
+	}
+
+	clientMachine := plan.ClientMachine.Value
+	if plan.ClientMachine.Null || plan.ClientMachine.Unknown {
+		clientMachine = agent.ClientMachine
+	}
+
+	body := &storeDiscoveryJobRequest{
+		ClientMachine: clientMachine,
+		AgentId:       agent.AgentId,
+		Type:          storeType.StoreType,
+		Schedule:      schedule,
+		SymLinks:      plan.FollowSymlinks.Value,
+		ServerUseSsl:  plan.ServerUseSsl.Value,
+	}
+	lists := []struct {
+		list   types.List
+		target *string
+	}{
+		{plan.Directories, &body.Dirs},
+		{plan.IgnoredDirectories, &body.IgnoredDirs},
+		{plan.Extensions, &body.Extensions},
+		{plan.NamePatterns, &body.NamePatterns},
+	}
+	for _, l := range lists {
+		if l.list.Null {
+			continue
+		}
+		var values []string
+		diags.Append(l.list.ElementsAs(ctx, &values, false)...)
+		*l.target = strings.Join(values, ",")
+	}
+	if diags.HasError() {
+		return plan, diags
+	}
+
+	if !plan.ServerUsername.Null {
+		body.ServerUsername = api.SecretParamValue{SecretValue: plan.ServerUsername.Value}
+	}
+	if !plan.ServerPassword.Null {
+		body.ServerPassword = api.SecretParamValue{SecretValue: plan.ServerPassword.Value}
+	}
+	usernameReference, referenceDiags := expandPamReference(ctx, p, plan.ServerUsernamePam, path.Root("server_username_pam"))
+	diags.Append(referenceDiags...)
+	passwordReference, referenceDiags := expandPamReference(ctx, p, plan.ServerPasswordPam, path.Root("server_password_pam"))
+	diags.Append(referenceDiags...)
+	if diags.HasError() {
+		return plan, diags
+	}
+	if usernameReference != nil {
+		body.ServerUsername = usernameReference
+	}
+	if passwordReference != nil {
+		body.ServerPassword = passwordReference
+	}
+
+	err = p.commandRequest(ctx, http.MethodPut, "CertificateStores/DiscoveryJob", nil, body, nil)
+	if err != nil {
+		diags.AddError(
+			ERR_SUMMARY_DISCOVERY_JOB_SUBMIT,
+			fmt.Sprintf("Could not schedule discovery of '%s' certificate stores on agent '%s': %s", plan.StoreType.Value, plan.AgentIdentifier.Value, err.Error()),
+		)
+		return plan, diags
+	}
+
+	result := plan
+	result.ID = types.String{Value: fmt.Sprintf("%s:%s", agent.AgentId, plan.StoreType.Value)}
+	result.AgentId = types.String{Value: agent.AgentId}
+	result.ClientMachine = types.String{Value: clientMachine}
+	return result, diags
+}
@@ -0,0 +1,103 @@
+package keyfactor
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"os"
+	"regexp"
+	"testing"
+)
+
+type storeDiscoveryJobTestCase struct {
+	agentIdentifier string
+	storeType       string
+	directory       string
+	extension       string
+	resourceName    string
+}
+
+func TestAccKeyfactorStoreDiscoveryJobResource(t *testing.T) {
+
+	r := storeDiscoveryJobTestCase{
+		agentIdentifier: os.Getenv("KEYFACTOR_CERTIFICATE_STORE_ORCHESTRATOR_AGENT_ID"),
+		storeType:       os.Getenv("KEYFACTOR_CERTIFICATE_STORE_TYPE"),
+		directory:       "/home",
+		extension:       "pem",
+		resourceName:    "keyfactor_store_discovery_job.tf_acc_test",
+	}
+	if r.storeType == "" {
+		r.storeType = "PEM"
+	}
+	r2 := r
+	r2.directory = "/opt"
+	r2.extension = "crt"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccKeyfactorStoreDiscoveryJobResourceConfig(r),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(r.resourceName, "id"),
+					resource.TestCheckResourceAttrSet(r.resourceName, "agent_id"),
+					resource.TestCheckResourceAttrSet(r.resourceName, "client_machine"),
+					resource.TestCheckResourceAttr(r.resourceName, "store_type", r.storeType),
+					resource.TestCheckResourceAttr(r.resourceName, "directories.0", r.directory),
+					resource.TestCheckResourceAttr(r.resourceName, "extensions.0", r.extension),
+					resource.TestCheckResourceAttr(r.resourceName, "follow_symlinks", "false"),
+					resource.TestCheckResourceAttr(r.resourceName, "schedule.0.immediate", "true"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccKeyfactorStoreDiscoveryJobResourceConfig(r2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(r2.resourceName, "directories.0", r2.directory),
+					resource.TestCheckResourceAttr(r2.resourceName, "extensions.0", r2.extension),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccKeyfactorStoreDiscoveryJobResource_InvalidSchedule(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "keyfactor_store_discovery_job" "tf_acc_test" {
+  agent_identifier = "tf-acc-test"
+  store_type       = "PEM"
+  directories      = ["fullscan"]
+  schedule {
+    immediate        = true
+    interval_minutes = 60
+  }
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Exactly one of"),
+			},
+		},
+	})
+}
+
+func testAccKeyfactorStoreDiscoveryJobResourceConfig(t storeDiscoveryJobTestCase) string {
+	output := fmt.Sprintf(`
+resource "keyfactor_store_discovery_job" "tf_acc_test" {
+  agent_identifier = "%s"
+  store_type       = "%s"
+  directories      = ["%s"]
+  extensions       = ["%s"]
+  schedule {
+    immediate = true
+  }
+}
+`, t.agentIdentifier, t.storeType, t.directory, t.extension)
+	return output
+}